@@ -24,6 +24,7 @@ const (
 	GatewayService_PushMsg_FullMethodName      = "/gateway.GatewayService/PushMsg"
 	GatewayService_BatchPushMsg_FullMethodName = "/gateway.GatewayService/BatchPushMsg"
 	GatewayService_CloseConn_FullMethodName    = "/gateway.GatewayService/CloseConn"
+	GatewayService_Broadcast_FullMethodName    = "/gateway.GatewayService/Broadcast"
 )
 
 // GatewayServiceClient is the client API for GatewayService service.
@@ -38,6 +39,8 @@ type GatewayServiceClient interface {
 	BatchPushMsg(ctx context.Context, in *BatchPushReq, opts ...grpc.CallOption) (*BatchPushResp, error)
 	// CloseConn 取消连接
 	CloseConn(ctx context.Context, in *CloseConnReq, opts ...grpc.CallOption) (*CloseConnResp, error)
+	// Broadcast 广播消息到本节点满足过滤条件的连接，topic 不为空时只推送给订阅了该主题的连接
+	Broadcast(ctx context.Context, in *BroadcastReq, opts ...grpc.CallOption) (*BroadcastResp, error)
 }
 
 type gatewayServiceClient struct {
@@ -78,6 +81,16 @@ func (c *gatewayServiceClient) CloseConn(ctx context.Context, in *CloseConnReq,
 	return out, nil
 }
 
+func (c *gatewayServiceClient) Broadcast(ctx context.Context, in *BroadcastReq, opts ...grpc.CallOption) (*BroadcastResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastResp)
+	err := c.cc.Invoke(ctx, GatewayService_Broadcast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GatewayServiceServer is the server API for GatewayService service.
 // All implementations must embed UnimplementedGatewayServiceServer
 // for forward compatibility.
@@ -90,6 +103,8 @@ type GatewayServiceServer interface {
 	BatchPushMsg(context.Context, *BatchPushReq) (*BatchPushResp, error)
 	// CloseConn 取消连接
 	CloseConn(context.Context, *CloseConnReq) (*CloseConnResp, error)
+	// Broadcast 广播消息到本节点满足过滤条件的连接，topic 不为空时只推送给订阅了该主题的连接
+	Broadcast(context.Context, *BroadcastReq) (*BroadcastResp, error)
 	mustEmbedUnimplementedGatewayServiceServer()
 }
 
@@ -109,6 +124,9 @@ func (UnimplementedGatewayServiceServer) BatchPushMsg(context.Context, *BatchPus
 func (UnimplementedGatewayServiceServer) CloseConn(context.Context, *CloseConnReq) (*CloseConnResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CloseConn not implemented")
 }
+func (UnimplementedGatewayServiceServer) Broadcast(context.Context, *BroadcastReq) (*BroadcastResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Broadcast not implemented")
+}
 func (UnimplementedGatewayServiceServer) mustEmbedUnimplementedGatewayServiceServer() {}
 func (UnimplementedGatewayServiceServer) testEmbeddedByValue()                        {}
 
@@ -184,6 +202,24 @@ func _GatewayService_CloseConn_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GatewayService_Broadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).Broadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_Broadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).Broadcast(ctx, req.(*BroadcastReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GatewayService_ServiceDesc is the grpc.ServiceDesc for GatewayService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -203,6 +239,10 @@ var GatewayService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CloseConn",
 			Handler:    _GatewayService_CloseConn_Handler,
 		},
+		{
+			MethodName: "Broadcast",
+			Handler:    _GatewayService_Broadcast_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "idl/gateway/gateway.proto",
@@ -21,7 +21,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MessageService_SendMessage_FullMethodName = "/message.MessageService/SendMessage"
+	MessageService_SendMessage_FullMethodName           = "/message.MessageService/SendMessage"
+	MessageService_SyncMessages_FullMethodName          = "/message.MessageService/SyncMessages"
+	MessageService_GetUnread_FullMethodName             = "/message.MessageService/GetUnread"
+	MessageService_ListConversations_FullMethodName     = "/message.MessageService/ListConversations"
+	MessageService_SetConversationPinned_FullMethodName = "/message.MessageService/SetConversationPinned"
+	MessageService_SetConversationMuted_FullMethodName  = "/message.MessageService/SetConversationMuted"
 )
 
 // MessageServiceClient is the client API for MessageService service.
@@ -30,6 +35,16 @@ const (
 type MessageServiceClient interface {
 	// SendMessage 发送消息
 	SendMessage(ctx context.Context, in *UpstreamRequest, opts ...grpc.CallOption) (*UpstreamResponse, error)
+	// SyncMessages 同步收件箱中的消息（离线消息）
+	SyncMessages(ctx context.Context, in *SyncMessagesReq, opts ...grpc.CallOption) (*SyncMessagesResp, error)
+	// GetUnread 获取用户各会话的未读数和已读位置
+	GetUnread(ctx context.Context, in *GetUnreadReq, opts ...grpc.CallOption) (*GetUnreadResp, error)
+	// ListConversations 按置顶和最近活跃时间分页获取用户的会话列表
+	ListConversations(ctx context.Context, in *ListConversationsReq, opts ...grpc.CallOption) (*ListConversationsResp, error)
+	// SetConversationPinned 设置会话置顶
+	SetConversationPinned(ctx context.Context, in *SetConversationPinnedReq, opts ...grpc.CallOption) (*SetConversationPinnedResp, error)
+	// SetConversationMuted 设置会话免打扰
+	SetConversationMuted(ctx context.Context, in *SetConversationMutedReq, opts ...grpc.CallOption) (*SetConversationMutedResp, error)
 }
 
 type messageServiceClient struct {
@@ -50,12 +65,72 @@ func (c *messageServiceClient) SendMessage(ctx context.Context, in *UpstreamRequ
 	return out, nil
 }
 
+func (c *messageServiceClient) SyncMessages(ctx context.Context, in *SyncMessagesReq, opts ...grpc.CallOption) (*SyncMessagesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncMessagesResp)
+	err := c.cc.Invoke(ctx, MessageService_SyncMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) GetUnread(ctx context.Context, in *GetUnreadReq, opts ...grpc.CallOption) (*GetUnreadResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUnreadResp)
+	err := c.cc.Invoke(ctx, MessageService_GetUnread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) ListConversations(ctx context.Context, in *ListConversationsReq, opts ...grpc.CallOption) (*ListConversationsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConversationsResp)
+	err := c.cc.Invoke(ctx, MessageService_ListConversations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) SetConversationPinned(ctx context.Context, in *SetConversationPinnedReq, opts ...grpc.CallOption) (*SetConversationPinnedResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetConversationPinnedResp)
+	err := c.cc.Invoke(ctx, MessageService_SetConversationPinned_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) SetConversationMuted(ctx context.Context, in *SetConversationMutedReq, opts ...grpc.CallOption) (*SetConversationMutedResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetConversationMutedResp)
+	err := c.cc.Invoke(ctx, MessageService_SetConversationMuted_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MessageServiceServer is the server API for MessageService service.
 // All implementations must embed UnimplementedMessageServiceServer
 // for forward compatibility.
 type MessageServiceServer interface {
 	// SendMessage 发送消息
 	SendMessage(context.Context, *UpstreamRequest) (*UpstreamResponse, error)
+	// SyncMessages 同步收件箱中的消息（离线消息）
+	SyncMessages(context.Context, *SyncMessagesReq) (*SyncMessagesResp, error)
+	// GetUnread 获取用户各会话的未读数和已读位置
+	GetUnread(context.Context, *GetUnreadReq) (*GetUnreadResp, error)
+	// ListConversations 按置顶和最近活跃时间分页获取用户的会话列表
+	ListConversations(context.Context, *ListConversationsReq) (*ListConversationsResp, error)
+	// SetConversationPinned 设置会话置顶
+	SetConversationPinned(context.Context, *SetConversationPinnedReq) (*SetConversationPinnedResp, error)
+	// SetConversationMuted 设置会话免打扰
+	SetConversationMuted(context.Context, *SetConversationMutedReq) (*SetConversationMutedResp, error)
 	mustEmbedUnimplementedMessageServiceServer()
 }
 
@@ -69,6 +144,21 @@ type UnimplementedMessageServiceServer struct{}
 func (UnimplementedMessageServiceServer) SendMessage(context.Context, *UpstreamRequest) (*UpstreamResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
 }
+func (UnimplementedMessageServiceServer) SyncMessages(context.Context, *SyncMessagesReq) (*SyncMessagesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncMessages not implemented")
+}
+func (UnimplementedMessageServiceServer) GetUnread(context.Context, *GetUnreadReq) (*GetUnreadResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnread not implemented")
+}
+func (UnimplementedMessageServiceServer) ListConversations(context.Context, *ListConversationsReq) (*ListConversationsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConversations not implemented")
+}
+func (UnimplementedMessageServiceServer) SetConversationPinned(context.Context, *SetConversationPinnedReq) (*SetConversationPinnedResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConversationPinned not implemented")
+}
+func (UnimplementedMessageServiceServer) SetConversationMuted(context.Context, *SetConversationMutedReq) (*SetConversationMutedResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConversationMuted not implemented")
+}
 func (UnimplementedMessageServiceServer) mustEmbedUnimplementedMessageServiceServer() {}
 func (UnimplementedMessageServiceServer) testEmbeddedByValue()                        {}
 
@@ -108,6 +198,96 @@ func _MessageService_SendMessage_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MessageService_SyncMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncMessagesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).SyncMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_SyncMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).SyncMessages(ctx, req.(*SyncMessagesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_GetUnread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnreadReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).GetUnread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_GetUnread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).GetUnread(ctx, req.(*GetUnreadReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_ListConversations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConversationsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).ListConversations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_ListConversations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).ListConversations(ctx, req.(*ListConversationsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_SetConversationPinned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConversationPinnedReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).SetConversationPinned(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_SetConversationPinned_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).SetConversationPinned(ctx, req.(*SetConversationPinnedReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_SetConversationMuted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConversationMutedReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).SetConversationMuted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_SetConversationMuted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).SetConversationMuted(ctx, req.(*SetConversationMutedReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MessageService_ServiceDesc is the grpc.ServiceDesc for MessageService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -119,6 +299,26 @@ var MessageService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendMessage",
 			Handler:    _MessageService_SendMessage_Handler,
 		},
+		{
+			MethodName: "SyncMessages",
+			Handler:    _MessageService_SyncMessages_Handler,
+		},
+		{
+			MethodName: "GetUnread",
+			Handler:    _MessageService_GetUnread_Handler,
+		},
+		{
+			MethodName: "ListConversations",
+			Handler:    _MessageService_ListConversations_Handler,
+		},
+		{
+			MethodName: "SetConversationPinned",
+			Handler:    _MessageService_SetConversationPinned_Handler,
+		},
+		{
+			MethodName: "SetConversationMuted",
+			Handler:    _MessageService_SetConversationMuted_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "idl/message/message.proto",
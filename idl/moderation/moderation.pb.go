@@ -0,0 +1,448 @@
+//protoc --go_out=. --go-grpc_out=. moderation.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: idl/moderation/moderation.proto
+
+package moderation
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ModerateAction 审核结果
+type ModerateAction int32
+
+const (
+	// MODERATE_ACTION_ACCEPT 通过
+	ModerateAction_MODERATE_ACTION_ACCEPT ModerateAction = 0
+	// MODERATE_ACTION_REJECT 拒绝，整条消息不投递
+	ModerateAction_MODERATE_ACTION_REJECT ModerateAction = 1
+	// MODERATE_ACTION_REWRITE 改写，使用返回的内容元素替换原元素
+	ModerateAction_MODERATE_ACTION_REWRITE ModerateAction = 2
+)
+
+// Enum value maps for ModerateAction.
+var (
+	ModerateAction_name = map[int32]string{
+		0: "MODERATE_ACTION_ACCEPT",
+		1: "MODERATE_ACTION_REJECT",
+		2: "MODERATE_ACTION_REWRITE",
+	}
+	ModerateAction_value = map[string]int32{
+		"MODERATE_ACTION_ACCEPT":  0,
+		"MODERATE_ACTION_REJECT":  1,
+		"MODERATE_ACTION_REWRITE": 2,
+	}
+)
+
+func (x ModerateAction) Enum() *ModerateAction {
+	p := new(ModerateAction)
+	*p = x
+	return p
+}
+
+func (x ModerateAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModerateAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_moderation_moderation_proto_enumTypes[0].Descriptor()
+}
+
+func (ModerateAction) Type() protoreflect.EnumType {
+	return &file_idl_moderation_moderation_proto_enumTypes[0]
+}
+
+func (x ModerateAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModerateAction.Descriptor instead.
+func (ModerateAction) EnumDescriptor() ([]byte, []int) {
+	return file_idl_moderation_moderation_proto_rawDescGZIP(), []int{0}
+}
+
+// ModerateReq 审核请求
+type ModerateReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// sender_id 发送者ID
+	SenderId string `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	// receiver_id 接收者ID（单聊）
+	ReceiverId string `protobuf:"bytes,2,opt,name=receiver_id,json=receiverId,proto3" json:"receiver_id,omitempty"`
+	// group_id 群组ID（群聊）
+	GroupId int64 `protobuf:"varint,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// msg_type 消息类型（message.MessageType）
+	MsgType int32 `protobuf:"varint,4,opt,name=msg_type,json=msgType,proto3" json:"msg_type,omitempty"`
+	// element_type 内容元素类型（message.MessageElementType）
+	ElementType int32 `protobuf:"varint,5,opt,name=element_type,json=elementType,proto3" json:"element_type,omitempty"`
+	// element 内容元素（message.MessageContent 序列化后的字节）
+	Element []byte `protobuf:"bytes,6,opt,name=element,proto3" json:"element,omitempty"`
+}
+
+func (x *ModerateReq) Reset() {
+	*x = ModerateReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_moderation_moderation_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModerateReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateReq) ProtoMessage() {}
+
+func (x *ModerateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_moderation_moderation_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateReq.ProtoReflect.Descriptor instead.
+func (*ModerateReq) Descriptor() ([]byte, []int) {
+	return file_idl_moderation_moderation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ModerateReq) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *ModerateReq) GetReceiverId() string {
+	if x != nil {
+		return x.ReceiverId
+	}
+	return ""
+}
+
+func (x *ModerateReq) GetGroupId() int64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *ModerateReq) GetMsgType() int32 {
+	if x != nil {
+		return x.MsgType
+	}
+	return 0
+}
+
+func (x *ModerateReq) GetElementType() int32 {
+	if x != nil {
+		return x.ElementType
+	}
+	return 0
+}
+
+func (x *ModerateReq) GetElement() []byte {
+	if x != nil {
+		return x.Element
+	}
+	return nil
+}
+
+// ModerateResp 审核响应
+type ModerateResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// code 响应码，0表示成功，非0表示失败
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// message 响应消息，通常用于错误描述
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// data 审核结果
+	Data *ModerateData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ModerateResp) Reset() {
+	*x = ModerateResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_moderation_moderation_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModerateResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateResp) ProtoMessage() {}
+
+func (x *ModerateResp) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_moderation_moderation_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateResp.ProtoReflect.Descriptor instead.
+func (*ModerateResp) Descriptor() ([]byte, []int) {
+	return file_idl_moderation_moderation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ModerateResp) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ModerateResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ModerateResp) GetData() *ModerateData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ModerateData 审核结果
+type ModerateData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// action 审核结果
+	Action ModerateAction `protobuf:"varint,1,opt,name=action,proto3,enum=moderation.ModerateAction" json:"action,omitempty"`
+	// element 改写后的内容元素（message.MessageContent 序列化后的字节，action 为 REWRITE 时有效）
+	Element []byte `protobuf:"bytes,2,opt,name=element,proto3" json:"element,omitempty"`
+	// reason 拒绝原因（action 为 REJECT 时有效，返回给发送者）
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *ModerateData) Reset() {
+	*x = ModerateData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_moderation_moderation_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModerateData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateData) ProtoMessage() {}
+
+func (x *ModerateData) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_moderation_moderation_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateData.ProtoReflect.Descriptor instead.
+func (*ModerateData) Descriptor() ([]byte, []int) {
+	return file_idl_moderation_moderation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ModerateData) GetAction() ModerateAction {
+	if x != nil {
+		return x.Action
+	}
+	return ModerateAction_MODERATE_ACTION_ACCEPT
+}
+
+func (x *ModerateData) GetElement() []byte {
+	if x != nil {
+		return x.Element
+	}
+	return nil
+}
+
+func (x *ModerateData) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+var File_idl_moderation_moderation_proto protoreflect.FileDescriptor
+
+var file_idl_moderation_moderation_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x69, 0x64, 0x6c, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0a, 0x6d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xbe, 0x01,
+	0x0a, 0x0b, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x1b, 0x0a,
+	0x09, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x73, 0x67, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x6a,
+	0x0a, 0x0c, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x74, 0x0a, 0x0c, 0x4d, 0x6f,
+	0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x32, 0x0a, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18,
+	0x0a, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x2a, 0x65, 0x0a, 0x0e, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x16, 0x4d, 0x4f, 0x44, 0x45, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x41,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x50, 0x54, 0x10, 0x00, 0x12, 0x1a,
+	0x0a, 0x16, 0x4d, 0x4f, 0x44, 0x45, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x4d, 0x4f,
+	0x44, 0x45, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45,
+	0x57, 0x52, 0x49, 0x54, 0x45, 0x10, 0x02, 0x32, 0x52, 0x0a, 0x11, 0x4d, 0x6f, 0x64, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x08,
+	0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x1a, 0x18, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d,
+	0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x42, 0x0f, 0x5a, 0x0d, 0x2e,
+	0x2f, 0x3b, 0x6d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_idl_moderation_moderation_proto_rawDescOnce sync.Once
+	file_idl_moderation_moderation_proto_rawDescData = file_idl_moderation_moderation_proto_rawDesc
+)
+
+func file_idl_moderation_moderation_proto_rawDescGZIP() []byte {
+	file_idl_moderation_moderation_proto_rawDescOnce.Do(func() {
+		file_idl_moderation_moderation_proto_rawDescData = protoimpl.X.CompressGZIP(file_idl_moderation_moderation_proto_rawDescData)
+	})
+	return file_idl_moderation_moderation_proto_rawDescData
+}
+
+var file_idl_moderation_moderation_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_idl_moderation_moderation_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_idl_moderation_moderation_proto_goTypes = []interface{}{
+	(ModerateAction)(0),  // 0: moderation.ModerateAction
+	(*ModerateReq)(nil),  // 1: moderation.ModerateReq
+	(*ModerateResp)(nil), // 2: moderation.ModerateResp
+	(*ModerateData)(nil), // 3: moderation.ModerateData
+}
+var file_idl_moderation_moderation_proto_depIdxs = []int32{
+	3, // 0: moderation.ModerateResp.data:type_name -> moderation.ModerateData
+	0, // 1: moderation.ModerateData.action:type_name -> moderation.ModerateAction
+	1, // 2: moderation.ModerationService.Moderate:input_type -> moderation.ModerateReq
+	2, // 3: moderation.ModerationService.Moderate:output_type -> moderation.ModerateResp
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_idl_moderation_moderation_proto_init() }
+func file_idl_moderation_moderation_proto_init() {
+	if File_idl_moderation_moderation_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_idl_moderation_moderation_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModerateReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_moderation_moderation_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModerateResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_moderation_moderation_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModerateData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_idl_moderation_moderation_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_idl_moderation_moderation_proto_goTypes,
+		DependencyIndexes: file_idl_moderation_moderation_proto_depIdxs,
+		EnumInfos:         file_idl_moderation_moderation_proto_enumTypes,
+		MessageInfos:      file_idl_moderation_moderation_proto_msgTypes,
+	}.Build()
+	File_idl_moderation_moderation_proto = out.File
+	file_idl_moderation_moderation_proto_rawDesc = nil
+	file_idl_moderation_moderation_proto_goTypes = nil
+	file_idl_moderation_moderation_proto_depIdxs = nil
+}
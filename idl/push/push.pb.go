@@ -34,6 +34,12 @@ type PushReq struct {
 	DeviceId string `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
 	// msg 消息内容
 	Msg []byte `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// exclude_conn_id 不推送的连接ID（可选，多端同步时排除发送消息的连接）
+	ExcludeConnId uint64 `protobuf:"varint,4,opt,name=exclude_conn_id,json=excludeConnId,proto3" json:"exclude_conn_id,omitempty"`
+	// async 是否异步推送：放入推送队列后立即返回推送ID，失败时按退避策略重试
+	Async bool `protobuf:"varint,5,opt,name=async,proto3" json:"async,omitempty"`
+	// priority 是否高优先级推送（如@提醒），不受会话免打扰等推送抑制的影响
+	Priority bool `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
 }
 
 func (x *PushReq) Reset() {
@@ -89,6 +95,27 @@ func (x *PushReq) GetMsg() []byte {
 	return nil
 }
 
+func (x *PushReq) GetExcludeConnId() uint64 {
+	if x != nil {
+		return x.ExcludeConnId
+	}
+	return 0
+}
+
+func (x *PushReq) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *PushReq) GetPriority() bool {
+	if x != nil {
+		return x.Priority
+	}
+	return false
+}
+
 // PushResp 推送消息响应
 type PushResp struct {
 	state         protoimpl.MessageState
@@ -99,6 +126,8 @@ type PushResp struct {
 	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	// message 响应消息，通常用于错误描述
 	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// data 返回数据（异步推送时有效）
+	Data *PushData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 }
 
 func (x *PushResp) Reset() {
@@ -147,6 +176,62 @@ func (x *PushResp) GetMessage() string {
 	return ""
 }
 
+func (x *PushResp) GetData() *PushData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// PushData 推送消息响应数据
+type PushData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// push_id 异步推送任务ID
+	PushId string `protobuf:"bytes,1,opt,name=push_id,json=pushId,proto3" json:"push_id,omitempty"`
+}
+
+func (x *PushData) Reset() {
+	*x = PushData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushData) ProtoMessage() {}
+
+func (x *PushData) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushData.ProtoReflect.Descriptor instead.
+func (*PushData) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PushData) GetPushId() string {
+	if x != nil {
+		return x.PushId
+	}
+	return ""
+}
+
 // BatchPushReq 批量推送消息请求
 type BatchPushReq struct {
 	state         protoimpl.MessageState
@@ -156,13 +241,15 @@ type BatchPushReq struct {
 	// targets 推送目标列表
 	Targets []*PushTarget `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
 	// msg 消息内容
-	Msg string `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Msg []byte `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	// priority 是否高优先级推送（如@提醒、@所有人），不受会话免打扰等推送抑制的影响
+	Priority bool `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
 }
 
 func (x *BatchPushReq) Reset() {
 	*x = BatchPushReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_idl_push_push_proto_msgTypes[2]
+		mi := &file_idl_push_push_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -175,7 +262,7 @@ func (x *BatchPushReq) String() string {
 func (*BatchPushReq) ProtoMessage() {}
 
 func (x *BatchPushReq) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_push_push_proto_msgTypes[2]
+	mi := &file_idl_push_push_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -188,7 +275,7 @@ func (x *BatchPushReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchPushReq.ProtoReflect.Descriptor instead.
 func (*BatchPushReq) Descriptor() ([]byte, []int) {
-	return file_idl_push_push_proto_rawDescGZIP(), []int{2}
+	return file_idl_push_push_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *BatchPushReq) GetTargets() []*PushTarget {
@@ -198,11 +285,18 @@ func (x *BatchPushReq) GetTargets() []*PushTarget {
 	return nil
 }
 
-func (x *BatchPushReq) GetMsg() string {
+func (x *BatchPushReq) GetMsg() []byte {
 	if x != nil {
 		return x.Msg
 	}
-	return ""
+	return nil
+}
+
+func (x *BatchPushReq) GetPriority() bool {
+	if x != nil {
+		return x.Priority
+	}
+	return false
 }
 
 // PushTarget 推送目标
@@ -215,12 +309,14 @@ type PushTarget struct {
 	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	// device_id 设备ID（可选，指定设备推送）
 	DeviceId string `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// msg 该目标单独的消息内容（可选，为空时使用 BatchPushReq.msg），如携带接收方收件箱序号的消息
+	Msg []byte `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
 }
 
 func (x *PushTarget) Reset() {
 	*x = PushTarget{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_idl_push_push_proto_msgTypes[3]
+		mi := &file_idl_push_push_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -233,7 +329,7 @@ func (x *PushTarget) String() string {
 func (*PushTarget) ProtoMessage() {}
 
 func (x *PushTarget) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_push_push_proto_msgTypes[3]
+	mi := &file_idl_push_push_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -246,7 +342,7 @@ func (x *PushTarget) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PushTarget.ProtoReflect.Descriptor instead.
 func (*PushTarget) Descriptor() ([]byte, []int) {
-	return file_idl_push_push_proto_rawDescGZIP(), []int{3}
+	return file_idl_push_push_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *PushTarget) GetUserId() string {
@@ -263,6 +359,13 @@ func (x *PushTarget) GetDeviceId() string {
 	return ""
 }
 
+func (x *PushTarget) GetMsg() []byte {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
 // BatchPushResp 批量推送消息响应
 type BatchPushResp struct {
 	state         protoimpl.MessageState
@@ -280,7 +383,7 @@ type BatchPushResp struct {
 func (x *BatchPushResp) Reset() {
 	*x = BatchPushResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_idl_push_push_proto_msgTypes[4]
+		mi := &file_idl_push_push_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -293,7 +396,7 @@ func (x *BatchPushResp) String() string {
 func (*BatchPushResp) ProtoMessage() {}
 
 func (x *BatchPushResp) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_push_push_proto_msgTypes[4]
+	mi := &file_idl_push_push_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -306,7 +409,7 @@ func (x *BatchPushResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchPushResp.ProtoReflect.Descriptor instead.
 func (*BatchPushResp) Descriptor() ([]byte, []int) {
-	return file_idl_push_push_proto_rawDescGZIP(), []int{4}
+	return file_idl_push_push_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *BatchPushResp) GetCode() int32 {
@@ -349,7 +452,7 @@ type PushResult struct {
 func (x *PushResult) Reset() {
 	*x = PushResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_idl_push_push_proto_msgTypes[5]
+		mi := &file_idl_push_push_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -362,7 +465,7 @@ func (x *PushResult) String() string {
 func (*PushResult) ProtoMessage() {}
 
 func (x *PushResult) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_push_push_proto_msgTypes[5]
+	mi := &file_idl_push_push_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -375,7 +478,7 @@ func (x *PushResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PushResult.ProtoReflect.Descriptor instead.
 func (*PushResult) Descriptor() ([]byte, []int) {
-	return file_idl_push_push_proto_rawDescGZIP(), []int{5}
+	return file_idl_push_push_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *PushResult) GetUserId() string {
@@ -421,7 +524,7 @@ type CloseConnReq struct {
 func (x *CloseConnReq) Reset() {
 	*x = CloseConnReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_idl_push_push_proto_msgTypes[6]
+		mi := &file_idl_push_push_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -434,7 +537,7 @@ func (x *CloseConnReq) String() string {
 func (*CloseConnReq) ProtoMessage() {}
 
 func (x *CloseConnReq) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_push_push_proto_msgTypes[6]
+	mi := &file_idl_push_push_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -447,7 +550,7 @@ func (x *CloseConnReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseConnReq.ProtoReflect.Descriptor instead.
 func (*CloseConnReq) Descriptor() ([]byte, []int) {
-	return file_idl_push_push_proto_rawDescGZIP(), []int{6}
+	return file_idl_push_push_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CloseConnReq) GetUserId() string {
@@ -479,7 +582,7 @@ type CloseConnResp struct {
 func (x *CloseConnResp) Reset() {
 	*x = CloseConnResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_idl_push_push_proto_msgTypes[7]
+		mi := &file_idl_push_push_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -492,7 +595,7 @@ func (x *CloseConnResp) String() string {
 func (*CloseConnResp) ProtoMessage() {}
 
 func (x *CloseConnResp) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_push_push_proto_msgTypes[7]
+	mi := &file_idl_push_push_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,7 +608,7 @@ func (x *CloseConnResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseConnResp.ProtoReflect.Descriptor instead.
 func (*CloseConnResp) Descriptor() ([]byte, []int) {
-	return file_idl_push_push_proto_rawDescGZIP(), []int{7}
+	return file_idl_push_push_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CloseConnResp) GetCode() int32 {
@@ -522,137 +625,1123 @@ func (x *CloseConnResp) GetMessage() string {
 	return ""
 }
 
-var File_idl_push_push_proto protoreflect.FileDescriptor
+// DeadLetter 重试次数用尽的异步推送任务
+type DeadLetter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_idl_push_push_proto_rawDesc = []byte{
-	0x0a, 0x13, 0x69, 0x64, 0x6c, 0x2f, 0x70, 0x75, 0x73, 0x68, 0x2f, 0x70, 0x75, 0x73, 0x68, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x70, 0x75, 0x73, 0x68, 0x22, 0x51, 0x0a, 0x07, 0x50,
-	0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03,
-	0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x38,
-	0x0a, 0x08, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
-	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x4c, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x63,
-	0x68, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x12, 0x2a, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x75, 0x73, 0x68,
-	0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x07, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x42, 0x0a, 0x0a, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a,
-	0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x69, 0x0a, 0x0d, 0x42, 0x61,
-	0x74, 0x63, 0x68, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x63,
-	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x72, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x75, 0x73,
-	0x68, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x70, 0x0a, 0x0a, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09,
-	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x44, 0x0a, 0x0c, 0x43, 0x6c, 0x6f, 0x73, 0x65,
-	0x43, 0x6f, 0x6e, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
-	0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x3d, 0x0a,
-	0x0d, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12,
-	0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f,
-	0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xa6, 0x01, 0x0a,
-	0x0b, 0x50, 0x75, 0x73, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x07,
-	0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x12, 0x0d, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x50,
-	0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x50, 0x75,
-	0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x12, 0x37, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50,
-	0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x12, 0x12, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x42, 0x61,
-	0x74, 0x63, 0x68, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x70, 0x75, 0x73,
-	0x68, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x12,
-	0x34, 0x0a, 0x09, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x12, 0x12, 0x2e, 0x70,
-	0x75, 0x73, 0x68, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x52, 0x65, 0x71,
-	0x1a, 0x13, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x6e,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f, 0x3b, 0x70, 0x75, 0x73, 0x68,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	// push_id 异步推送任务ID
+	PushId string `protobuf:"bytes,1,opt,name=push_id,json=pushId,proto3" json:"push_id,omitempty"`
+	// req 推送请求
+	Req *PushReq `protobuf:"bytes,2,opt,name=req,proto3" json:"req,omitempty"`
+	// attempts 已尝试次数
+	Attempts int32 `protobuf:"varint,3,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// last_error 最后一次失败的原因
+	LastError string `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// created_at 任务创建时间戳（毫秒）
+	CreatedAt int64 `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// failed_at 进入死信的时间戳（毫秒）
+	FailedAt int64 `protobuf:"varint,6,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var (
-	file_idl_push_push_proto_rawDescOnce sync.Once
-	file_idl_push_push_proto_rawDescData = file_idl_push_push_proto_rawDesc
-)
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_idl_push_push_proto_rawDescGZIP() []byte {
-	file_idl_push_push_proto_rawDescOnce.Do(func() {
-		file_idl_push_push_proto_rawDescData = protoimpl.X.CompressGZIP(file_idl_push_push_proto_rawDescData)
-	})
-	return file_idl_push_push_proto_rawDescData
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_idl_push_push_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_idl_push_push_proto_goTypes = []interface{}{
-	(*PushReq)(nil),       // 0: push.PushReq
-	(*PushResp)(nil),      // 1: push.PushResp
-	(*BatchPushReq)(nil),  // 2: push.BatchPushReq
-	(*PushTarget)(nil),    // 3: push.PushTarget
-	(*BatchPushResp)(nil), // 4: push.BatchPushResp
-	(*PushResult)(nil),    // 5: push.PushResult
-	(*CloseConnReq)(nil),  // 6: push.CloseConnReq
-	(*CloseConnResp)(nil), // 7: push.CloseConnResp
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{9}
 }
-var file_idl_push_push_proto_depIdxs = []int32{
-	3, // 0: push.BatchPushReq.targets:type_name -> push.PushTarget
-	5, // 1: push.BatchPushResp.results:type_name -> push.PushResult
-	0, // 2: push.PushService.PushMsg:input_type -> push.PushReq
-	2, // 3: push.PushService.BatchPushMsg:input_type -> push.BatchPushReq
-	6, // 4: push.PushService.CloseConn:input_type -> push.CloseConnReq
-	1, // 5: push.PushService.PushMsg:output_type -> push.PushResp
-	4, // 6: push.PushService.BatchPushMsg:output_type -> push.BatchPushResp
-	7, // 7: push.PushService.CloseConn:output_type -> push.CloseConnResp
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+
+func (x *DeadLetter) GetPushId() string {
+	if x != nil {
+		return x.PushId
+	}
+	return ""
 }
 
-func init() { file_idl_push_push_proto_init() }
-func file_idl_push_push_proto_init() {
-	if File_idl_push_push_proto != nil {
-		return
+func (x *DeadLetter) GetReq() *PushReq {
+	if x != nil {
+		return x.Req
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_idl_push_push_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushReq); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return nil
+}
+
+func (x *DeadLetter) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetFailedAt() int64 {
+	if x != nil {
+		return x.FailedAt
+	}
+	return 0
+}
+
+// ListDeadLettersReq 查看死信任务请求
+type ListDeadLettersReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// offset 偏移量（按进入死信的时间倒序）
+	Offset int32 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit 返回数量
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListDeadLettersReq) Reset() {
+	*x = ListDeadLettersReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeadLettersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersReq) ProtoMessage() {}
+
+func (x *ListDeadLettersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_idl_push_push_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushResp); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersReq.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersReq) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListDeadLettersReq) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListDeadLettersReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListDeadLettersResp 查看死信任务响应
+type ListDeadLettersResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// code 响应码，0表示成功，非0表示失败
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// message 响应消息，通常用于错误描述
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// data 返回数据
+	Data *ListDeadLettersData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ListDeadLettersResp) Reset() {
+	*x = ListDeadLettersResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeadLettersResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersResp) ProtoMessage() {}
+
+func (x *ListDeadLettersResp) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_idl_push_push_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchPushReq); i {
-			case 0:
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersResp.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResp) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListDeadLettersResp) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ListDeadLettersResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListDeadLettersResp) GetData() *ListDeadLettersData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ListDeadLettersData 查看死信任务响应数据
+type ListDeadLettersData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// dead_letters 死信任务列表
+	DeadLetters []*DeadLetter `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	// total 死信任务总数
+	Total int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *ListDeadLettersData) Reset() {
+	*x = ListDeadLettersData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeadLettersData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersData) ProtoMessage() {}
+
+func (x *ListDeadLettersData) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersData.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersData) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListDeadLettersData) GetDeadLetters() []*DeadLetter {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+func (x *ListDeadLettersData) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// ReplayDeadLettersReq 重放死信任务请求
+type ReplayDeadLettersReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// push_ids 需要重放的任务ID
+	PushIds []string `protobuf:"bytes,1,rep,name=push_ids,json=pushIds,proto3" json:"push_ids,omitempty"`
+}
+
+func (x *ReplayDeadLettersReq) Reset() {
+	*x = ReplayDeadLettersReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplayDeadLettersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayDeadLettersReq) ProtoMessage() {}
+
+func (x *ReplayDeadLettersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayDeadLettersReq.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLettersReq) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReplayDeadLettersReq) GetPushIds() []string {
+	if x != nil {
+		return x.PushIds
+	}
+	return nil
+}
+
+// ReplayDeadLettersResp 重放死信任务响应
+type ReplayDeadLettersResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// code 响应码，0表示成功，非0表示失败
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// message 响应消息，通常用于错误描述
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// data 返回数据
+	Data *ReplayDeadLettersData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ReplayDeadLettersResp) Reset() {
+	*x = ReplayDeadLettersResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplayDeadLettersResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayDeadLettersResp) ProtoMessage() {}
+
+func (x *ReplayDeadLettersResp) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayDeadLettersResp.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLettersResp) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReplayDeadLettersResp) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ReplayDeadLettersResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReplayDeadLettersResp) GetData() *ReplayDeadLettersData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ReplayDeadLettersData 重放死信任务响应数据
+type ReplayDeadLettersData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// replayed 重新入队的任务ID（不存在的任务ID不返回）
+	Replayed []string `protobuf:"bytes,1,rep,name=replayed,proto3" json:"replayed,omitempty"`
+}
+
+func (x *ReplayDeadLettersData) Reset() {
+	*x = ReplayDeadLettersData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplayDeadLettersData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayDeadLettersData) ProtoMessage() {}
+
+func (x *ReplayDeadLettersData) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayDeadLettersData.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLettersData) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReplayDeadLettersData) GetReplayed() []string {
+	if x != nil {
+		return x.Replayed
+	}
+	return nil
+}
+
+// BroadcastFilter 广播过滤条件，由各个 Gateway 在本地匹配连接，多个条件同时满足才推送，未设置的条件不过滤
+type BroadcastFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// device_types 设备类型，取值同 session.DeviceType
+	DeviceTypes []int32 `protobuf:"varint,1,rep,packed,name=device_types,json=deviceTypes,proto3" json:"device_types,omitempty"`
+	// min_app_version 最低应用版本号（包含），按点分隔的数字逐段比较，如 1.10.0 > 1.9.3
+	MinAppVersion string `protobuf:"bytes,2,opt,name=min_app_version,json=minAppVersion,proto3" json:"min_app_version,omitempty"`
+	// max_app_version 最高应用版本号（包含）
+	MaxAppVersion string `protobuf:"bytes,3,opt,name=max_app_version,json=maxAppVersion,proto3" json:"max_app_version,omitempty"`
+	// meta 会话扩展信息，key 必须存在，value 不为空时还需要相等
+	Meta map[string]string `protobuf:"bytes,4,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *BroadcastFilter) Reset() {
+	*x = BroadcastFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastFilter) ProtoMessage() {}
+
+func (x *BroadcastFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastFilter.ProtoReflect.Descriptor instead.
+func (*BroadcastFilter) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BroadcastFilter) GetDeviceTypes() []int32 {
+	if x != nil {
+		return x.DeviceTypes
+	}
+	return nil
+}
+
+func (x *BroadcastFilter) GetMinAppVersion() string {
+	if x != nil {
+		return x.MinAppVersion
+	}
+	return ""
+}
+
+func (x *BroadcastFilter) GetMaxAppVersion() string {
+	if x != nil {
+		return x.MaxAppVersion
+	}
+	return ""
+}
+
+func (x *BroadcastFilter) GetMeta() map[string]string {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+// BroadcastReq 广播消息请求
+type BroadcastReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// msg 消息内容
+	Msg []byte `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	// filter 过滤条件（可选）
+	Filter *BroadcastFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *BroadcastReq) Reset() {
+	*x = BroadcastReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastReq) ProtoMessage() {}
+
+func (x *BroadcastReq) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastReq.ProtoReflect.Descriptor instead.
+func (*BroadcastReq) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BroadcastReq) GetMsg() []byte {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *BroadcastReq) GetFilter() *BroadcastFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// BroadcastResp 广播消息响应
+type BroadcastResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// code 响应码，0表示成功，非0表示失败
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// message 响应消息，通常用于错误描述
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// data 返回数据
+	Data *BroadcastData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *BroadcastResp) Reset() {
+	*x = BroadcastResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastResp) ProtoMessage() {}
+
+func (x *BroadcastResp) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastResp.ProtoReflect.Descriptor instead.
+func (*BroadcastResp) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BroadcastResp) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BroadcastResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BroadcastResp) GetData() *BroadcastData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// BroadcastData 广播结果
+type BroadcastData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// delivered 推送成功的连接数量
+	Delivered int64 `protobuf:"varint,1,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	// failed_gateways 推送失败的 Gateway 节点ID，这些节点上的连接没有收到消息
+	FailedGateways []string `protobuf:"bytes,2,rep,name=failed_gateways,json=failedGateways,proto3" json:"failed_gateways,omitempty"`
+}
+
+func (x *BroadcastData) Reset() {
+	*x = BroadcastData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastData) ProtoMessage() {}
+
+func (x *BroadcastData) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastData.ProtoReflect.Descriptor instead.
+func (*BroadcastData) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BroadcastData) GetDelivered() int64 {
+	if x != nil {
+		return x.Delivered
+	}
+	return 0
+}
+
+func (x *BroadcastData) GetFailedGateways() []string {
+	if x != nil {
+		return x.FailedGateways
+	}
+	return nil
+}
+
+// PublishReq 发布主题消息请求
+type PublishReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// topic 主题
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// msg 消息内容
+	Msg []byte `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	// filter 过滤条件（可选）
+	Filter *BroadcastFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *PublishReq) Reset() {
+	*x = PublishReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublishReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishReq) ProtoMessage() {}
+
+func (x *PublishReq) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishReq.ProtoReflect.Descriptor instead.
+func (*PublishReq) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PublishReq) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *PublishReq) GetMsg() []byte {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *PublishReq) GetFilter() *BroadcastFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// PublishResp 发布主题消息响应
+type PublishResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// code 响应码，0表示成功，非0表示失败
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// message 响应消息，通常用于错误描述
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// data 返回数据
+	Data *BroadcastData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *PublishResp) Reset() {
+	*x = PublishResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_idl_push_push_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublishResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishResp) ProtoMessage() {}
+
+func (x *PublishResp) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_push_push_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishResp.ProtoReflect.Descriptor instead.
+func (*PublishResp) Descriptor() ([]byte, []int) {
+	return file_idl_push_push_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PublishResp) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *PublishResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PublishResp) GetData() *BroadcastData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_idl_push_push_proto protoreflect.FileDescriptor
+
+var file_idl_push_push_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x69, 0x64, 0x6c, 0x2f, 0x70, 0x75, 0x73, 0x68, 0x2f, 0x70, 0x75, 0x73, 0x68, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x70, 0x75, 0x73, 0x68, 0x22, 0xab, 0x01, 0x0a, 0x07,
+	0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12,
+	0x26, 0x0a, 0x0f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x73, 0x79, 0x6e, 0x63,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x73, 0x79, 0x6e, 0x63, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x5c, 0x0a, 0x08, 0x50, 0x75, 0x73,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x44, 0x61, 0x74,
+	0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x23, 0x0a, 0x08, 0x50, 0x75, 0x73, 0x68, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x73, 0x68, 0x49, 0x64, 0x22, 0x68, 0x0a, 0x0c,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x12, 0x2a, 0x0a, 0x07,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x70, 0x75, 0x73, 0x68, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52,
+	0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x54, 0x0a, 0x0a, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a,
+	0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73,
+	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x69, 0x0a, 0x0d,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70,
+	0x75, 0x73, 0x68, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x70, 0x0a, 0x0a, 0x50, 0x75, 0x73, 0x68, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b,
+	0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x44, 0x0a, 0x0c, 0x43, 0x6c, 0x6f,
+	0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22,
+	0x3d, 0x0a, 0x0d, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xbd,
+	0x01, 0x0a, 0x0a, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x12, 0x17, 0x0a,
+	0x07, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x70, 0x75, 0x73, 0x68, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x03, 0x72, 0x65, 0x71, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52,
+	0x65, 0x71, 0x52, 0x03, 0x72, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x41, 0x74, 0x22, 0x42,
+	0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x22, 0x72, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65,
+	0x74, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x60, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65,
+	0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x44, 0x61, 0x74, 0x61, 0x12, 0x33, 0x0a,
+	0x0c, 0x64, 0x65, 0x61, 0x64, 0x5f, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x44, 0x65, 0x61, 0x64, 0x4c,
+	0x65, 0x74, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x64, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65,
+	0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x31, 0x0a, 0x14, 0x52, 0x65, 0x70, 0x6c,
+	0x61, 0x79, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x12, 0x19, 0x0a, 0x08, 0x70, 0x75, 0x73, 0x68, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x70, 0x75, 0x73, 0x68, 0x49, 0x64, 0x73, 0x22, 0x76, 0x0a, 0x15, 0x52,
+	0x65, 0x70, 0x6c, 0x61, 0x79, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x44, 0x65,
+	0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x22, 0x33, 0x0a, 0x15, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x44, 0x65, 0x61,
+	0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08,
+	0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x22, 0xf2, 0x01, 0x0a, 0x0f, 0x42, 0x72, 0x6f,
+	0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c,
+	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12,
+	0x26, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x70, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x41, 0x70, 0x70,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x61,
+	0x70, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x6d, 0x61, 0x78, 0x41, 0x70, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x33, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x70, 0x75, 0x73, 0x68, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04,
+	0x6d, 0x65, 0x74, 0x61, 0x1a, 0x37, 0x0a, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4f, 0x0a,
+	0x0c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12,
+	0x2d, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x66,
+	0x0a, 0x0d, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x75,
+	0x73, 0x68, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x56, 0x0a, 0x0d, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76,
+	0x65, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x22, 0x63,
+	0x0a, 0x0a, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x6d, 0x73, 0x67, 0x12, 0x2d, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x42, 0x72, 0x6f, 0x61,
+	0x64, 0x63, 0x61, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x22, 0x64, 0x0a, 0x0b, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x27, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0xa2, 0x03, 0x0a, 0x0b, 0x50, 0x75,
+	0x73, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x50, 0x75, 0x73,
+	0x68, 0x4d, 0x73, 0x67, 0x12, 0x0d, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x50, 0x75, 0x73, 0x68,
+	0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x37, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x75, 0x73, 0x68,
+	0x4d, 0x73, 0x67, 0x12, 0x12, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x12, 0x34, 0x0a, 0x09,
+	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x12, 0x12, 0x2e, 0x70, 0x75, 0x73, 0x68,
+	0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e,
+	0x70, 0x75, 0x73, 0x68, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x46, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65,
+	0x74, 0x74, 0x65, 0x72, 0x73, 0x12, 0x18, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x1a,
+	0x19, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x61, 0x64, 0x4c,
+	0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x4c, 0x0a, 0x11, 0x52, 0x65,
+	0x70, 0x6c, 0x61, 0x79, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x1a, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x44, 0x65, 0x61,
+	0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1b, 0x2e, 0x70, 0x75,
+	0x73, 0x68, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74,
+	0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x34, 0x0a, 0x09, 0x42, 0x72, 0x6f, 0x61,
+	0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x12, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x42, 0x72, 0x6f,
+	0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x70, 0x75, 0x73, 0x68,
+	0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2e,
+	0x0a, 0x07, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x12, 0x10, 0x2e, 0x70, 0x75, 0x73, 0x68,
+	0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x70, 0x75,
+	0x73, 0x68, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x42, 0x09,
+	0x5a, 0x07, 0x2e, 0x2f, 0x3b, 0x70, 0x75, 0x73, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_idl_push_push_proto_rawDescOnce sync.Once
+	file_idl_push_push_proto_rawDescData = file_idl_push_push_proto_rawDesc
+)
+
+func file_idl_push_push_proto_rawDescGZIP() []byte {
+	file_idl_push_push_proto_rawDescOnce.Do(func() {
+		file_idl_push_push_proto_rawDescData = protoimpl.X.CompressGZIP(file_idl_push_push_proto_rawDescData)
+	})
+	return file_idl_push_push_proto_rawDescData
+}
+
+var file_idl_push_push_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_idl_push_push_proto_goTypes = []interface{}{
+	(*PushReq)(nil),               // 0: push.PushReq
+	(*PushResp)(nil),              // 1: push.PushResp
+	(*PushData)(nil),              // 2: push.PushData
+	(*BatchPushReq)(nil),          // 3: push.BatchPushReq
+	(*PushTarget)(nil),            // 4: push.PushTarget
+	(*BatchPushResp)(nil),         // 5: push.BatchPushResp
+	(*PushResult)(nil),            // 6: push.PushResult
+	(*CloseConnReq)(nil),          // 7: push.CloseConnReq
+	(*CloseConnResp)(nil),         // 8: push.CloseConnResp
+	(*DeadLetter)(nil),            // 9: push.DeadLetter
+	(*ListDeadLettersReq)(nil),    // 10: push.ListDeadLettersReq
+	(*ListDeadLettersResp)(nil),   // 11: push.ListDeadLettersResp
+	(*ListDeadLettersData)(nil),   // 12: push.ListDeadLettersData
+	(*ReplayDeadLettersReq)(nil),  // 13: push.ReplayDeadLettersReq
+	(*ReplayDeadLettersResp)(nil), // 14: push.ReplayDeadLettersResp
+	(*ReplayDeadLettersData)(nil), // 15: push.ReplayDeadLettersData
+	(*BroadcastFilter)(nil),       // 16: push.BroadcastFilter
+	(*BroadcastReq)(nil),          // 17: push.BroadcastReq
+	(*BroadcastResp)(nil),         // 18: push.BroadcastResp
+	(*BroadcastData)(nil),         // 19: push.BroadcastData
+	(*PublishReq)(nil),            // 20: push.PublishReq
+	(*PublishResp)(nil),           // 21: push.PublishResp
+	nil,                           // 22: push.BroadcastFilter.MetaEntry
+}
+var file_idl_push_push_proto_depIdxs = []int32{
+	2,  // 0: push.PushResp.data:type_name -> push.PushData
+	4,  // 1: push.BatchPushReq.targets:type_name -> push.PushTarget
+	6,  // 2: push.BatchPushResp.results:type_name -> push.PushResult
+	0,  // 3: push.DeadLetter.req:type_name -> push.PushReq
+	12, // 4: push.ListDeadLettersResp.data:type_name -> push.ListDeadLettersData
+	9,  // 5: push.ListDeadLettersData.dead_letters:type_name -> push.DeadLetter
+	15, // 6: push.ReplayDeadLettersResp.data:type_name -> push.ReplayDeadLettersData
+	22, // 7: push.BroadcastFilter.meta:type_name -> push.BroadcastFilter.MetaEntry
+	16, // 8: push.BroadcastReq.filter:type_name -> push.BroadcastFilter
+	19, // 9: push.BroadcastResp.data:type_name -> push.BroadcastData
+	16, // 10: push.PublishReq.filter:type_name -> push.BroadcastFilter
+	19, // 11: push.PublishResp.data:type_name -> push.BroadcastData
+	0,  // 12: push.PushService.PushMsg:input_type -> push.PushReq
+	3,  // 13: push.PushService.BatchPushMsg:input_type -> push.BatchPushReq
+	7,  // 14: push.PushService.CloseConn:input_type -> push.CloseConnReq
+	10, // 15: push.PushService.ListDeadLetters:input_type -> push.ListDeadLettersReq
+	13, // 16: push.PushService.ReplayDeadLetters:input_type -> push.ReplayDeadLettersReq
+	17, // 17: push.PushService.Broadcast:input_type -> push.BroadcastReq
+	20, // 18: push.PushService.Publish:input_type -> push.PublishReq
+	1,  // 19: push.PushService.PushMsg:output_type -> push.PushResp
+	5,  // 20: push.PushService.BatchPushMsg:output_type -> push.BatchPushResp
+	8,  // 21: push.PushService.CloseConn:output_type -> push.CloseConnResp
+	11, // 22: push.PushService.ListDeadLetters:output_type -> push.ListDeadLettersResp
+	14, // 23: push.PushService.ReplayDeadLetters:output_type -> push.ReplayDeadLettersResp
+	18, // 24: push.PushService.Broadcast:output_type -> push.BroadcastResp
+	21, // 25: push.PushService.Publish:output_type -> push.PublishResp
+	19, // [19:26] is the sub-list for method output_type
+	12, // [12:19] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_idl_push_push_proto_init() }
+func file_idl_push_push_proto_init() {
+	if File_idl_push_push_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_idl_push_push_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushData); i {
+			case 0:
 				return &v.state
 			case 1:
 				return &v.sizeCache
@@ -663,7 +1752,7 @@ func file_idl_push_push_proto_init() {
 			}
 		}
 		file_idl_push_push_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushTarget); i {
+			switch v := v.(*BatchPushReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -675,7 +1764,7 @@ func file_idl_push_push_proto_init() {
 			}
 		}
 		file_idl_push_push_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchPushResp); i {
+			switch v := v.(*PushTarget); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -687,7 +1776,7 @@ func file_idl_push_push_proto_init() {
 			}
 		}
 		file_idl_push_push_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushResult); i {
+			switch v := v.(*BatchPushResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -699,7 +1788,7 @@ func file_idl_push_push_proto_init() {
 			}
 		}
 		file_idl_push_push_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CloseConnReq); i {
+			switch v := v.(*PushResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -711,6 +1800,18 @@ func file_idl_push_push_proto_init() {
 			}
 		}
 		file_idl_push_push_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseConnReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CloseConnResp); i {
 			case 0:
 				return &v.state
@@ -722,6 +1823,162 @@ func file_idl_push_push_proto_init() {
 				return nil
 			}
 		}
+		file_idl_push_push_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeadLetter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeadLettersReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeadLettersResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeadLettersData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplayDeadLettersReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplayDeadLettersResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplayDeadLettersData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublishReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_idl_push_push_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublishResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -729,7 +1986,7 @@ func file_idl_push_push_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_idl_push_push_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,9 +21,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PushService_PushMsg_FullMethodName      = "/push.PushService/PushMsg"
-	PushService_BatchPushMsg_FullMethodName = "/push.PushService/BatchPushMsg"
-	PushService_CloseConn_FullMethodName    = "/push.PushService/CloseConn"
+	PushService_PushMsg_FullMethodName           = "/push.PushService/PushMsg"
+	PushService_BatchPushMsg_FullMethodName      = "/push.PushService/BatchPushMsg"
+	PushService_CloseConn_FullMethodName         = "/push.PushService/CloseConn"
+	PushService_ListDeadLetters_FullMethodName   = "/push.PushService/ListDeadLetters"
+	PushService_ReplayDeadLetters_FullMethodName = "/push.PushService/ReplayDeadLetters"
+	PushService_Broadcast_FullMethodName         = "/push.PushService/Broadcast"
+	PushService_Publish_FullMethodName           = "/push.PushService/Publish"
 )
 
 // PushServiceClient is the client API for PushService service.
@@ -38,6 +42,14 @@ type PushServiceClient interface {
 	BatchPushMsg(ctx context.Context, in *BatchPushReq, opts ...grpc.CallOption) (*BatchPushResp, error)
 	// CloseConn 取消连接
 	CloseConn(ctx context.Context, in *CloseConnReq, opts ...grpc.CallOption) (*CloseConnResp, error)
+	// ListDeadLetters 查看重试次数用尽的异步推送任务
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersReq, opts ...grpc.CallOption) (*ListDeadLettersResp, error)
+	// ReplayDeadLetters 将死信任务重新放入异步推送队列
+	ReplayDeadLetters(ctx context.Context, in *ReplayDeadLettersReq, opts ...grpc.CallOption) (*ReplayDeadLettersResp, error)
+	// Broadcast 广播消息到所有 Gateway 上满足过滤条件的连接
+	Broadcast(ctx context.Context, in *BroadcastReq, opts ...grpc.CallOption) (*BroadcastResp, error)
+	// Publish 发布消息到主题，推送给所有 Gateway 上订阅了该主题且满足过滤条件的连接
+	Publish(ctx context.Context, in *PublishReq, opts ...grpc.CallOption) (*PublishResp, error)
 }
 
 type pushServiceClient struct {
@@ -78,6 +90,46 @@ func (c *pushServiceClient) CloseConn(ctx context.Context, in *CloseConnReq, opt
 	return out, nil
 }
 
+func (c *pushServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersReq, opts ...grpc.CallOption) (*ListDeadLettersResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadLettersResp)
+	err := c.cc.Invoke(ctx, PushService_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pushServiceClient) ReplayDeadLetters(ctx context.Context, in *ReplayDeadLettersReq, opts ...grpc.CallOption) (*ReplayDeadLettersResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayDeadLettersResp)
+	err := c.cc.Invoke(ctx, PushService_ReplayDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pushServiceClient) Broadcast(ctx context.Context, in *BroadcastReq, opts ...grpc.CallOption) (*BroadcastResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastResp)
+	err := c.cc.Invoke(ctx, PushService_Broadcast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pushServiceClient) Publish(ctx context.Context, in *PublishReq, opts ...grpc.CallOption) (*PublishResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishResp)
+	err := c.cc.Invoke(ctx, PushService_Publish_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PushServiceServer is the server API for PushService service.
 // All implementations must embed UnimplementedPushServiceServer
 // for forward compatibility.
@@ -90,6 +142,14 @@ type PushServiceServer interface {
 	BatchPushMsg(context.Context, *BatchPushReq) (*BatchPushResp, error)
 	// CloseConn 取消连接
 	CloseConn(context.Context, *CloseConnReq) (*CloseConnResp, error)
+	// ListDeadLetters 查看重试次数用尽的异步推送任务
+	ListDeadLetters(context.Context, *ListDeadLettersReq) (*ListDeadLettersResp, error)
+	// ReplayDeadLetters 将死信任务重新放入异步推送队列
+	ReplayDeadLetters(context.Context, *ReplayDeadLettersReq) (*ReplayDeadLettersResp, error)
+	// Broadcast 广播消息到所有 Gateway 上满足过滤条件的连接
+	Broadcast(context.Context, *BroadcastReq) (*BroadcastResp, error)
+	// Publish 发布消息到主题，推送给所有 Gateway 上订阅了该主题且满足过滤条件的连接
+	Publish(context.Context, *PublishReq) (*PublishResp, error)
 	mustEmbedUnimplementedPushServiceServer()
 }
 
@@ -109,6 +169,18 @@ func (UnimplementedPushServiceServer) BatchPushMsg(context.Context, *BatchPushRe
 func (UnimplementedPushServiceServer) CloseConn(context.Context, *CloseConnReq) (*CloseConnResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CloseConn not implemented")
 }
+func (UnimplementedPushServiceServer) ListDeadLetters(context.Context, *ListDeadLettersReq) (*ListDeadLettersResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedPushServiceServer) ReplayDeadLetters(context.Context, *ReplayDeadLettersReq) (*ReplayDeadLettersResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayDeadLetters not implemented")
+}
+func (UnimplementedPushServiceServer) Broadcast(context.Context, *BroadcastReq) (*BroadcastResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Broadcast not implemented")
+}
+func (UnimplementedPushServiceServer) Publish(context.Context, *PublishReq) (*PublishResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
+}
 func (UnimplementedPushServiceServer) mustEmbedUnimplementedPushServiceServer() {}
 func (UnimplementedPushServiceServer) testEmbeddedByValue()                     {}
 
@@ -184,6 +256,78 @@ func _PushService_CloseConn_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PushService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PushServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PushService_ListDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PushServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PushService_ReplayDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayDeadLettersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PushServiceServer).ReplayDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PushService_ReplayDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PushServiceServer).ReplayDeadLetters(ctx, req.(*ReplayDeadLettersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PushService_Broadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PushServiceServer).Broadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PushService_Broadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PushServiceServer).Broadcast(ctx, req.(*BroadcastReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PushService_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PushServiceServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PushService_Publish_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PushServiceServer).Publish(ctx, req.(*PublishReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PushService_ServiceDesc is the grpc.ServiceDesc for PushService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -203,6 +347,22 @@ var PushService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CloseConn",
 			Handler:    _PushService_CloseConn_Handler,
 		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _PushService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "ReplayDeadLetters",
+			Handler:    _PushService_ReplayDeadLetters_Handler,
+		},
+		{
+			MethodName: "Broadcast",
+			Handler:    _PushService_Broadcast_Handler,
+		},
+		{
+			MethodName: "Publish",
+			Handler:    _PushService_Publish_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "idl/push/push.proto",
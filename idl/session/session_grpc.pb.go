@@ -24,6 +24,7 @@ const (
 	SessionService_Login_FullMethodName             = "/session.SessionService/Login"
 	SessionService_DelSession_FullMethodName        = "/session.SessionService/DelSession"
 	SessionService_GetSessions_FullMethodName       = "/session.SessionService/GetSessions"
+	SessionService_BatchGetSessions_FullMethodName  = "/session.SessionService/BatchGetSessions"
 	SessionService_Kick_FullMethodName              = "/session.SessionService/Kick"
 	SessionService_RefreshSessionTTL_FullMethodName = "/session.SessionService/RefreshSessionTTL"
 )
@@ -40,6 +41,8 @@ type SessionServiceClient interface {
 	DelSession(ctx context.Context, in *DelSessionReq, opts ...grpc.CallOption) (*DelSessionResp, error)
 	// GetSessions 获取用户会话列表
 	GetSessions(ctx context.Context, in *GetSessionsReq, opts ...grpc.CallOption) (*GetSessionsResp, error)
+	// BatchGetSessions 批量获取多个用户的会话列表
+	BatchGetSessions(ctx context.Context, in *BatchGetSessionsReq, opts ...grpc.CallOption) (*BatchGetSessionsResp, error)
 	// KickSession 踢人
 	Kick(ctx context.Context, in *KickReq, opts ...grpc.CallOption) (*KickResp, error)
 	// RefreshSessionTTL 刷新会话 TTL
@@ -84,6 +87,16 @@ func (c *sessionServiceClient) GetSessions(ctx context.Context, in *GetSessionsR
 	return out, nil
 }
 
+func (c *sessionServiceClient) BatchGetSessions(ctx context.Context, in *BatchGetSessionsReq, opts ...grpc.CallOption) (*BatchGetSessionsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetSessionsResp)
+	err := c.cc.Invoke(ctx, SessionService_BatchGetSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *sessionServiceClient) Kick(ctx context.Context, in *KickReq, opts ...grpc.CallOption) (*KickResp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(KickResp)
@@ -116,6 +129,8 @@ type SessionServiceServer interface {
 	DelSession(context.Context, *DelSessionReq) (*DelSessionResp, error)
 	// GetSessions 获取用户会话列表
 	GetSessions(context.Context, *GetSessionsReq) (*GetSessionsResp, error)
+	// BatchGetSessions 批量获取多个用户的会话列表
+	BatchGetSessions(context.Context, *BatchGetSessionsReq) (*BatchGetSessionsResp, error)
 	// KickSession 踢人
 	Kick(context.Context, *KickReq) (*KickResp, error)
 	// RefreshSessionTTL 刷新会话 TTL
@@ -139,6 +154,9 @@ func (UnimplementedSessionServiceServer) DelSession(context.Context, *DelSession
 func (UnimplementedSessionServiceServer) GetSessions(context.Context, *GetSessionsReq) (*GetSessionsResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSessions not implemented")
 }
+func (UnimplementedSessionServiceServer) BatchGetSessions(context.Context, *BatchGetSessionsReq) (*BatchGetSessionsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetSessions not implemented")
+}
 func (UnimplementedSessionServiceServer) Kick(context.Context, *KickReq) (*KickResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Kick not implemented")
 }
@@ -220,6 +238,24 @@ func _SessionService_GetSessions_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_BatchGetSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetSessionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).BatchGetSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionService_BatchGetSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).BatchGetSessions(ctx, req.(*BatchGetSessionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SessionService_Kick_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(KickReq)
 	if err := dec(in); err != nil {
@@ -275,6 +311,10 @@ var SessionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSessions",
 			Handler:    _SessionService_GetSessions_Handler,
 		},
+		{
+			MethodName: "BatchGetSessions",
+			Handler:    _SessionService_BatchGetSessions_Handler,
+		},
 		{
 			MethodName: "Kick",
 			Handler:    _SessionService_Kick_Handler,
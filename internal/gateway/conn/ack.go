@@ -0,0 +1,139 @@
+package conn
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrAckWindowFull = errors.New("ack window full")
+
+// maxBackoffShift 重传退避的最大指数，避免退避时间无限增长
+const maxBackoffShift = 5
+
+// AckConfig 下行可靠投递配置
+// 开启后 v2 连接的每个推送在头部 RequestID 中携带连接内递增的序号并带上 FlagNeedAck，
+// 客户端需回复 MsgTypeACK（RequestID 为序号），表示该序号及之前的推送都已收到（累计确认）。
+// v1 头部没有 RequestID，v1 连接不开启可靠投递，推送包体保持不变
+type AckConfig struct {
+	// WindowSize 每个连接未确认推送的最大数量，0 表示不开启可靠投递；超过窗口视为慢连接并断开
+	WindowSize int
+	// RetransmitTimeout 首次重传超时时间，之后按指数退避
+	RetransmitTimeout time.Duration
+	// MaxRetries 最大重传次数，超过后断开连接
+	MaxRetries int
+}
+
+// enabled 是否开启可靠投递
+func (c AckConfig) enabled() bool {
+	return c.WindowSize > 0
+}
+
+// withDefaults 填充未设置的重传参数
+func (c AckConfig) withDefaults() AckConfig {
+	if c.RetransmitTimeout <= 0 {
+		c.RetransmitTimeout = 3 * time.Second
+	}
+	if c.MaxRetries <= 0 {
+		c.MaxRetries = 3
+	}
+	return c
+}
+
+// inflightPacket 已发送未确认的推送
+type inflightPacket struct {
+	seq      uint64
+	data     []byte    // 已编码的完整数据包，重传时直接写出
+	retries  int       // 已重传次数
+	deadline time.Time // 下次重传时间
+}
+
+// ackWindow 连接的下行推送窗口，按序号递增保存未确认的推送
+type ackWindow struct {
+	mu       sync.Mutex
+	cfg      AckConfig
+	nextSeq  uint64
+	inflight []*inflightPacket
+}
+
+func newAckWindow(cfg AckConfig) *ackWindow {
+	return &ackWindow{
+		cfg:      cfg,
+		inflight: make([]*inflightPacket, 0, cfg.WindowSize),
+	}
+}
+
+// add 为推送分配序号、按 v2 编码并加入窗口，返回需要写出的数据
+func (w *ackWindow) add(body []byte, now time.Time) ([]byte, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.inflight) >= w.cfg.WindowSize {
+		return nil, ErrAckWindowFull
+	}
+
+	seq := w.nextSeq + 1
+	data, err := EncodePacket(Packet{
+		Version:   VersionV2,
+		MsgType:   MsgTypePush,
+		Flags:     FlagNeedAck,
+		RequestID: seq,
+		Body:      body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	w.nextSeq = seq
+	w.inflight = append(w.inflight, &inflightPacket{
+		seq:      seq,
+		data:     data,
+		deadline: now.Add(w.cfg.RetransmitTimeout),
+	})
+	return data, nil
+}
+
+// ack 累计确认，移除序号小于等于 seq 的推送，返回确认的数量
+func (w *ackWindow) ack(seq uint64) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n := 0
+	for n < len(w.inflight) && w.inflight[n].seq <= seq {
+		n++
+	}
+	if n > 0 {
+		w.inflight = append(w.inflight[:0], w.inflight[n:]...)
+	}
+	return n
+}
+
+// due 返回到期需要重传的数据，exhausted 表示有推送超过最大重传次数
+func (w *ackWindow) due(now time.Time) (resend [][]byte, exhausted bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, p := range w.inflight {
+		if now.Before(p.deadline) {
+			continue
+		}
+		if p.retries >= w.cfg.MaxRetries {
+			return nil, true
+		}
+		p.retries++
+		shift := p.retries
+		if shift > maxBackoffShift {
+			shift = maxBackoffShift
+		}
+		p.deadline = now.Add(w.cfg.RetransmitTimeout << shift)
+		resend = append(resend, p.data)
+	}
+	return resend, false
+}
+
+// len 返回未确认推送数量
+func (w *ackWindow) len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.inflight)
+}
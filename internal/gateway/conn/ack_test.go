@@ -0,0 +1,126 @@
+package conn
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAckWindowAdd(t *testing.T) {
+	now := time.Now()
+	w := newAckWindow(AckConfig{WindowSize: 2, RetransmitTimeout: time.Second, MaxRetries: 1})
+
+	tests := []struct {
+		name    string
+		body    []byte
+		seq     uint64
+		wantErr error
+	}{
+		{name: "first", body: []byte("a"), seq: 1},
+		{name: "second", body: []byte("b"), seq: 2},
+		{name: "window full", body: []byte("c"), wantErr: ErrAckWindowFull},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := w.add(tt.body, now)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			packet, n, err := tryDecodePacket(data)
+			if err != nil || n != len(data) {
+				t.Fatalf("decode packet failed: n=%d err=%v", n, err)
+			}
+			if packet.Version != VersionV2 || packet.MsgType != MsgTypePush || !packet.Flags.Has(FlagNeedAck) ||
+				packet.RequestID != tt.seq || !bytes.Equal(packet.Body, tt.body) {
+				t.Fatalf("unexpected packet: %+v", packet)
+			}
+		})
+	}
+}
+
+func TestAckWindowAck(t *testing.T) {
+	tests := []struct {
+		name string
+		// sent 已发送的推送数量，序号从 1 开始
+		sent    int
+		acks    []uint64
+		acked   []int
+		pending int
+	}{
+		{name: "cumulative", sent: 3, acks: []uint64{2}, acked: []int{2}, pending: 1},
+		{name: "all", sent: 3, acks: []uint64{3}, acked: []int{3}, pending: 0},
+		{name: "duplicate", sent: 3, acks: []uint64{1, 1}, acked: []int{1, 0}, pending: 2},
+		{name: "stale after newer", sent: 3, acks: []uint64{2, 1}, acked: []int{2, 0}, pending: 1},
+		{name: "zero", sent: 2, acks: []uint64{0}, acked: []int{0}, pending: 2},
+		{name: "beyond sent", sent: 2, acks: []uint64{10}, acked: []int{2}, pending: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newAckWindow(AckConfig{WindowSize: 8, RetransmitTimeout: time.Second, MaxRetries: 1})
+			for i := 0; i < tt.sent; i++ {
+				if _, err := w.add([]byte("x"), time.Now()); err != nil {
+					t.Fatalf("add failed: %v", err)
+				}
+			}
+			for i, seq := range tt.acks {
+				if n := w.ack(seq); n != tt.acked[i] {
+					t.Fatalf("ack %d: expected %d acked, got %d", seq, tt.acked[i], n)
+				}
+			}
+			if n := w.len(); n != tt.pending {
+				t.Fatalf("expected %d pending, got %d", tt.pending, n)
+			}
+		})
+	}
+}
+
+func TestAckWindowDue(t *testing.T) {
+	const timeout = time.Second
+	start := time.Now()
+	w := newAckWindow(AckConfig{WindowSize: 8, RetransmitTimeout: timeout, MaxRetries: 2})
+	first, _ := w.add([]byte("a"), start)
+	second, _ := w.add([]byte("b"), start.Add(timeout/2))
+
+	// 每次重传后按指数退避：第 n 次重传后等待 timeout << n
+	tests := []struct {
+		name      string
+		at        time.Duration
+		resend    [][]byte
+		exhausted bool
+	}{
+		{name: "before timeout", at: timeout / 2},
+		{name: "first timeout", at: timeout, resend: [][]byte{first}},
+		{name: "second timeout", at: timeout + timeout/2, resend: [][]byte{second}},
+		{name: "backoff not elapsed", at: 2 * timeout},
+		{name: "first retry", at: 3 * timeout, resend: [][]byte{first}},
+		{name: "second retry", at: 3*timeout + timeout/2, resend: [][]byte{second}},
+		{name: "retries exhausted", at: 7 * timeout, exhausted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resend, exhausted := w.due(start.Add(tt.at))
+			if exhausted != tt.exhausted {
+				t.Fatalf("expected exhausted %v, got %v", tt.exhausted, exhausted)
+			}
+			if len(resend) != len(tt.resend) {
+				t.Fatalf("expected %d resend, got %d", len(tt.resend), len(resend))
+			}
+			for i := range resend {
+				if !bytes.Equal(resend[i], tt.resend[i]) {
+					t.Fatalf("unexpected resend packet %d", i)
+				}
+			}
+		})
+	}
+}
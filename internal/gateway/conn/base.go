@@ -0,0 +1,772 @@
+package conn
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sessionpb "github.com/wsx864321/kim/idl/session"
+	"github.com/wsx864321/kim/pkg/log"
+)
+
+// drainStep 排空连接时每批之间的最小间隔
+const drainStep = 10 * time.Millisecond
+
+// baseTransport 各协议 Transport 共用的连接管理逻辑：
+// 登录鉴权、连接池、epoll 事件循环、心跳检测以及 Session TTL 续期。
+// 具体协议只需要负责监听、握手并为连接指定分帧方式
+type baseTransport struct {
+	eps                []*epoll // 每个工作协程独享一个 epoll
+	connPool           *connPool
+	handler            EventHandler
+	ctx                context.Context
+	cancel             context.CancelFunc
+	wg                 sync.WaitGroup
+	stopped            int32
+	draining           int32
+	heartbeatTimeout   time.Duration
+	numWorkers         int
+	gatewayID          string           // Gateway 节点ID
+	timeWheel          *timeWheel       // 时间轮定时器
+	refreshTTLInterval time.Duration    // 刷新TTL的间隔（默认60s）
+	ackCfg             AckConfig        // 下行可靠投递配置
+	writeQueueCfg      WriteQueueConfig // 连接发送队列配置
+	upstreamCfg        UpstreamConfig   // 上行请求配置
+}
+
+// newBaseTransport 创建公共传输层，填充默认配置
+func newBaseTransport() *baseTransport {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &baseTransport{
+		connPool:           newConnPool(),
+		ctx:                ctx,
+		cancel:             cancel,
+		heartbeatTimeout:   180 * time.Second,
+		numWorkers:         2 * runtime.NumCPU(),
+		gatewayID:          "default",        // 默认 Gateway ID
+		refreshTTLInterval: 60 * time.Second, // 默认60秒刷新一次TTL
+		writeQueueCfg:      WriteQueueConfig{}.withDefaults(),
+		upstreamCfg:        UpstreamConfig{}.withDefaults(),
+	}
+}
+
+// init 按配置创建 epoll 和时间轮，在应用完 Option 之后调用
+func (t *baseTransport) init() error {
+	if t.numWorkers <= 0 {
+		t.numWorkers = 2 * runtime.NumCPU()
+	}
+
+	t.eps = make([]*epoll, 0, t.numWorkers)
+	for i := 0; i < t.numWorkers; i++ {
+		ep, err := newEpoll()
+		if err != nil {
+			for _, created := range t.eps {
+				created.close()
+			}
+			return err
+		}
+		t.eps = append(t.eps, ep)
+	}
+
+	t.initTimeWheel()
+	return nil
+}
+
+// pickEpoll 选择当前监听连接最少的 epoll
+func (t *baseTransport) pickEpoll() *epoll {
+	picked := t.eps[0]
+	for _, ep := range t.eps[1:] {
+		if ep.getCount() < picked.getCount() {
+			picked = ep
+		}
+	}
+	return picked
+}
+
+// initTimeWheel 初始化时间轮（槽数等于间隔秒数，每1秒转动一次）
+func (t *baseTransport) initTimeWheel() {
+	if t.timeWheel != nil {
+		t.timeWheel.stop()
+	}
+	slots := int(t.refreshTTLInterval.Seconds())
+	if slots <= 0 {
+		slots = 60 // 默认60个槽
+	}
+	t.timeWheel = newTimeWheel(t.refreshTTLInterval, slots)
+}
+
+// start 启动事件循环、心跳检测以及时间轮
+func (t *baseTransport) start() {
+	for i, ep := range t.eps {
+		t.wg.Add(1)
+		go t.eventLoop(i, ep)
+	}
+
+	// 启动心跳检测协程
+	t.wg.Add(1)
+	go t.heartbeatLoop()
+
+	// 启动时间轮定时器
+	if t.timeWheel != nil {
+		t.timeWheel.start(t.refreshSessionTTL)
+	}
+
+	// 开启可靠投递时启动重传协程
+	if t.ackCfg.enabled() {
+		t.wg.Add(1)
+		go t.retransmitLoop()
+	}
+}
+
+// shutdown 停止时间轮并关闭所有连接，调用方需要先关闭监听并 cancel 上下文
+func (t *baseTransport) shutdown() {
+	// 停止时间轮
+	if t.timeWheel != nil {
+		t.timeWheel.stop()
+	}
+
+	// 关闭所有连接
+	conns := t.connPool.getAll()
+	for _, conn := range conns {
+		conn.close()
+	}
+
+	t.wg.Wait()
+
+	for _, ep := range t.eps {
+		ep.close()
+	}
+}
+
+// isStopped 是否已经停止
+func (t *baseTransport) isStopped() bool {
+	return atomic.LoadInt32(&t.stopped) == 1
+}
+
+// startDraining 进入排空模式，只有第一次调用返回 true
+func (t *baseTransport) startDraining() bool {
+	return atomic.CompareAndSwapInt32(&t.draining, 0, 1)
+}
+
+// isDraining 是否正在排空连接
+func (t *baseTransport) isDraining() bool {
+	return atomic.LoadInt32(&t.draining) == 1
+}
+
+// drain 通知所有连接重连到其他节点，并在 window 内分批关闭，
+// 避免客户端同时重连到其他节点，以及连接断开时集中删除 Session。调用方需要先停止 accept
+func (t *baseTransport) drain(window time.Duration) {
+	ctx := context.Background()
+	conns := t.connPool.getAll()
+	log.Info(ctx, "draining connections", log.Int("connections", len(conns)), log.String("window", window.String()))
+	if len(conns) == 0 {
+		return
+	}
+
+	// 连接均匀分布在窗口内分批关闭，批次间隔不小于 drainStep
+	batches := min(int(window/drainStep), len(conns))
+	if batches < 1 {
+		batches = 1
+	}
+	perBatch := (len(conns) + batches - 1) / batches
+
+	ticker := time.NewTicker(max(window/time.Duration(batches), drainStep))
+	defer ticker.Stop()
+
+	for i := 0; i < len(conns); i += perBatch {
+		if i > 0 {
+			select {
+			case <-t.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+
+		end := min(i+perBatch, len(conns))
+		for _, conn := range conns[i:end] {
+			// 通知发送完成后由 onLastWrite 关闭连接
+			data, err := conn.encode(Packet{MsgType: MsgTypeReconnect})
+			if err == nil {
+				err = conn.writeLast(data, "reconnect elsewhere")
+			}
+			if err != nil {
+				t.handleDisconnect(ctx, conn, "reconnect elsewhere")
+			}
+		}
+	}
+
+	// 等待最后一批通知发送完成
+	deadline := time.Now().Add(t.writeQueueCfg.WriteTimeout)
+	for t.connPool.count() > 0 && time.Now().Before(deadline) {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+	log.Info(ctx, "drain completed", log.Int("remaining", t.connPool.count()))
+}
+
+// handleNewConnection 处理新连接（在独立协程中，避免阻塞 accept）
+// conn 为已完成协议握手的连接，codec 为该连接使用的分帧方式
+func (t *baseTransport) handleNewConnection(conn net.Conn, codec frameCodec) {
+	ctx := context.Background()
+	// 设置初始读取超时（用于读取登录包）
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	// 读取并验证登录包，登录包之后多读到的数据留给后续处理
+	packet, rest, err := readLoginPacket(conn, codec)
+	if err != nil {
+		log.Warn(ctx, "decode logic packet failed", log.String("error", err.Error()), log.String("remote", conn.RemoteAddr().String()))
+		conn.Close()
+		return
+	}
+
+	if packet.MsgType != MsgTypeLogin {
+		log.Warn(ctx, "first packet must be logic", log.Any("msgType", packet.MsgType))
+		conn.Close()
+		return
+	}
+	// 排空期间不再接收新的登录（已经 accept 但还未完成登录的连接）
+	if t.isDraining() {
+		log.Info(ctx, "gateway draining, reject login", log.String("remote", conn.RemoteAddr().String()))
+		conn.Close()
+		return
+	}
+	session, err := t.handler.OnLogin(ctx, conn, packet.Body, t.gatewayID)
+	if err != nil {
+		log.Warn(ctx, "logic failed", log.String("error", err.Error()), log.String("remote", conn.RemoteAddr().String()))
+		conn.Close()
+		return
+	}
+
+	// 登录完成，后续读取由 epoll 驱动，清除读取超时
+	conn.SetReadDeadline(time.Time{})
+
+	raw, fd, err := rawConnOf(conn)
+	if err != nil {
+		log.Error(ctx, "get raw conn failed", log.String("error", err.Error()))
+		conn.Close()
+		return
+	}
+
+	// 创建连接对象
+	expireTime := time.Unix(session.ExpireAt, 0)
+	c := &connection{
+		id:           session.GetConnId(),
+		fd:           fd,
+		raw:          raw,
+		tlsSock:      tlsSocketOf(conn),
+		userID:       session.GetUserId(),
+		platformType: ToPlatformType(session.DeviceType),
+		deviceID:     session.GetDeviceId(),
+		appVersion:   session.GetAppVersion(),
+		meta:         session.GetMeta(),
+		expireTime:   expireTime,
+		conn:         conn,
+		codec:        codec,
+		version:      packet.Version,
+		inbuf:        rest,
+		lastActiveAt: time.Now(),
+	}
+	// 可靠投递的序号放在 v2 头部，v1 连接不开启
+	if t.ackCfg.enabled() && c.version == VersionV2 {
+		c.ack = newAckWindow(t.ackCfg)
+	}
+	c.queue = newWriteQueue(t.writeQueueCfg, t.gatewayID)
+	c.upstream = newUpstreamQueue(t.upstreamCfg.MaxPending)
+	c.onWriteError = func(err error) {
+		t.handleDisconnect(context.Background(), c, "write failed: "+err.Error())
+	}
+	c.onLastWrite = func(reason string) {
+		t.handleDisconnect(context.Background(), c, reason)
+	}
+	// TLS 之后由 epoll 线程喂数据给 tls.Conn，不再阻塞读取 socket
+	if c.tlsSock != nil {
+		c.tlsSock.setNonblock()
+	}
+
+	//  添加到连接池，并订阅登录时携带的主题
+	t.connPool.add(c)
+	t.subscribeLoginTopics(ctx, c, session.GetTopics())
+
+	//  添加到 epoll
+	c.ep = t.pickEpoll()
+	if err := c.ep.add(c); err != nil {
+		log.Error(ctx, "add to epoll failed", log.String("error", err.Error()))
+		t.connPool.remove(c)
+		conn.Close()
+		return
+	}
+
+	// 添加到时间轮，用于定期刷新Session TTL
+	if t.timeWheel != nil {
+		t.timeWheel.add(c)
+	}
+
+	// 通知上层连接建立
+	if t.handler != nil {
+		if err := t.handler.OnConnect(ctx, c); err != nil {
+			log.Warn(ctx, "onConnect handler failed", log.String("error", err.Error()))
+		}
+	}
+
+	log.Info(ctx, "new connection established", log.String("userID", session.UserId), log.Uint64("connID", c.id), log.String("deviceID", session.DeviceId))
+
+	// 登录包之后客户端可能紧接着发送了数据，已在读缓存（或 tls.Conn 内部缓存）中，主动处理一次
+	t.handleConnectionRead(ctx, c, nil)
+}
+
+// readLoginPacket 阻塞读取登录包（超时由调用方设置），返回登录包以及之后多读到的数据
+func readLoginPacket(conn net.Conn, codec frameCodec) (*Packet, []byte, error) {
+	var buf []byte
+	tmp := make([]byte, 4096)
+	for {
+		for {
+			packet, n, err := codec.decode(loginControlWriter{conn}, buf)
+			if err != nil {
+				return nil, nil, err
+			}
+			if n == 0 {
+				break
+			}
+			buf = buf[n:]
+			if packet != nil {
+				return packet, buf, nil
+			}
+		}
+
+		n, err := conn.Read(tmp)
+		if err != nil {
+			return nil, nil, err
+		}
+		buf = append(buf, tmp[:n]...)
+	}
+}
+
+// loginControlWriter 登录阶段还没有发送队列和写协程，控制帧直接写入连接
+type loginControlWriter struct {
+	conn net.Conn
+}
+
+func (w loginControlWriter) writeControl(data []byte) error {
+	_, err := w.conn.Write(data)
+	return err
+}
+
+// writeLastControl 写出后返回 io.EOF 结束登录
+func (w loginControlWriter) writeLastControl(data []byte, _ string) error {
+	if err := w.writeControl(data); err != nil {
+		return err
+	}
+	return io.EOF
+}
+
+// ToPlatformType 将会话的设备类型转换为平台类型
+func ToPlatformType(deviceType sessionpb.DeviceType) PlatformType {
+	switch deviceType {
+	case sessionpb.DeviceType_DEVICE_TYPE_WEB:
+		return PlatformTypeWeb
+	case sessionpb.DeviceType_DEVICE_TYPE_MOBILE:
+		return PlatformTypeMobile
+	case sessionpb.DeviceType_DEVICE_TYPE_PC:
+		return PlatformTypePC
+	case sessionpb.DeviceType_DEVICE_TYPE_BOT:
+		return PlatformTypeBot
+	case sessionpb.DeviceType_DEVICE_TYPE_PAD:
+		return PlatformTypePAD
+	default:
+		return PlatformTypeUnknown
+	}
+}
+
+// eventLoop epoll 事件循环，每个工作协程处理自己的 epoll
+func (t *baseTransport) eventLoop(workerID int, ep *epoll) {
+	defer t.wg.Done()
+
+	// 工作协程复用的读缓冲区，连接只保存未解析完的数据
+	scratch := make([]byte, readScratchSize)
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		default:
+			// 等待 epoll 事件，超时时间 100ms
+			conns, err := ep.wait(100)
+			if err != nil {
+				if t.isStopped() {
+					return
+				}
+				log.Warn(context.Background(), "epoll wait error", log.String("error", err.Error()), log.Int("worker", workerID))
+				continue
+			}
+
+			// 处理就绪的连接
+			for _, conn := range conns {
+				// todo 生成一个带tracing的上下文
+				ctx := context.Background()
+				t.handleConnectionRead(ctx, conn, scratch)
+			}
+		}
+	}
+}
+
+// handleConnectionRead 处理连接读取
+// 边缘触发模式下每次通知都要把 socket 读空，读到的数据先进入连接的读缓存，再增量解析出完整的数据包，
+// 半个数据包会留在缓存中等待下一次通知，不会阻塞工作协程
+func (t *baseTransport) handleConnectionRead(ctx context.Context, conn *connection, scratch []byte) {
+	conn.readMu.Lock()
+	defer conn.readMu.Unlock()
+
+	if conn.isClosed() {
+		return
+	}
+	if scratch == nil {
+		scratch = make([]byte, readScratchSize)
+	}
+
+	for {
+		drained, readErr := conn.readAvailable(scratch)
+
+		// 先处理已经读到的数据包，再处理读错误（客户端可能发送完数据后立即关闭连接）
+		for {
+			packet, err := conn.nextPacket()
+			if err != nil { // 数据错误直接断开连接
+				t.handleDisconnect(ctx, conn, err.Error())
+				return
+			}
+			if packet == nil {
+				break
+			}
+			if !t.handlePacket(ctx, conn, packet) {
+				return
+			}
+		}
+
+		if readErr != nil { // 不管是什么原因的错误，都断开连接（连接关闭、TLS 解密失败等等）
+			log.Debug(context.Background(), "read failed", log.String("error", readErr.Error()), log.Uint64("connID", conn.id))
+			t.handleDisconnect(ctx, conn, readErr.Error())
+			return
+		}
+		if drained {
+			return
+		}
+	}
+}
+
+// handlePacket 处理一个数据包，连接已断开时返回 false
+func (t *baseTransport) handlePacket(ctx context.Context, conn *connection, packet *Packet) bool {
+	// 更新活跃时间
+	conn.updateActiveTime()
+
+	switch packet.MsgType {
+	case MsgTypePing:
+		// 心跳包，回复 Pong
+		t.sendPong(ctx, conn)
+	case MsgTypeLogout:
+		// 登出
+		t.handleDisconnect(ctx, conn, "logout")
+		return false
+	case MsgTypeACK:
+		// 客户端确认收到推送
+		t.handleAck(ctx, conn, packet)
+	case MsgTypeUpstream:
+		// 上行消息（客户端→服务端），异步交给上层处理并回复结果
+		t.handleRequest(conn, packet, upstreamRequest)
+	case MsgTypeSync:
+		// 同步消息（客户端登录后拉取离线消息），异步交给上层处理并回复结果
+		t.handleRequest(conn, packet, syncRequest)
+	case MsgTypeSubscribe:
+		// 订阅/取消订阅主题，只修改本节点的主题索引，直接处理并回复结果
+		t.handleSubscribe(ctx, conn, packet)
+	default:
+		log.Warn(context.Background(), "unknown msg type", log.Any("msgType", packet.MsgType), log.Uint64("connID", conn.id))
+	}
+	return true
+}
+
+// sendPong 发送心跳响应
+func (t *baseTransport) sendPong(ctx context.Context, conn *connection) {
+	pongPacket := Packet{
+		MsgType: MsgTypePong,
+		Body:    nil,
+	}
+	data, err := conn.encode(pongPacket)
+	if err != nil {
+		log.Warn(context.Background(), "encode pong failed", log.String("error", err.Error()))
+		return
+	}
+	conn.write(data)
+
+	// 更新活跃时间
+	conn.updateActiveTime()
+
+	// 通知上层收到心跳
+	if t.handler != nil {
+		t.handler.OnHeartbeat(ctx, conn)
+	}
+}
+
+// handleDisconnect 处理连接断开
+func (t *baseTransport) handleDisconnect(ctx context.Context, conn *connection, reason string) {
+	// 读写协程可能同时发现连接异常，只处理一次
+	if !conn.markClosed() {
+		return
+	}
+
+	// 从时间轮移除
+	if t.timeWheel != nil {
+		t.timeWheel.remove(conn.id)
+	}
+
+	// 从 epoll 移除
+	if conn.ep != nil {
+		conn.ep.remove(conn)
+	}
+
+	// 从连接池移除
+	t.connPool.remove(conn)
+
+	// 关闭连接
+	conn.close()
+
+	// 通知上层
+	if t.handler != nil {
+		t.handler.OnDisconnect(ctx, conn, reason)
+	}
+
+	log.Info(
+		ctx,
+		"connection closed",
+		log.Uint64("connID", conn.id),
+		log.String("userID", conn.userID),
+		log.String("reason", reason),
+	)
+}
+
+// heartbeatLoop 心跳检测循环
+func (t *baseTransport) heartbeatLoop() {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(10 * time.Second) // 每10秒检查一次
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			conns := t.connPool.getAll()
+
+			ctx := context.Background()
+			log.Info(ctx, "heartbeat check", log.Int("connections", len(conns)))
+			for _, conn := range conns {
+				lastActive := conn.getLastActiveTime()
+				if now.Sub(lastActive) > t.heartbeatTimeout {
+					t.handleDisconnect(ctx, conn, "heartbeat timeout")
+				}
+			}
+			log.Info(ctx, "heartbeat check completed")
+		}
+	}
+}
+
+// SetHandler 设置事件处理器
+func (t *baseTransport) SetHandler(h EventHandler) {
+	t.handler = h
+}
+
+// Send 发送消息到指定连接
+func (t *baseTransport) Send(ctx context.Context, connID uint64, data []byte) error {
+	conn, ok := t.connPool.getByID(connID)
+	if !ok {
+		return ErrConnNotFound
+	}
+
+	if err := t.writePush(ctx, conn, &pushPacket{body: data}); err != nil {
+		log.Warn(ctx, "send message failed", log.String("error", err.Error()), log.Uint64("connID", uint64(connID)))
+		return err
+	}
+
+	return nil
+}
+
+// BatchSend 批量发送消息到多个连接（发送相同消息）
+func (t *baseTransport) BatchSend(ctx context.Context, connIDs []uint64, data []byte) ([]uint64, error) {
+	if len(connIDs) == 0 {
+		return nil, nil
+	}
+
+	// 编码数据包（所有连接使用相同消息，同一协议版本只编码一次）
+	packet := &pushPacket{body: data}
+
+	// 批量发送
+	failConns := make([]uint64, 0)
+	for _, connID := range connIDs {
+		conn, ok := t.connPool.getByID(connID)
+		if !ok {
+			log.Warn(ctx, "connection not found", log.Uint64("connID", uint64(connID)))
+			failConns = append(failConns, connID)
+			continue
+		}
+
+		if err := t.writePush(ctx, conn, packet); err != nil {
+			log.Warn(ctx, "send batch message failed", log.String("error", err.Error()), log.Uint64("connID", uint64(connID)))
+			failConns = append(failConns, connID)
+		}
+	}
+
+	return failConns, nil
+}
+
+// pushPacket 推送数据包，按协议版本缓存编码结果
+type pushPacket struct {
+	body    []byte
+	encoded [VersionV2 + 1][]byte
+}
+
+// encode 按协议版本编码推送数据包
+func (p *pushPacket) encode(version byte) ([]byte, error) {
+	if p.encoded[version] != nil {
+		return p.encoded[version], nil
+	}
+
+	data, err := EncodePacket(Packet{
+		Version: version,
+		MsgType: MsgTypePush,
+		Body:    p.body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.encoded[version] = data
+	return data, nil
+}
+
+// writePush 写出推送消息，开启可靠投递时分配序号并加入确认窗口
+func (t *baseTransport) writePush(ctx context.Context, conn *connection, packet *pushPacket) error {
+	if conn.ack == nil {
+		encoded, err := packet.encode(conn.version)
+		if err != nil {
+			return err
+		}
+		return conn.write(encoded)
+	}
+
+	encoded, err := conn.ack.add(packet.body, time.Now())
+	if err != nil {
+		if errors.Is(err, ErrAckWindowFull) {
+			// 客户端长时间不确认，视为慢连接断开
+			t.handleDisconnect(ctx, conn, "slow connection: ack window full")
+		}
+		return err
+	}
+
+	// 写失败的推送仍在窗口中，由重传协程负责重发
+	return conn.write(encoded)
+}
+
+// handleAck 处理客户端 ACK，序号在 v2 头部的 RequestID 中
+func (t *baseTransport) handleAck(ctx context.Context, conn *connection, packet *Packet) {
+	if conn.ack == nil {
+		return
+	}
+
+	conn.ack.ack(packet.RequestID)
+}
+
+// retransmitLoop 重传未确认的推送，超过最大重传次数的连接将被断开
+func (t *baseTransport) retransmitLoop() {
+	defer t.wg.Done()
+
+	interval := t.ackCfg.RetransmitTimeout / 2
+	if interval < 50*time.Millisecond {
+		interval = 50 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+			ctx := context.Background()
+			now := time.Now()
+			for _, conn := range t.connPool.getAll() {
+				if conn.ack == nil {
+					continue
+				}
+
+				resend, exhausted := conn.ack.due(now)
+				if exhausted {
+					t.handleDisconnect(ctx, conn, "ack timeout")
+					continue
+				}
+
+				for _, data := range resend {
+					if err := conn.write(data); err != nil {
+						log.Warn(ctx, "retransmit failed", log.String("error", err.Error()), log.Uint64("connID", conn.id))
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// CloseConn 关闭指定连接
+func (t *baseTransport) CloseConn(ctx context.Context, connID uint64) error {
+	conn, ok := t.connPool.getByID(connID)
+	if !ok {
+		return ErrConnNotFound
+	}
+
+	// 使用 handleDisconnect 确保完整清理
+	t.handleDisconnect(ctx, conn, "closed by server")
+	return nil
+}
+
+// refreshSessionTTL 刷新Session TTL的回调函数
+func (t *baseTransport) refreshSessionTTL(conns []*connection) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Second)
+	defer cancel()
+
+	for _, conn := range conns {
+		if ctx.Err() != nil { // 上下文已超时或取消，停止处理
+			log.Warn(ctx, "refresh session timeout")
+			return
+		}
+		// 检查连接是否仍然有效
+		if _, ok := t.connPool.getByID(conn.id); !ok {
+			// 连接已断开，跳过
+			continue
+		}
+
+		// 获取最后活跃时间
+		lastActiveAt := conn.getLastActiveTime().Unix()
+
+		err := t.handler.OnRefreshSession(ctx, conn, lastActiveAt)
+		if err != nil { // 刷新失败，断开连接
+			t.handleDisconnect(ctx, conn, "refresh session err")
+			continue
+		}
+		// 刷新成功，将连接重新添加到时间轮，以便下次继续刷新
+		if t.timeWheel != nil {
+			t.timeWheel.add(conn)
+		}
+
+		log.Debug(
+			ctx,
+			"session TTL refreshed",
+			log.Uint64("connID", conn.id),
+			log.String("userID", conn.userID),
+		)
+	}
+}
@@ -0,0 +1,211 @@
+package conn
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	gatewaypb "github.com/wsx864321/kim/idl/gateway"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// maxTopicsPerConn 单个连接最多订阅的主题数量
+	maxTopicsPerConn = 64
+	// maxTopicLen 主题的最大长度（字节）
+	maxTopicLen = 128
+)
+
+var (
+	ErrInvalidTopic   = errors.New("invalid topic")
+	ErrTooManyTopics  = errors.New("too many topics")
+	ErrEmptyBroadcast = errors.New("broadcast message is empty")
+)
+
+// BroadcastFilter 广播过滤条件，多个条件同时满足才推送，未设置的条件不过滤
+type BroadcastFilter struct {
+	// PlatformTypes 平台类型
+	PlatformTypes []PlatformType
+	// MinAppVersion 最低应用版本号（包含）
+	MinAppVersion string
+	// MaxAppVersion 最高应用版本号（包含）
+	MaxAppVersion string
+	// Meta 会话扩展信息，key 必须存在，value 不为空时还需要相等
+	Meta map[string]string
+}
+
+// match 连接是否满足过滤条件
+func (f *BroadcastFilter) match(c *connection) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.PlatformTypes) > 0 {
+		matched := false
+		for _, platformType := range f.PlatformTypes {
+			if c.platformType == platformType {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	// 设置了版本范围时，没有上报版本号的连接不推送
+	if f.MinAppVersion != "" && (c.appVersion == "" || compareVersion(c.appVersion, f.MinAppVersion) < 0) {
+		return false
+	}
+	if f.MaxAppVersion != "" && (c.appVersion == "" || compareVersion(c.appVersion, f.MaxAppVersion) > 0) {
+		return false
+	}
+
+	for key, value := range f.Meta {
+		v, ok := c.meta[key]
+		if !ok || (value != "" && v != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// compareVersion 比较点分隔的版本号，逐段比较，两段都是数字时按数值比较，否则按字符串比较，缺少的段视为 0
+func compareVersion(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		x, y := "0", "0"
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+
+		xn, xErr := strconv.ParseUint(x, 10, 64)
+		yn, yErr := strconv.ParseUint(y, 10, 64)
+		switch {
+		case xErr == nil && yErr == nil:
+			if xn != yn {
+				if xn < yn {
+					return -1
+				}
+				return 1
+			}
+		default:
+			if c := strings.Compare(x, y); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// normalizeTopics 校验主题并去重
+func normalizeTopics(topics []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(topics))
+	result := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		if topic == "" || len(topic) > maxTopicLen || strings.IndexFunc(topic, func(r rune) bool {
+			return unicode.IsSpace(r) || unicode.IsControl(r)
+		}) >= 0 {
+			return nil, ErrInvalidTopic
+		}
+		if _, ok := seen[topic]; ok {
+			continue
+		}
+		seen[topic] = struct{}{}
+		result = append(result, topic)
+	}
+	return result, nil
+}
+
+// subscribeLoginTopics 订阅登录时携带的主题，主题不合法时只记录日志，不影响登录
+func (t *baseTransport) subscribeLoginTopics(ctx context.Context, c *connection, topics []string) {
+	if len(topics) == 0 {
+		return
+	}
+
+	topics, err := normalizeTopics(topics)
+	if err == nil {
+		err = t.connPool.subscribe(c, topics, maxTopicsPerConn)
+	}
+	if err != nil {
+		log.Warn(ctx, "subscribe login topics failed",
+			log.String("error", err.Error()),
+			log.Uint64("connID", c.id),
+			log.String("userID", c.userID),
+		)
+	}
+}
+
+// handleSubscribe 处理客户端订阅/取消订阅主题，只修改本节点的主题索引，先取消订阅再订阅
+func (t *baseTransport) handleSubscribe(ctx context.Context, conn *connection, packet *Packet) {
+	requestID, body := splitRequestID(packet)
+
+	failed := func(e *xerr.Error) {
+		t.reply(conn, requestID, MsgTypeSubscribeResponse, &gatewaypb.SubscribeResp{Code: e.Code(), Message: e.Error()})
+	}
+
+	req := &gatewaypb.SubscribeReq{}
+	if err := proto.Unmarshal(body, req); err != nil {
+		failed(xerr.ErrInvalidParams.WithMessage("invalid subscribe request"))
+		return
+	}
+	subscribe, err := normalizeTopics(req.Subscribe)
+	if err != nil {
+		failed(xerr.ErrInvalidParams.WithMessage(err.Error()))
+		return
+	}
+
+	t.connPool.unsubscribe(conn, req.Unsubscribe)
+	if err := t.connPool.subscribe(conn, subscribe, maxTopicsPerConn); err != nil {
+		failed(xerr.ErrInvalidParams.WithMessage(err.Error()))
+		return
+	}
+
+	t.reply(conn, requestID, MsgTypeSubscribeResponse, &gatewaypb.SubscribeResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Topics:  t.connPool.topicsOf(conn),
+	})
+}
+
+// Broadcast 推送消息到本节点满足过滤条件的连接，topic 不为空时只推送给订阅了该主题的连接，返回推送成功的连接数量
+func (t *baseTransport) Broadcast(ctx context.Context, topic string, filter *BroadcastFilter, data []byte) (int, error) {
+	if len(data) == 0 {
+		return 0, ErrEmptyBroadcast
+	}
+
+	var conns []*connection
+	if topic != "" {
+		conns = t.connPool.getByTopic(topic)
+	} else {
+		conns = t.connPool.getAll()
+	}
+
+	// 所有连接发送相同消息，同一协议版本只编码一次
+	packet := &pushPacket{body: data}
+	delivered := 0
+	for _, conn := range conns {
+		if conn.isClosed() || !filter.match(conn) {
+			continue
+		}
+		if err := t.writePush(ctx, conn, packet); err != nil {
+			log.Debug(ctx, "broadcast to connection failed", log.String("error", err.Error()), log.Uint64("connID", conn.id))
+			continue
+		}
+		delivered++
+	}
+
+	log.Info(ctx, "broadcast completed",
+		log.String("topic", topic),
+		log.Int("candidates", len(conns)),
+		log.Int("delivered", delivered),
+	)
+	return delivered, nil
+}
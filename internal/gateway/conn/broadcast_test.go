@@ -0,0 +1,204 @@
+package conn
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCompareVersion(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.2.3", b: "1.2.3", want: 0},
+		{a: "1.10.0", b: "1.9.0", want: 1},
+		{a: "1.2", b: "1.2.0", want: 0},
+		{a: "1.2", b: "1.2.1", want: -1},
+		{a: "2.0.0-beta", b: "2.0.0-alpha", want: 1},
+		{a: "1.0.a", b: "1.0.1", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			if got := compareVersion(tt.a, tt.b); got != tt.want {
+				t.Fatalf("compareVersion(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBroadcastFilterMatch(t *testing.T) {
+	conn := &connection{
+		platformType: PlatformTypeMobile,
+		appVersion:   "2.5.0",
+		meta:         map[string]string{"region": "cn", "vip": "1"},
+	}
+	noVersion := &connection{platformType: PlatformTypeMobile}
+
+	tests := []struct {
+		name   string
+		filter *BroadcastFilter
+		conn   *connection
+		want   bool
+	}{
+		{name: "nil filter", conn: conn, want: true},
+		{name: "empty filter", filter: &BroadcastFilter{}, conn: conn, want: true},
+		{name: "platform match", filter: &BroadcastFilter{PlatformTypes: []PlatformType{PlatformTypeWeb, PlatformTypeMobile}}, conn: conn, want: true},
+		{name: "platform mismatch", filter: &BroadcastFilter{PlatformTypes: []PlatformType{PlatformTypePC}}, conn: conn, want: false},
+		{name: "version in range", filter: &BroadcastFilter{MinAppVersion: "2.0", MaxAppVersion: "2.10"}, conn: conn, want: true},
+		{name: "version inclusive", filter: &BroadcastFilter{MinAppVersion: "2.5.0", MaxAppVersion: "2.5"}, conn: conn, want: true},
+		{name: "version below min", filter: &BroadcastFilter{MinAppVersion: "2.10.0"}, conn: conn, want: false},
+		{name: "version above max", filter: &BroadcastFilter{MaxAppVersion: "2.4.9"}, conn: conn, want: false},
+		{name: "version missing", filter: &BroadcastFilter{MinAppVersion: "1.0"}, conn: noVersion, want: false},
+		{name: "meta key exists", filter: &BroadcastFilter{Meta: map[string]string{"vip": ""}}, conn: conn, want: true},
+		{name: "meta value equal", filter: &BroadcastFilter{Meta: map[string]string{"region": "cn", "vip": "1"}}, conn: conn, want: true},
+		{name: "meta value mismatch", filter: &BroadcastFilter{Meta: map[string]string{"region": "us"}}, conn: conn, want: false},
+		{name: "meta key missing", filter: &BroadcastFilter{Meta: map[string]string{"beta": ""}}, conn: conn, want: false},
+		{
+			name:   "all conditions",
+			filter: &BroadcastFilter{PlatformTypes: []PlatformType{PlatformTypeMobile}, MinAppVersion: "2.0", Meta: map[string]string{"region": "cn"}},
+			conn:   conn,
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.match(tt.conn); got != tt.want {
+				t.Fatalf("expected match %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeTopics(t *testing.T) {
+	tests := []struct {
+		name    string
+		topics  []string
+		want    []string
+		wantErr error
+	}{
+		{name: "dedup", topics: []string{"news", "sports", "news"}, want: []string{"news", "sports"}},
+		{name: "empty", topics: []string{"news", ""}, wantErr: ErrInvalidTopic},
+		{name: "space", topics: []string{"live room"}, wantErr: ErrInvalidTopic},
+		{name: "control", topics: []string{"live\x00"}, wantErr: ErrInvalidTopic},
+		{name: "too long", topics: []string{string(make([]byte, maxTopicLen+1))}, wantErr: ErrInvalidTopic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeTopics(tt.topics)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// newBroadcastConn 创建已登录的连接，发送队列只入队不写出
+func newBroadcastConn(id uint64, platformType PlatformType, appVersion string) *connection {
+	c := &connection{
+		id:           id,
+		userID:       "user",
+		platformType: platformType,
+		appVersion:   appVersion,
+		version:      Version,
+		queue:        newWriteQueue(WriteQueueConfig{Size: 8, MaxBatchBytes: 1024}, "test"),
+	}
+	c.queue.writing = true
+	return c
+}
+
+// pending 连接发送队列中的数据包数量
+func (c *connection) pending() int {
+	batch, _, _, _ := c.queue.pop()
+	return len(batch)
+}
+
+func TestBroadcastTopics(t *testing.T) {
+	ctx := context.Background()
+	tr := newBaseTransport()
+	mobile := newBroadcastConn(1, PlatformTypeMobile, "2.0.0")
+	web := newBroadcastConn(2, PlatformTypeWeb, "1.0.0")
+	pc := newBroadcastConn(3, PlatformTypePC, "")
+	for _, c := range []*connection{mobile, web, pc} {
+		tr.connPool.add(c)
+	}
+
+	// 登录时订阅，非法主题整体忽略
+	tr.subscribeLoginTopics(ctx, mobile, []string{"news", "sports", "news"})
+	tr.subscribeLoginTopics(ctx, web, []string{"news", "bad topic"})
+	if err := tr.connPool.subscribe(web, []string{"news"}, maxTopicsPerConn); err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	// 超过上限时不做任何修改
+	if err := tr.connPool.subscribe(pc, []string{"news", "sports"}, 1); !errors.Is(err, ErrTooManyTopics) {
+		t.Fatalf("expected ErrTooManyTopics, got %v", err)
+	}
+	if topics := tr.connPool.topicsOf(pc); len(topics) != 0 {
+		t.Fatalf("expected no topics, got %v", topics)
+	}
+	if topics := tr.connPool.topicsOf(mobile); !slices.Equal(topics, []string{"news", "sports"}) {
+		t.Fatalf("unexpected topics: %v", topics)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		topic  string
+		filter *BroadcastFilter
+		want   []*connection
+	}{
+		{name: "all connections", want: []*connection{mobile, web, pc}},
+		{name: "topic", topic: "news", want: []*connection{mobile, web}},
+		{name: "topic with filter", topic: "news", filter: &BroadcastFilter{MinAppVersion: "1.5"}, want: []*connection{mobile}},
+		{name: "platform filter", filter: &BroadcastFilter{PlatformTypes: []PlatformType{PlatformTypeWeb, PlatformTypePC}}, want: []*connection{web, pc}},
+		{name: "unknown topic", topic: "weather"},
+		{
+			name:  "unsubscribe",
+			setup: func() { tr.connPool.unsubscribe(mobile, []string{"news", "weather"}) },
+			topic: "news",
+			want:  []*connection{web},
+		},
+		{
+			name:  "unsubscribe last",
+			setup: func() { tr.connPool.unsubscribe(web, []string{"news"}) },
+			topic: "news",
+		},
+		{
+			name:  "disconnected",
+			setup: func() { tr.connPool.remove(mobile) },
+			topic: "sports",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+			delivered, err := tr.Broadcast(ctx, tt.topic, tt.filter, []byte("hello"))
+			if err != nil || delivered != len(tt.want) {
+				t.Fatalf("expected %d delivered, got %d err=%v", len(tt.want), delivered, err)
+			}
+			for _, c := range []*connection{mobile, web, pc} {
+				want := 0
+				if slices.Contains(tt.want, c) {
+					want = 1
+				}
+				if got := c.pending(); got != want {
+					t.Fatalf("conn %d: expected %d pushes, got %d", c.id, want, got)
+				}
+			}
+		})
+	}
+
+	if _, err := tr.Broadcast(ctx, "", nil, nil); !errors.Is(err, ErrEmptyBroadcast) {
+		t.Fatalf("expected ErrEmptyBroadcast, got %v", err)
+	}
+}
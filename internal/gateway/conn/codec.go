@@ -0,0 +1,36 @@
+package conn
+
+import (
+	"net"
+)
+
+// frameCodec 连接的分帧方式，不同协议只在如何从连接上读写一个 Packet 上有区别
+type frameCodec interface {
+	// decode 从读缓存中增量解析一个 Packet，n 为消耗的字节数，数据不完整时返回 nil, 0, nil；
+	// 消耗了数据但没有产生 Packet（如 WebSocket 控制帧、分片帧）时返回 nil, n, nil，控制帧的应答通过 w 发送
+	decode(w controlWriter, buf []byte) (packet *Packet, n int, err error)
+	// writePackets 将一批已编码的 Packet 合并写入连接
+	writePackets(conn net.Conn, batch [][]byte) error
+}
+
+// controlWriter 发送分帧协议的控制帧（如 WebSocket pong、close），控制帧已经编码，不再经过 writePackets
+type controlWriter interface {
+	// writeControl 发送控制帧
+	writeControl(data []byte) error
+	// writeLastControl 发送最后一个控制帧，发送完成后以 reason 断开连接
+	writeLastControl(data []byte, reason string) error
+}
+
+// tcpCodec TCP 直接在字节流上传输 Packet
+type tcpCodec struct{}
+
+func (tcpCodec) decode(_ controlWriter, buf []byte) (*Packet, int, error) {
+	return tryDecodePacket(buf)
+}
+
+// writePackets 使用 writev 一次系统调用写出多个 Packet
+func (tcpCodec) writePackets(conn net.Conn, batch [][]byte) error {
+	bufs := net.Buffers(batch)
+	_, err := bufs.WriteTo(conn)
+	return err
+}
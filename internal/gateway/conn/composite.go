@@ -0,0 +1,189 @@
+package conn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	ErrConnNotFound = errors.New("connection not found")
+)
+
+// CompositeTransport 组合多个 Transport，使一个 Gateway 进程同时监听多种协议（TCP、WebSocket...）
+// 连接ID由 Gateway 进程内统一生成，因此在各个 Transport 之间全局唯一，
+// 连接建立时记录 connID 所属的 Transport，Send/BatchSend/CloseConn 据此分发
+type CompositeTransport struct {
+	names      []string
+	transports map[string]Transport
+	// routes key: connID, value: Transport
+	routes sync.Map
+}
+
+// NewCompositeTransport 创建组合 Transport
+func NewCompositeTransport() *CompositeTransport {
+	return &CompositeTransport{
+		transports: make(map[string]Transport),
+	}
+}
+
+// Add 添加一个 Transport，name 用于日志和配置（如 tcp、ws）
+func (c *CompositeTransport) Add(name string, t Transport) {
+	if _, ok := c.transports[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.transports[name] = t
+}
+
+// Start 依次启动所有 Transport，任意一个失败则停止已启动的 Transport
+func (c *CompositeTransport) Start() error {
+	if len(c.names) == 0 {
+		return errors.New("no transport configured")
+	}
+
+	for i, name := range c.names {
+		if err := c.transports[name].Start(); err != nil {
+			for _, started := range c.names[:i] {
+				c.transports[started].Stop()
+			}
+			return fmt.Errorf("start %s transport failed: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Stop 停止所有 Transport
+func (c *CompositeTransport) Stop() error {
+	var errs []error
+	for _, name := range c.names {
+		if err := c.transports[name].Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("stop %s transport failed: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Drain 同时排空所有 Transport 的连接
+func (c *CompositeTransport) Drain(window time.Duration) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, name := range c.names {
+		wg.Add(1)
+		go func(name string, t Transport) {
+			defer wg.Done()
+			if err := t.Drain(window); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("drain %s transport failed: %w", name, err))
+				mu.Unlock()
+			}
+		}(name, c.transports[name])
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// SetHandler 设置事件回调，每个 Transport 的回调都会先经过路由记录
+func (c *CompositeTransport) SetHandler(h EventHandler) {
+	for _, name := range c.names {
+		t := c.transports[name]
+		t.SetHandler(&routeHandler{
+			EventHandler: h,
+			owner:        t,
+			routes:       &c.routes,
+		})
+	}
+}
+
+// Send 发送消息到指定连接
+func (c *CompositeTransport) Send(ctx context.Context, connID uint64, data []byte) error {
+	t, ok := c.route(connID)
+	if !ok {
+		return ErrConnNotFound
+	}
+	return t.Send(ctx, connID, data)
+}
+
+// BatchSend 批量发送消息，按连接所属的 Transport 分组后分别发送
+func (c *CompositeTransport) BatchSend(ctx context.Context, connIDs []uint64, data []byte) ([]uint64, error) {
+	if len(connIDs) == 0 {
+		return nil, nil
+	}
+
+	failConns := make([]uint64, 0)
+	groups := make(map[Transport][]uint64)
+	for _, connID := range connIDs {
+		t, ok := c.route(connID)
+		if !ok {
+			failConns = append(failConns, connID)
+			continue
+		}
+		groups[t] = append(groups[t], connID)
+	}
+
+	for t, ids := range groups {
+		fails, err := t.BatchSend(ctx, ids, data)
+		if err != nil {
+			// 编码等整体性错误，该组连接全部视为失败
+			failConns = append(failConns, ids...)
+			continue
+		}
+		failConns = append(failConns, fails...)
+	}
+
+	return failConns, nil
+}
+
+// CloseConn 关闭指定连接
+func (c *CompositeTransport) CloseConn(ctx context.Context, connID uint64) error {
+	t, ok := c.route(connID)
+	if !ok {
+		return ErrConnNotFound
+	}
+	return t.CloseConn(ctx, connID)
+}
+
+// Broadcast 在所有 Transport 上广播，返回推送成功的连接总数
+func (c *CompositeTransport) Broadcast(ctx context.Context, topic string, filter *BroadcastFilter, data []byte) (int, error) {
+	delivered := 0
+	for _, name := range c.names {
+		n, err := c.transports[name].Broadcast(ctx, topic, filter, data)
+		if err != nil {
+			return delivered, fmt.Errorf("broadcast on %s transport failed: %w", name, err)
+		}
+		delivered += n
+	}
+	return delivered, nil
+}
+
+// route 查找连接所属的 Transport
+func (c *CompositeTransport) route(connID uint64) (Transport, bool) {
+	t, ok := c.routes.Load(connID)
+	if !ok {
+		return nil, false
+	}
+	return t.(Transport), true
+}
+
+// routeHandler 包装上层 EventHandler，在连接建立/断开时维护 connID → Transport 路由
+type routeHandler struct {
+	EventHandler
+	owner  Transport
+	routes *sync.Map
+}
+
+// OnConnect 连接建立，记录路由
+func (h *routeHandler) OnConnect(ctx context.Context, conn Connection) error {
+	h.routes.Store(conn.ID(), h.owner)
+	return h.EventHandler.OnConnect(ctx, conn)
+}
+
+// OnDisconnect 连接断开，删除路由
+func (h *routeHandler) OnDisconnect(ctx context.Context, conn Connection, reason string) {
+	h.routes.Delete(conn.ID())
+	h.EventHandler.OnDisconnect(ctx, conn, reason)
+}
@@ -0,0 +1,126 @@
+package conn
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// fakeTransport 记录发送到本 Transport 的连接
+type fakeTransport struct {
+	Transport
+
+	handler EventHandler
+	sent    []uint64
+	closed  []uint64
+	// fails BatchSend 返回失败的连接
+	fails map[uint64]bool
+}
+
+func (t *fakeTransport) SetHandler(h EventHandler) {
+	t.handler = h
+}
+
+func (t *fakeTransport) Send(_ context.Context, connID uint64, _ []byte) error {
+	t.sent = append(t.sent, connID)
+	return nil
+}
+
+func (t *fakeTransport) BatchSend(_ context.Context, connIDs []uint64, _ []byte) ([]uint64, error) {
+	var fails []uint64
+	for _, connID := range connIDs {
+		if t.fails[connID] {
+			fails = append(fails, connID)
+			continue
+		}
+		t.sent = append(t.sent, connID)
+	}
+	return fails, nil
+}
+
+func (t *fakeTransport) CloseConn(_ context.Context, connID uint64) error {
+	t.closed = append(t.closed, connID)
+	return nil
+}
+
+type fakeEventHandler struct {
+	EventHandler
+}
+
+func (h *fakeEventHandler) OnConnect(context.Context, Connection) error { return nil }
+
+func (h *fakeEventHandler) OnDisconnect(context.Context, Connection, string) {}
+
+type fakeConnection struct {
+	Connection
+	id uint64
+}
+
+func (c *fakeConnection) ID() uint64 { return c.id }
+
+func TestCompositeTransportRouting(t *testing.T) {
+	ctx := context.Background()
+	tcp := &fakeTransport{fails: map[uint64]bool{3: true}}
+	ws := &fakeTransport{}
+	c := NewCompositeTransport()
+	c.Add("tcp", tcp)
+	c.Add("ws", ws)
+	c.SetHandler(&fakeEventHandler{})
+
+	// 连接 1、3 建立在 TCP 上，连接 2 建立在 WebSocket 上，连接 4 已断开
+	for _, conn := range []struct {
+		t  *fakeTransport
+		id uint64
+	}{{tcp, 1}, {ws, 2}, {tcp, 3}, {ws, 4}} {
+		if err := conn.t.handler.OnConnect(ctx, &fakeConnection{id: conn.id}); err != nil {
+			t.Fatalf("connect %d failed: %v", conn.id, err)
+		}
+	}
+	ws.handler.OnDisconnect(ctx, &fakeConnection{id: 4}, "closed")
+
+	tests := []struct {
+		name    string
+		call    func() error
+		wantTCP []uint64
+		wantWS  []uint64
+		wantErr error
+	}{
+		{name: "send tcp", call: func() error { return c.Send(ctx, 1, nil) }, wantTCP: []uint64{1}},
+		{name: "send ws", call: func() error { return c.Send(ctx, 2, nil) }, wantWS: []uint64{2}},
+		{name: "send disconnected", call: func() error { return c.Send(ctx, 4, nil) }, wantErr: ErrConnNotFound},
+		{name: "send unknown", call: func() error { return c.Send(ctx, 5, nil) }, wantErr: ErrConnNotFound},
+		{
+			name: "batch send",
+			call: func() error {
+				fails, err := c.BatchSend(ctx, []uint64{1, 2, 3, 4, 5}, nil)
+				if err != nil {
+					return err
+				}
+				slices.Sort(fails)
+				if !slices.Equal(fails, []uint64{3, 4, 5}) {
+					t.Fatalf("expected fails [3 4 5], got %v", fails)
+				}
+				return nil
+			},
+			wantTCP: []uint64{1},
+			wantWS:  []uint64{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp.sent, ws.sent = nil, nil
+			if err := tt.call(); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !slices.Equal(tcp.sent, tt.wantTCP) || !slices.Equal(ws.sent, tt.wantWS) {
+				t.Fatalf("expected tcp %v ws %v, got tcp %v ws %v", tt.wantTCP, tt.wantWS, tcp.sent, ws.sent)
+			}
+		})
+	}
+
+	if err := c.CloseConn(ctx, 2); err != nil || !slices.Equal(ws.closed, []uint64{2}) || len(tcp.closed) != 0 {
+		t.Fatalf("close should route to ws transport: err=%v tcp=%v ws=%v", err, tcp.closed, ws.closed)
+	}
+}
@@ -1,6 +1,7 @@
 package conn
 
 import (
+	"sort"
 	"sync"
 )
 
@@ -10,12 +11,16 @@ type connPool struct {
 	connsByID sync.Map
 	// connsByUserID key: userID, value: map[connID]*connection (一个用户可能有多个设备)
 	connsByUserID sync.Map
+	// connsByTopic key: topic, value: map[connID]*connection，与连接的 topics 一起由 mu 保护
+	connsByTopic map[string]map[uint64]*connection
 	// mu rwmutex
 	mu sync.RWMutex
 }
 
 func newConnPool() *connPool {
-	return &connPool{}
+	return &connPool{
+		connsByTopic: make(map[string]map[uint64]*connection),
+	}
 }
 
 // add 添加连接
@@ -46,6 +51,101 @@ func (p *connPool) remove(conn *connection) {
 			p.connsByUserID.Delete(conn.userID)
 		}
 	}
+
+	// 从主题索引删除
+	p.mu.Lock()
+	for topic := range conn.topics {
+		p.unindexTopic(topic, conn.id)
+	}
+	conn.topics = nil
+	p.mu.Unlock()
+}
+
+// subscribe 连接订阅主题，订阅后连接的主题数量超过 limit 时不做任何修改并返回 ErrTooManyTopics，
+// 连接已断开时忽略（断开的连接已从索引中移除，不能再加入）
+func (p *connPool) subscribe(conn *connection, topics []string, limit int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if conn.isClosed() {
+		return nil
+	}
+
+	added := 0
+	for _, topic := range topics {
+		if _, ok := conn.topics[topic]; !ok {
+			added++
+		}
+	}
+	if len(conn.topics)+added > limit {
+		return ErrTooManyTopics
+	}
+
+	if conn.topics == nil {
+		conn.topics = make(map[string]struct{}, len(topics))
+	}
+	for _, topic := range topics {
+		conn.topics[topic] = struct{}{}
+		topicConns, ok := p.connsByTopic[topic]
+		if !ok {
+			topicConns = make(map[uint64]*connection)
+			p.connsByTopic[topic] = topicConns
+		}
+		topicConns[conn.id] = conn
+	}
+	return nil
+}
+
+// unsubscribe 连接取消订阅主题
+func (p *connPool) unsubscribe(conn *connection, topics []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, topic := range topics {
+		if _, ok := conn.topics[topic]; !ok {
+			continue
+		}
+		delete(conn.topics, topic)
+		p.unindexTopic(topic, conn.id)
+	}
+}
+
+// unindexTopic 从主题索引中删除连接，调用方需要持有写锁
+func (p *connPool) unindexTopic(topic string, connID uint64) {
+	topicConns, ok := p.connsByTopic[topic]
+	if !ok {
+		return
+	}
+	delete(topicConns, connID)
+	if len(topicConns) == 0 {
+		delete(p.connsByTopic, topic)
+	}
+}
+
+// topicsOf 获取连接订阅的全部主题（按字典序）
+func (p *connPool) topicsOf(conn *connection) []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	topics := make([]string, 0, len(conn.topics))
+	for topic := range conn.topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// getByTopic 获取订阅了主题的所有连接
+func (p *connPool) getByTopic(topic string) []*connection {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	topicConns := p.connsByTopic[topic]
+	conns := make([]*connection, 0, len(topicConns))
+	for _, conn := range topicConns {
+		conns = append(conns, conn)
+	}
+	return conns
 }
 
 // getByID 根据连接ID获取连接
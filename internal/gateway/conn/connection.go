@@ -1,9 +1,15 @@
 package conn
 
 import (
+	"errors"
+	"io"
 	"net"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
+
+	"golang.org/x/sys/unix"
 )
 
 type PlatformType int
@@ -17,15 +23,38 @@ const (
 	PlatformTypeBot                  // 机器人、第三方接入
 )
 
+const (
+	// readScratchSize 工作协程读 socket 时使用的缓冲区大小
+	readScratchSize = 64 * 1024
+	// maxReadPerRound 一轮最多读入读缓存的数据量，超过后先解析处理再继续读，避免单个连接占用过多内存
+	maxReadPerRound = 256 * 1024
+)
+
 type connection struct {
 	id           uint64
-	fd           int
+	fd           int             // 底层 socket fd（非复制），注册到 epoll
+	raw          syscall.RawConn // 用于非阻塞读取 socket，保证读取期间 fd 不会被关闭复用
+	tlsSock      *tlsSocket      // TLS 连接的底层 socket，非 TLS 连接为 nil
+	ep           *epoll          // 连接所在的 epoll
+	readMu       sync.Mutex      // 同一时刻只有一个协程读取和解析
+	inbuf        []byte          // 读缓存，保存还不足一个完整数据包的数据
 	userID       string
 	platformType PlatformType
 	deviceID     string
+	appVersion   string              // 应用版本号，用于广播时按版本过滤
+	meta         map[string]string   // 会话扩展信息，用于广播时按 meta 过滤
+	topics       map[string]struct{} // 订阅的主题，由连接池的锁保护
 	expireTime   time.Time
 	conn         net.Conn
-	lastActiveAt time.Time // 最后活跃时间，用于心跳检测
+	version      byte           // 登录时协商的协议版本，下行数据包都按该版本编码
+	codec        frameCodec     // 分帧方式（TCP/WebSocket）
+	ack          *ackWindow     // 下行推送确认窗口，未开启可靠投递时为 nil
+	queue        *writeQueue    // 发送队列，所有写操作都经过队列由写协程发送
+	upstream     *upstreamQueue // 上行请求队列，按顺序处理上行消息
+	onWriteError func(error)    // 写失败或队列溢出需要断开时的回调
+	onLastWrite  func(string)   // 最后一个数据包（writeLast）发送完成后的回调，参数为断开连接的原因
+	lastActiveAt time.Time      // 最后活跃时间，用于心跳检测
+	closed       int32
 	mu           sync.RWMutex
 }
 
@@ -43,11 +72,197 @@ func (c *connection) getLastActiveTime() time.Time {
 	return c.lastActiveAt
 }
 
-// close 关闭连接
+// isClosed 连接是否已断开
+func (c *connection) isClosed() bool {
+	return atomic.LoadInt32(&c.closed) == 1
+}
+
+// markClosed 标记连接已断开，只有第一次调用返回 true，避免读写两端重复处理断开
+func (c *connection) markClosed() bool {
+	return atomic.CompareAndSwapInt32(&c.closed, 0, 1)
+}
+
+// close 关闭连接，丢弃未发送的数据
 func (c *connection) close() error {
+	if c.queue != nil {
+		c.queue.close()
+	}
 	return c.conn.Close()
 }
 
+// readAvailable 非阻塞读取 socket 数据到读缓存，drained 表示已经读到 EAGAIN，可以等待下一次 epoll 通知
+// TLS 连接读到的是密文，交给 tls.Conn 解密后再放入读缓存
+func (c *connection) readAvailable(scratch []byte) (drained bool, err error) {
+	if c.tlsSock != nil {
+		// tls.Conn 内部可能还缓存着上一轮没有解密完的记录
+		if err := c.decryptTLS(scratch); err != nil {
+			return false, err
+		}
+	}
+
+	for len(c.inbuf) < maxReadPerRound {
+		n, err := c.readSocket(scratch)
+		if err != nil {
+			return false, err
+		}
+		if n == 0 {
+			return true, nil
+		}
+
+		if c.tlsSock == nil {
+			c.inbuf = append(c.inbuf, scratch[:n]...)
+			continue
+		}
+		c.tlsSock.feed(scratch[:n])
+		if err := c.decryptTLS(scratch); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// readSocket 直接读取一次 socket，没有数据时返回 0, nil，对端关闭时返回 io.EOF
+func (c *connection) readSocket(p []byte) (int, error) {
+	var (
+		n    int
+		rerr error
+	)
+	for {
+		if err := c.raw.Read(func(fd uintptr) bool {
+			n, rerr = unix.Read(int(fd), p)
+			// 始终返回 true，不等待可读，由 epoll 通知
+			return true
+		}); err != nil {
+			return 0, err
+		}
+		if rerr != unix.EINTR {
+			break
+		}
+	}
+
+	switch {
+	case rerr == unix.EAGAIN:
+		return 0, nil
+	case rerr != nil:
+		return 0, rerr
+	case n == 0:
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// decryptTLS 从 tls.Conn 读取已解密的数据直到没有完整的 TLS 记录
+func (c *connection) decryptTLS(scratch []byte) error {
+	for {
+		n, err := c.conn.Read(scratch)
+		c.inbuf = append(c.inbuf, scratch[:n]...)
+		if err != nil {
+			if errors.Is(err, errWouldBlock) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// nextPacket 从读缓存中解析下一个数据包，数据不完整时返回 nil
+func (c *connection) nextPacket() (*Packet, error) {
+	for {
+		packet, n, err := c.codec.decode(c, c.inbuf)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			// 缓存为空时释放，空闲连接不占用读缓存
+			if len(c.inbuf) == 0 {
+				c.inbuf = nil
+			}
+			return nil, nil
+		}
+
+		c.inbuf = c.inbuf[n:]
+		if packet != nil {
+			return packet, nil
+		}
+	}
+}
+
+// write 将已编码的数据包放入发送队列，由写协程异步写出
+func (c *connection) write(data []byte) error {
+	startWriter, err := c.queue.push(data)
+	if err != nil {
+		if errors.Is(err, ErrWriteQueueFull) && c.queue.cfg.Policy == OverflowDisconnect && c.onWriteError != nil {
+			c.onWriteError(err)
+		}
+		return err
+	}
+	if startWriter {
+		go c.flush()
+	}
+	return nil
+}
+
+// writeLast 写入最后一个数据包，发送完成后以 reason 回调 onLastWrite（用于先通知客户端再关闭连接）
+func (c *connection) writeLast(data []byte, reason string) error {
+	return c.startFlush(c.queue.pushLast(data, reason))
+}
+
+// writeControl 实现 controlWriter，控制帧经发送队列由写协程优先写出
+func (c *connection) writeControl(data []byte) error {
+	return c.startFlush(c.queue.pushControl(data))
+}
+
+// writeLastControl 实现 controlWriter，最后一个控制帧发送完成后以 reason 回调 onLastWrite
+func (c *connection) writeLastControl(data []byte, reason string) error {
+	return c.startFlush(c.queue.pushLastControl(data, reason))
+}
+
+// startFlush 入队成功且需要时启动写协程
+func (c *connection) startFlush(startWriter bool, err error) error {
+	if err != nil {
+		return err
+	}
+	if startWriter {
+		go c.flush()
+	}
+	return nil
+}
+
+// flush 写协程，按分帧方式合并写出队列中的数据包直到队列为空，控制帧已经编码，原样写出
+func (c *connection) flush() {
+	for {
+		batch, control, done, reason := c.queue.pop()
+		if done && c.onLastWrite != nil {
+			c.onLastWrite(reason)
+		}
+		if len(batch) == 0 {
+			return
+		}
+
+		c.conn.SetWriteDeadline(time.Now().Add(c.queue.cfg.WriteTimeout))
+		var err error
+		if control {
+			bufs := net.Buffers(batch)
+			_, err = bufs.WriteTo(c.conn)
+		} else {
+			err = c.codec.writePackets(c.conn, batch)
+		}
+		if err != nil {
+			c.queue.close()
+			if c.onWriteError != nil {
+				c.onWriteError(err)
+			}
+			return
+		}
+	}
+}
+
+// encode 按连接协商的协议版本编码数据包
+func (c *connection) encode(p Packet) ([]byte, error) {
+	p.Version = c.version
+	return EncodePacket(p)
+}
+
 // 实现 Connection 接口
 
 // ID 返回连接ID
@@ -70,6 +285,11 @@ func (c *connection) DeviceID() string {
 	return c.deviceID
 }
 
+// ProtocolVersion 返回登录时协商的协议版本
+func (c *connection) ProtocolVersion() byte {
+	return c.version
+}
+
 // RemoteAddr 返回远程地址
 func (c *connection) RemoteAddr() net.Addr {
 	return c.conn.RemoteAddr()
@@ -0,0 +1,138 @@
+package conn
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// newSocketPair 创建一对相连的 Unix socket，返回服务端连接对象和客户端连接
+func newSocketPair(t *testing.T) (*connection, net.Conn) {
+	t.Helper()
+	server, client := socketPair(t)
+	raw, err := server.(*net.UnixConn).SyscallConn()
+	if err != nil {
+		t.Fatalf("syscall conn failed: %v", err)
+	}
+	return &connection{raw: raw, conn: server, codec: tcpCodec{}}, client
+}
+
+// socketPair 创建一对相连的 Unix socket，测试结束时关闭
+func socketPair(t *testing.T) (server, client net.Conn) {
+	t.Helper()
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		t.Fatalf("socketpair failed: %v", err)
+	}
+	// 客户端一次写入的数据需要超过 maxReadPerRound 才能覆盖分轮读取
+	if err := unix.SetsockoptInt(fds[1], unix.SOL_SOCKET, unix.SO_SNDBUF, 1<<20); err != nil {
+		t.Fatalf("set send buffer failed: %v", err)
+	}
+
+	fileConn := func(fd int) net.Conn {
+		f := os.NewFile(uintptr(fd), "socketpair")
+		defer f.Close()
+		conn, err := net.FileConn(f)
+		if err != nil {
+			t.Fatalf("file conn failed: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+	return fileConn(fds[0]), fileConn(fds[1])
+}
+
+func TestReadAvailableRounds(t *testing.T) {
+	c, client := newSocketPair(t)
+
+	const count = 100
+	var stream []byte
+	for i := 0; i < count; i++ {
+		body := bytes.Repeat([]byte{byte(i)}, 10*1024)
+		data, err := EncodePacket(Packet{Version: Version, MsgType: MsgTypeUpstream, Body: body})
+		if err != nil {
+			t.Fatalf("encode packet failed: %v", err)
+		}
+		stream = append(stream, data...)
+	}
+
+	// 第一块在开始读之前完整写入 socket，保证第一轮读取达到 maxReadPerRound；其余数据边读边写
+	chunk := maxReadPerRound + readScratchSize
+	client.SetWriteDeadline(time.Now().Add(2 * time.Second))
+	if _, err := client.Write(stream[:chunk]); err != nil {
+		t.Skipf("socket buffer cannot hold %d bytes: %v", chunk, err)
+	}
+	client.SetWriteDeadline(time.Time{})
+	writeErr := make(chan error, 1)
+	go func() {
+		for off := chunk; off < len(stream); off += chunk {
+			if _, err := client.Write(stream[off:min(off+chunk, len(stream))]); err != nil {
+				writeErr <- err
+				return
+			}
+		}
+		writeErr <- nil
+	}()
+
+	scratch := make([]byte, readScratchSize)
+	deadline := time.Now().Add(5 * time.Second)
+	var (
+		got     int
+		limited int
+	)
+	for got < count {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout after %d packets", got)
+		}
+
+		drained, err := c.readAvailable(scratch)
+		if err != nil {
+			t.Fatalf("read failed: %v", err)
+		}
+		// 一轮最多多读一次 scratch
+		if len(c.inbuf) >= maxReadPerRound+readScratchSize {
+			t.Fatalf("read buffer grew to %d bytes", len(c.inbuf))
+		}
+		if !drained {
+			limited++
+		}
+
+		for {
+			packet, err := c.nextPacket()
+			if err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+			if packet == nil {
+				break
+			}
+			if want := bytes.Repeat([]byte{byte(got)}, 10*1024); !bytes.Equal(packet.Body, want) {
+				t.Fatalf("packet %d out of order", got)
+			}
+			got++
+		}
+
+		// 读到 EAGAIN 后等待客户端继续写入，相当于等待下一次 epoll 通知
+		if drained {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	if err := <-writeErr; err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if limited == 0 {
+		t.Fatal("expected at least one round limited by maxReadPerRound")
+	}
+
+	// 数据全部处理完后读到 EAGAIN，读缓存释放
+	drained, err := c.readAvailable(scratch)
+	if err != nil || !drained {
+		t.Fatalf("expected drained, got drained=%v err=%v", drained, err)
+	}
+	if packet, err := c.nextPacket(); packet != nil || err != nil || c.inbuf != nil {
+		t.Fatalf("expected empty read buffer, got packet=%v err=%v buf=%d", packet, err, len(c.inbuf))
+	}
+}
@@ -1,12 +1,20 @@
 package conn
 
 import (
-	"golang.org/x/sys/unix"
+	"errors"
 	"net"
 	"sync"
 	"sync/atomic"
+	"syscall"
+
+	"golang.org/x/sys/unix"
 )
 
+// epollEvents 边缘触发，只在有新数据到达时通知一次，读取方需要一次读空
+const epollEvents = unix.EPOLLIN | unix.EPOLLRDHUP | unix.EPOLLET
+
+// epoll 每个工作协程独享一个 epoll 实例，连接按负载分配到不同的 epoll 上，
+// 同一个连接的事件只会被一个协程处理
 type epoll struct {
 	// fd epoll的fd
 	fd int
@@ -14,10 +22,12 @@ type epoll struct {
 	tables sync.Map
 	// count 当前epoll中监听的fd数量
 	count int32
+	// events 复用的事件缓冲区，只有所属的工作协程调用 wait
+	events []unix.EpollEvent
 }
 
 func newEpoll() (*epoll, error) {
-	fd, err := unix.EpollCreate(0)
+	fd, err := unix.EpollCreate1(unix.EPOLL_CLOEXEC)
 	if err != nil {
 		return nil, err
 	}
@@ -25,67 +35,98 @@ func newEpoll() (*epoll, error) {
 		fd:     fd,
 		tables: sync.Map{},
 		count:  0,
+		// 这里的128是一次性处理的最大事件数，可以根据业务调整
+		events: make([]unix.EpollEvent, 128),
 	}, nil
 }
 
-// add 添加新的fd到epoll中，当前先采用水平触发模式
+// add 添加连接的 fd 到 epoll 中（边缘触发）
 func (e *epoll) add(conn *connection) error {
-	// 这里只有tcp链接才会走epoll，因此可以直接断言获取fd
-	file, err := conn.conn.(*net.TCPConn).File()
-	if err != nil {
-		return err
-	}
-	fd := int32(file.Fd())
 	event := &unix.EpollEvent{
-		Events: unix.EPOLLIN,
-		Fd:     fd,
+		Events: epollEvents,
+		Fd:     int32(conn.fd),
 	}
-	err = unix.EpollCtl(e.fd, unix.EPOLL_CTL_ADD, int(fd), event)
-	if err != nil {
+	if err := unix.EpollCtl(e.fd, unix.EPOLL_CTL_ADD, conn.fd, event); err != nil {
 		return err
 	}
 
-	e.tables.Store(fd, conn)
+	e.tables.Store(int32(conn.fd), conn)
 	atomic.AddInt32(&e.count, 1)
 
 	return nil
 }
 
-// remove 从epoll中删除一个fd
+// remove 从epoll中删除一个fd，需要在关闭连接之前调用
 func (e *epoll) remove(conn *connection) error {
-	// 这里只有tcp链接才会走epoll，因此可以直接断言获取fd
-	file, err := conn.conn.(*net.TCPConn).File()
-	if err != nil {
-		return err
-	}
-
-	err = unix.EpollCtl(e.fd, unix.EPOLL_CTL_DEL, int(file.Fd()), nil)
-	if err != nil {
-		return err
+	if _, ok := e.tables.LoadAndDelete(int32(conn.fd)); !ok {
+		return nil
 	}
-
-	e.tables.Delete(int32(file.Fd()))
 	atomic.AddInt32(&e.count, -1)
 
-	return nil
+	return unix.EpollCtl(e.fd, unix.EPOLL_CTL_DEL, conn.fd, nil)
 }
 
 // wait 等待epoll事件的发生
 func (e *epoll) wait(mesc int) ([]*connection, error) {
-	// 这里的100是一次性处理的最大事件数，可以根据业务调整,后续改成配置项
-	events := make([]unix.EpollEvent, 100)
-	n, err := unix.EpollWait(e.fd, events, mesc)
+	n, err := unix.EpollWait(e.fd, e.events, mesc)
+	if err != nil {
+		if errors.Is(err, unix.EINTR) {
+			return nil, nil
+		}
+		return nil, err
+	}
 
 	conns := make([]*connection, 0, n)
 	for i := 0; i < n; i++ {
-		if conn, ok := e.tables.Load(events[i].Fd); ok {
+		if conn, ok := e.tables.Load(e.events[i].Fd); ok {
 			conns = append(conns, conn.(*connection))
 		}
 	}
-	return conns, err
+	return conns, nil
 }
 
 // getCount 返回当前epoll中监听的fd数量
 func (e *epoll) getCount() int32 {
 	return atomic.LoadInt32(&e.count)
 }
+
+// close 关闭 epoll
+func (e *epoll) close() error {
+	return unix.Close(e.fd)
+}
+
+// tcpConnOf 获取连接底层的 *net.TCPConn，走 epoll 的连接底层都是 TCP（TLS、WebSocket 连接需要先解包）
+func tcpConnOf(conn net.Conn) (*net.TCPConn, error) {
+	for {
+		wrapped, ok := conn.(interface{ NetConn() net.Conn })
+		if !ok {
+			break
+		}
+		conn = wrapped.NetConn()
+	}
+	tcpConn, ok := conn.(*net.TCPConn)
+	if !ok {
+		return nil, errors.New("connection is not tcp")
+	}
+	return tcpConn, nil
+}
+
+// rawConnOf 获取连接底层 socket 的 RawConn 和 fd，不会像 File() 一样复制 fd
+func rawConnOf(conn net.Conn) (syscall.RawConn, int, error) {
+	tcpConn, err := tcpConnOf(conn)
+	if err != nil {
+		return nil, 0, err
+	}
+	raw, err := tcpConn.SyscallConn()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	fd := -1
+	if err := raw.Control(func(s uintptr) {
+		fd = int(s)
+	}); err != nil {
+		return nil, 0, err
+	}
+	return raw, fd, nil
+}
@@ -0,0 +1,41 @@
+package conn
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/wsx864321/kim/pkg/krpc/prome"
+)
+
+const metricNamespace = "kim_gateway"
+
+var (
+	// writeQueueDepthGauge 所有连接写队列中待发送的数据包总数
+	writeQueueDepthGauge = prome.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Subsystem: "write_queue",
+			Name:      "depth",
+		},
+		[]string{"gateway_id"},
+	)
+
+	// writeQueueDepthHistogram 入队时单个连接写队列的长度分布
+	writeQueueDepthHistogram = prome.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricNamespace,
+			Subsystem: "write_queue",
+			Name:      "conn_depth",
+			Buckets:   []float64{1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024},
+		},
+		[]string{"gateway_id"},
+	)
+
+	// writeQueueOverflowCounter 写队列溢出次数，policy 为触发的溢出策略
+	writeQueueOverflowCounter = prome.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Subsystem: "write_queue",
+			Name:      "overflow_total",
+		},
+		[]string{"gateway_id", "policy"},
+	)
+)
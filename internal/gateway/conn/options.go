@@ -43,3 +43,97 @@ func WithRefreshTTLInterval(d time.Duration) TCPOption {
 		o.timeWheel = newTimeWheel(d, slots)
 	}
 }
+
+// WithTLS 开启 TLS，certFile/keyFile 为 PEM 格式的证书和私钥，收到 SIGHUP 时重新加载
+func WithTLS(certFile, keyFile string) TCPOption {
+	return func(o *TCPTransport) {
+		o.certFile = certFile
+		o.keyFile = keyFile
+	}
+}
+
+// WithTLSClientCA 开启客户端证书校验，caFile 为签发客户端证书的 CA（PEM 格式）
+func WithTLSClientCA(caFile string) TCPOption {
+	return func(o *TCPTransport) {
+		o.clientCAFile = caFile
+	}
+}
+
+// WithTCPAck 开启下行可靠投递（推送序号 + 客户端 ACK + 超时重传），只对 v2 连接生效
+func WithTCPAck(cfg AckConfig) TCPOption {
+	return func(o *TCPTransport) {
+		o.ackCfg = cfg.withDefaults()
+	}
+}
+
+// WithTCPWriteQueue 设置连接发送队列（长度、溢出策略、合并写大小、写超时）
+func WithTCPWriteQueue(cfg WriteQueueConfig) TCPOption {
+	return func(o *TCPTransport) {
+		o.writeQueueCfg = cfg.withDefaults()
+	}
+}
+
+// WithTCPUpstream 设置上行请求的处理超时和每个连接的排队数量
+func WithTCPUpstream(cfg UpstreamConfig) TCPOption {
+	return func(o *TCPTransport) {
+		o.upstreamCfg = cfg.withDefaults()
+	}
+}
+
+type WebSocketOption func(transport *WebSocketTransport)
+
+// WithWSHeartbeatTimeout 设置心跳超时时间
+func WithWSHeartbeatTimeout(d time.Duration) WebSocketOption {
+	return func(o *WebSocketTransport) {
+		o.heartbeatTimeout = d
+	}
+}
+
+// WithWSNumWorkers 设置工作线协程数量
+func WithWSNumWorkers(n int) WebSocketOption {
+	return func(o *WebSocketTransport) {
+		o.numWorkers = n
+	}
+}
+
+// WithWSGatewayID 设置 Gateway 节点ID
+func WithWSGatewayID(gatewayID string) WebSocketOption {
+	return func(o *WebSocketTransport) {
+		o.gatewayID = gatewayID
+	}
+}
+
+// WithWSRefreshTTLInterval 设置刷新Session TTL的间隔时间
+func WithWSRefreshTTLInterval(d time.Duration) WebSocketOption {
+	return func(o *WebSocketTransport) {
+		o.refreshTTLInterval = d
+	}
+}
+
+// WithWSPath 设置 WebSocket 握手路径，默认 /ws
+func WithWSPath(path string) WebSocketOption {
+	return func(o *WebSocketTransport) {
+		o.path = path
+	}
+}
+
+// WithWSAck 开启下行可靠投递（推送序号 + 客户端 ACK + 超时重传），只对 v2 连接生效
+func WithWSAck(cfg AckConfig) WebSocketOption {
+	return func(o *WebSocketTransport) {
+		o.ackCfg = cfg.withDefaults()
+	}
+}
+
+// WithWSWriteQueue 设置连接发送队列（长度、溢出策略、合并写大小、写超时）
+func WithWSWriteQueue(cfg WriteQueueConfig) WebSocketOption {
+	return func(o *WebSocketTransport) {
+		o.writeQueueCfg = cfg.withDefaults()
+	}
+}
+
+// WithWSUpstream 设置上行请求的处理超时和每个连接的排队数量
+func WithWSUpstream(cfg UpstreamConfig) WebSocketOption {
+	return func(o *WebSocketTransport) {
+		o.upstreamCfg = cfg.withDefaults()
+	}
+}
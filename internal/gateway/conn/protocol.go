@@ -5,22 +5,37 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net"
+	"math"
 )
 
 // 自定义二进制协议
+//
+// v1:
 // +--------+---------+---------+---------+------------------+
 // | Magic  | Version | MsgType | Length  | Body (PB)        |
 // | 2bytes | 1 byte  | 1 byte  | 4 bytes | N bytes          |
 // +--------+---------+---------+---------+------------------+
+//
+// v2:
+// +--------+---------+---------+-------+---------+-----------+---------+------------+-----------+
+// | Magic  | Version | MsgType | Flags | ExtLen  | RequestID | Length  | Extensions | Body (PB) |
+// | 2bytes | 1 byte  | 1 byte  | 1byte | 2 bytes | 8 bytes   | 4 bytes | ExtLen     | N bytes   |
+// +--------+---------+---------+-------+---------+-----------+---------+------------+-----------+
+// Extensions 由多个 Key(1 byte) + ValueLen(2 bytes) + Value 组成
+//
+// 协议版本按连接协商：客户端使用哪个版本发送登录包，网关之后就用该版本与这个连接通信
 
 type MsgType byte
 
 const (
-	MagicNumber uint16 = 0xABCD
-	Version            = 1
-	HeaderSize         = 8
-	MaxBodySize        = 10 * 1024 * 1024 // 10MB，防止内存攻击
+	MagicNumber  uint16 = 0xABCD
+	Version             = 1 // v1 协议版本
+	VersionV2           = 2 // v2 协议版本，支持 Flags、RequestID 以及头部扩展
+	HeaderSize          = 8
+	HeaderSizeV2        = 19
+	MaxBodySize         = 10 * 1024 * 1024 // 10MB，防止内存攻击
+	// MaxPacketSize 单个数据包的最大长度：v2 头部 + 最大扩展长度（ExtLen 为 2 字节）+ 最大包体
+	MaxPacketSize = HeaderSizeV2 + math.MaxUint16 + MaxBodySize
 )
 
 const (
@@ -29,20 +44,51 @@ const (
 	MsgTypeLogout
 	MsgTypePing
 	MsgTypePong
-	MsgTypeUpstream // 上行消息（客户端→服务端）
-	MsgTypePush     // 推送消息（服务端→客户端）
-	MsgTypeACK      // 确认消息
+	MsgTypeUpstream          // 上行消息（客户端→服务端）
+	MsgTypePush              // 推送消息（服务端→客户端）
+	MsgTypeACK               // 确认消息
+	MsgTypeReconnect         // 服务端通知客户端重连到其他节点（节点下线排空连接时发送，收到后客户端应断开并重新接入）
+	MsgTypeUpstreamResponse  // 上行消息的处理结果（服务端→客户端），包体为 UpstreamResponse，通过请求ID与上行消息对应（仅 v2）
+	MsgTypeSync              // 同步消息（客户端→服务端），包体为 SyncMessagesReq，客户端登录后按同步位点拉取离线消息
+	MsgTypeSyncResponse      // 同步消息的结果（服务端→客户端），包体为 SyncMessagesResp，通过请求ID与同步请求对应
+	MsgTypeSubscribe         // 订阅/取消订阅主题（客户端→服务端），包体为 SubscribeReq，v2 在头部 RequestID 带请求ID
+	MsgTypeSubscribeResponse // 订阅主题的结果（服务端→客户端），包体为 SubscribeResp，通过请求ID与订阅请求对应
 )
 
+// Flags v2 头部标记位
+type Flags byte
+
+const (
+	FlagCompressed Flags = 1 << iota // 包体已压缩
+	FlagEncrypted                    // 包体已加密
+	FlagNeedAck                      // 需要对端回复 ACK
+)
+
+// Has 是否包含指定标记
+func (f Flags) Has(flag Flags) bool {
+	return f&flag != 0
+}
+
 var (
 	ErrInvalidMagic       = errors.New("invalid magic number")
 	ErrUnsupportedVersion = errors.New("unsupported protocol version")
 	ErrBodyTooLarge       = errors.New("message body too large")
+	ErrInvalidExtensions  = errors.New("invalid header extensions")
 )
 
+// Extension v2 头部扩展
+type Extension struct {
+	Key   uint8
+	Value []byte
+}
+
 type Packet struct {
-	MsgType MsgType
-	Body    []byte
+	Version    byte // 协议版本，为 0 时按 v1 编码
+	MsgType    MsgType
+	Flags      Flags       // v2
+	RequestID  uint64      // v2，请求/关联ID，用于匹配上行请求与响应
+	Extensions []Extension // v2，头部扩展
+	Body       []byte
 }
 
 // EncodePacket 编码 Packet → 二进制字节流
@@ -54,6 +100,20 @@ func EncodePacket(p Packet) ([]byte, error) {
 		return nil, fmt.Errorf("%w: %d bytes, max: %d bytes", ErrBodyTooLarge, bodyLen, MaxBodySize)
 	}
 
+	switch p.Version {
+	case 0, Version:
+		return encodeV1(p), nil
+	case VersionV2:
+		return encodeV2(p)
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, p.Version)
+	}
+}
+
+// encodeV1 v1 只有消息类型和包体，Flags、RequestID、Extensions 会被忽略
+func encodeV1(p Packet) []byte {
+	bodyLen := len(p.Body)
+
 	// 预分配完整缓冲区，避免多次扩容
 	buf := make([]byte, HeaderSize+bodyLen)
 
@@ -68,47 +128,182 @@ func EncodePacket(p Packet) ([]byte, error) {
 		copy(buf[HeaderSize:], p.Body)
 	}
 
+	return buf
+}
+
+func encodeV2(p Packet) ([]byte, error) {
+	extLen := 0
+	for _, ext := range p.Extensions {
+		if len(ext.Value) > math.MaxUint16 {
+			return nil, ErrInvalidExtensions
+		}
+		extLen += 3 + len(ext.Value)
+	}
+	if extLen > math.MaxUint16 {
+		return nil, ErrInvalidExtensions
+	}
+
+	bodyLen := len(p.Body)
+	buf := make([]byte, HeaderSizeV2+extLen+bodyLen)
+
+	binary.BigEndian.PutUint16(buf[0:2], MagicNumber)
+	buf[2] = VersionV2
+	buf[3] = byte(p.MsgType)
+	buf[4] = byte(p.Flags)
+	binary.BigEndian.PutUint16(buf[5:7], uint16(extLen))
+	binary.BigEndian.PutUint64(buf[7:15], p.RequestID)
+	binary.BigEndian.PutUint32(buf[15:19], uint32(bodyLen))
+
+	off := HeaderSizeV2
+	for _, ext := range p.Extensions {
+		buf[off] = ext.Key
+		binary.BigEndian.PutUint16(buf[off+1:off+3], uint16(len(ext.Value)))
+		off += 3
+		off += copy(buf[off:], ext.Value)
+	}
+	copy(buf[off:], p.Body)
+
 	return buf, nil
 }
 
 // DecodePacket 解码数据包（不设置超时，由调用方控制）
-func DecodePacket(conn net.Conn) (*Packet, error) {
-	header := make([]byte, HeaderSize)
-	if _, err := io.ReadFull(conn, header); err != nil {
+func DecodePacket(conn io.Reader) (*Packet, error) {
+	// 先读取 Magic + Version，确定头部长度
+	buf := make([]byte, 3, HeaderSizeV2)
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return nil, err
+	}
+	headerSize, err := headerSizeOf(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	buf = buf[:headerSize]
+	if _, err := io.ReadFull(conn, buf[3:]); err != nil {
 		return nil, err
 	}
+	payloadSize, err := payloadSizeOf(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	// 读取扩展和 body
+	if payloadSize > 0 {
+		buf = append(buf, make([]byte, payloadSize)...)
+		if _, err := io.ReadFull(conn, buf[headerSize:]); err != nil {
+			return nil, fmt.Errorf("read body error: %w", err)
+		}
+	}
+
+	return parsePacket(buf, headerSize)
+}
 
-	// 验证 Magic Number
-	magic := binary.BigEndian.Uint16(header[0:2])
-	if magic != MagicNumber {
-		return nil, ErrInvalidMagic
+// tryDecodePacket 从缓冲区中尝试解码一个完整的数据包
+// 数据不足时返回 nil, 0, nil；成功时返回数据包以及消耗的字节数
+func tryDecodePacket(buf []byte) (*Packet, int, error) {
+	if len(buf) < 3 {
+		return nil, 0, nil
+	}
+	headerSize, err := headerSizeOf(buf)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(buf) < headerSize {
+		return nil, 0, nil
+	}
+
+	payloadSize, err := payloadSizeOf(buf)
+	if err != nil {
+		return nil, 0, err
+	}
+	total := headerSize + payloadSize
+	if len(buf) < total {
+		return nil, 0, nil
 	}
 
-	// 验证 Version（支持版本兼容）
-	version := header[2]
-	if version != Version {
-		return nil, fmt.Errorf("%w: got %d, expected %d", ErrUnsupportedVersion, version, Version)
+	packet, err := parsePacket(buf[:total], headerSize)
+	if err != nil {
+		return nil, 0, err
 	}
+	return packet, total, nil
+}
 
-	msgType := MsgType(header[3])
-	length := binary.BigEndian.Uint32(header[4:8])
+// headerSizeOf 校验 Magic Number 与 Version，返回对应版本的头部长度，buf 至少 3 字节
+func headerSizeOf(buf []byte) (int, error) {
+	if magic := binary.BigEndian.Uint16(buf[0:2]); magic != MagicNumber {
+		return 0, ErrInvalidMagic
+	}
+
+	switch version := buf[2]; version {
+	case Version:
+		return HeaderSize, nil
+	case VersionV2:
+		return HeaderSizeV2, nil
+	default:
+		return 0, fmt.Errorf("%w: got %d, expected %d or %d", ErrUnsupportedVersion, version, Version, VersionV2)
+	}
+}
+
+// payloadSizeOf 返回头部之后的数据长度（扩展 + body），buf 至少包含完整头部
+func payloadSizeOf(buf []byte) (int, error) {
+	var extLen, length uint32
+	if buf[2] == VersionV2 {
+		extLen = uint32(binary.BigEndian.Uint16(buf[5:7]))
+		length = binary.BigEndian.Uint32(buf[15:19])
+	} else {
+		length = binary.BigEndian.Uint32(buf[4:8])
+	}
 
 	// 安全检查：限制消息体大小，防止内存攻击
 	if length > MaxBodySize {
-		return nil, fmt.Errorf("%w: %d bytes, max: %d bytes", ErrBodyTooLarge, length, MaxBodySize)
+		return 0, fmt.Errorf("%w: %d bytes, max: %d bytes", ErrBodyTooLarge, length, MaxBodySize)
 	}
+	return int(extLen + length), nil
+}
 
-	// 读取 body
-	var body []byte
-	if length > 0 {
-		body = make([]byte, length)
-		if _, err := io.ReadFull(conn, body); err != nil {
-			return nil, fmt.Errorf("read body error: %w", err)
+// parsePacket 解析一个完整的数据包，buf 恰好是一个数据包，body 会被复制
+func parsePacket(buf []byte, headerSize int) (*Packet, error) {
+	p := &Packet{
+		Version: buf[2],
+		MsgType: MsgType(buf[3]),
+	}
+
+	body := buf[headerSize:]
+	if p.Version == VersionV2 {
+		p.Flags = Flags(buf[4])
+		p.RequestID = binary.BigEndian.Uint64(buf[7:15])
+
+		extLen := int(binary.BigEndian.Uint16(buf[5:7]))
+		exts, err := parseExtensions(buf[headerSize : headerSize+extLen])
+		if err != nil {
+			return nil, err
 		}
+		p.Extensions = exts
+		body = buf[headerSize+extLen:]
+	}
+
+	if len(body) > 0 {
+		p.Body = make([]byte, len(body))
+		copy(p.Body, body)
 	}
+	return p, nil
+}
 
-	return &Packet{
-		MsgType: msgType,
-		Body:    body,
-	}, nil
+// parseExtensions 解析 v2 头部扩展
+func parseExtensions(buf []byte) ([]Extension, error) {
+	var exts []Extension
+	for len(buf) > 0 {
+		if len(buf) < 3 {
+			return nil, ErrInvalidExtensions
+		}
+		n := int(binary.BigEndian.Uint16(buf[1:3]))
+		if len(buf) < 3+n {
+			return nil, ErrInvalidExtensions
+		}
+		value := make([]byte, n)
+		copy(value, buf[3:3+n])
+		exts = append(exts, Extension{Key: buf[0], Value: value})
+		buf = buf[3+n:]
+	}
+	return exts, nil
 }
@@ -0,0 +1,135 @@
+package conn
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func mustEncode(t *testing.T, p Packet) []byte {
+	t.Helper()
+	data, err := EncodePacket(p)
+	if err != nil {
+		t.Fatalf("encode packet failed: %v", err)
+	}
+	return data
+}
+
+func TestTryDecodePacket(t *testing.T) {
+	v1 := mustEncode(t, Packet{MsgType: MsgTypeUpstream, Body: []byte("hello")})
+	v2 := mustEncode(t, Packet{
+		Version:    VersionV2,
+		MsgType:    MsgTypeSync,
+		Flags:      FlagNeedAck,
+		RequestID:  42,
+		Extensions: []Extension{{Key: 1, Value: []byte("trace")}, {Key: 2}},
+		Body:       []byte("world"),
+	})
+
+	// v2 头部声明的扩展长度覆盖了整个扩展区，但扩展内部的长度越界
+	badExt := mustEncode(t, Packet{Version: VersionV2, MsgType: MsgTypeSync, Extensions: []Extension{{Key: 1, Value: []byte("ab")}}})
+	binary.BigEndian.PutUint16(badExt[HeaderSizeV2+1:HeaderSizeV2+3], 3)
+
+	// 扩展区长度不足一个扩展头部
+	shortExt := mustEncode(t, Packet{Version: VersionV2, MsgType: MsgTypeSync, Body: []byte("xy")})
+	binary.BigEndian.PutUint16(shortExt[5:7], 2)
+	binary.BigEndian.PutUint32(shortExt[15:19], 0)
+
+	// 扩展长度超过缓冲区中的数据，按数据不足处理
+	longExt := mustEncode(t, Packet{Version: VersionV2, MsgType: MsgTypeSync})
+	binary.BigEndian.PutUint16(longExt[5:7], 100)
+
+	tooLarge := mustEncode(t, Packet{MsgType: MsgTypeUpstream})
+	binary.BigEndian.PutUint32(tooLarge[4:8], MaxBodySize+1)
+
+	badMagic := append([]byte{}, v1...)
+	badMagic[0] = 0
+
+	badVersion := append([]byte{}, v1...)
+	badVersion[2] = 3
+
+	tests := []struct {
+		name    string
+		buf     []byte
+		want    *Packet
+		n       int
+		wantErr error
+	}{
+		{name: "empty", buf: nil},
+		{name: "magic only", buf: v1[:2]},
+		{name: "partial v1 header", buf: v1[:HeaderSize-1]},
+		{name: "partial v1 body", buf: v1[:len(v1)-1]},
+		{name: "partial v2 header", buf: v2[:HeaderSizeV2-1]},
+		{name: "partial v2 extensions", buf: v2[:HeaderSizeV2+4]},
+		{name: "partial v2 body", buf: v2[:len(v2)-1]},
+		{
+			name: "v1",
+			buf:  v1,
+			want: &Packet{Version: Version, MsgType: MsgTypeUpstream, Body: []byte("hello")},
+			n:    len(v1),
+		},
+		{
+			name: "v1 followed by next packet",
+			buf:  append(append([]byte{}, v1...), v2[:5]...),
+			want: &Packet{Version: Version, MsgType: MsgTypeUpstream, Body: []byte("hello")},
+			n:    len(v1),
+		},
+		{
+			name: "v2",
+			buf:  v2,
+			want: &Packet{
+				Version:    VersionV2,
+				MsgType:    MsgTypeSync,
+				Flags:      FlagNeedAck,
+				RequestID:  42,
+				Extensions: []Extension{{Key: 1, Value: []byte("trace")}, {Key: 2, Value: []byte{}}},
+				Body:       []byte("world"),
+			},
+			n: len(v2),
+		},
+		{name: "v2 extLen beyond buffer", buf: longExt},
+		{name: "v2 extension value out of bounds", buf: badExt, wantErr: ErrInvalidExtensions},
+		{name: "v2 truncated extension header", buf: shortExt, wantErr: ErrInvalidExtensions},
+		{name: "body too large", buf: tooLarge, wantErr: ErrBodyTooLarge},
+		{name: "invalid magic", buf: badMagic, wantErr: ErrInvalidMagic},
+		{name: "unsupported version", buf: badVersion, wantErr: ErrUnsupportedVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packet, n, err := tryDecodePacket(tt.buf)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n != tt.n {
+				t.Fatalf("expected %d bytes consumed, got %d", tt.n, n)
+			}
+			if !packetEqual(packet, tt.want) {
+				t.Fatalf("expected packet %+v, got %+v", tt.want, packet)
+			}
+		})
+	}
+}
+
+func packetEqual(a, b *Packet) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Version != b.Version || a.MsgType != b.MsgType || a.Flags != b.Flags || a.RequestID != b.RequestID ||
+		!bytes.Equal(a.Body, b.Body) || len(a.Extensions) != len(b.Extensions) {
+		return false
+	}
+	for i := range a.Extensions {
+		if a.Extensions[i].Key != b.Extensions[i].Key || !bytes.Equal(a.Extensions[i].Value, b.Extensions[i].Value) {
+			return false
+		}
+	}
+	return true
+}
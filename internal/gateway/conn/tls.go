@@ -0,0 +1,165 @@
+package conn
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/wsx864321/kim/pkg/log"
+)
+
+// tlsLoader 负责加载证书并生成 tls.Config，收到 SIGHUP 时重新加载证书（热更新）
+type tlsLoader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string                     // 不为空时开启客户端证书校验
+	current      atomic.Pointer[tls.Config] // 当前生效的配置
+}
+
+// newTLSLoader 创建证书加载器，并立即加载一次证书
+func newTLSLoader(certFile, keyFile, clientCAFile string) (*tlsLoader, error) {
+	l := &tlsLoader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+	if err := l.reload(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// reload 重新加载证书，失败时保留旧配置
+func (l *tlsLoader) reload() error {
+	cert, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+	if err != nil {
+		return fmt.Errorf("load tls key pair failed: %w", err)
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if l.clientCAFile != "" {
+		raw, err := os.ReadFile(l.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("read client ca failed: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(raw) {
+			return errors.New("no valid certificate in client ca file")
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	l.current.Store(cfg)
+	return nil
+}
+
+// config 返回新连接使用的 tls.Config，握手时再取当前生效的配置，保证热更新对新连接立即生效
+func (l *tlsLoader) config() *tls.Config {
+	return &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return l.current.Load(), nil
+		},
+	}
+}
+
+// watch 监听 SIGHUP 信号重新加载证书，直到 ctx 结束
+func (l *tlsLoader) watch(ctx context.Context) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	defer signal.Stop(ch)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ch:
+			if err := l.reload(); err != nil {
+				log.Error(ctx, "reload tls certificate failed", log.String("error", err.Error()))
+				continue
+			}
+			log.Info(ctx, "tls certificate reloaded", log.String("cert", l.certFile))
+		}
+	}
+}
+
+// errWouldBlock 非阻塞模式下 tlsSocket 没有数据可读
+// 实现 net.Error 且为临时错误，tls.Conn 不会因此把连接标记为失败，下次有数据时可以继续读取
+var errWouldBlock net.Error = wouldBlockError{}
+
+type wouldBlockError struct{}
+
+func (wouldBlockError) Error() string   { return "tls socket would block" }
+func (wouldBlockError) Timeout() bool   { return true }
+func (wouldBlockError) Temporary() bool { return true }
+
+// tlsSocket tls.Conn 的底层连接
+// 握手和登录阶段直接阻塞读取 socket；进入 epoll 之后由工作协程把读到的密文喂进来，
+// tls.Conn 读取时只消费已喂入的数据，没有数据时返回 errWouldBlock，不会阻塞工作协程。
+// 写操作始终直接写 socket
+type tlsSocket struct {
+	net.Conn
+	nonblock bool
+	buf      []byte
+}
+
+// setNonblock 切换到由 epoll 喂数据的模式，只能在没有并发读取时调用
+func (s *tlsSocket) setNonblock() {
+	s.nonblock = true
+}
+
+// feed 追加从 socket 读到的密文
+func (s *tlsSocket) feed(p []byte) {
+	s.buf = append(s.buf, p...)
+}
+
+func (s *tlsSocket) Read(p []byte) (int, error) {
+	if !s.nonblock {
+		return s.Conn.Read(p)
+	}
+	if len(s.buf) == 0 {
+		s.buf = nil
+		return 0, errWouldBlock
+	}
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+// NetConn 返回底层连接
+func (s *tlsSocket) NetConn() net.Conn {
+	return s.Conn
+}
+
+// tlsSocketOf 获取 TLS 连接的 tlsSocket，非 TLS 连接返回 nil
+func tlsSocketOf(conn net.Conn) *tlsSocket {
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return nil
+	}
+	sock, _ := tlsConn.NetConn().(*tlsSocket)
+	return sock
+}
+
+// tlsCodec TLS 分帧，解密后的数据与 TCP 一样直接是 Packet 字节流
+type tlsCodec struct {
+	tcpCodec
+}
+
+// writePackets 合并为一次写入，尽量放进同一条 TLS 记录
+func (tlsCodec) writePackets(conn net.Conn, batch [][]byte) error {
+	_, err := conn.Write(bytes.Join(batch, nil))
+	return err
+}
@@ -0,0 +1,145 @@
+package conn
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+// newTestCertificate 生成自签名证书
+func newTestCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key failed: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kim-gateway"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate failed: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// heldConn 握手完成后暂存客户端写出的密文，由测试控制写入 socket 的时机和分段
+type heldConn struct {
+	net.Conn
+	held *bytes.Buffer
+}
+
+func (c *heldConn) Write(p []byte) (int, error) {
+	if c.held != nil {
+		return c.held.Write(p)
+	}
+	return c.Conn.Write(p)
+}
+
+func TestTLSReadAvailable(t *testing.T) {
+	server, client := socketPair(t)
+	raw, err := server.(*net.UnixConn).SyscallConn()
+	if err != nil {
+		t.Fatalf("syscall conn failed: %v", err)
+	}
+
+	// 握手阶段阻塞读取 socket
+	sock := &tlsSocket{Conn: server}
+	tlsServer := tls.Server(sock, &tls.Config{Certificates: []tls.Certificate{newTestCertificate(t)}})
+	clientConn := &heldConn{Conn: client}
+	tlsClient := tls.Client(clientConn, &tls.Config{InsecureSkipVerify: true})
+
+	handshake := make(chan error, 1)
+	go func() { handshake <- tlsClient.Handshake() }()
+	tlsServer.SetDeadline(time.Now().Add(5 * time.Second))
+	if err := tlsServer.Handshake(); err != nil {
+		t.Fatalf("server handshake failed: %v", err)
+	}
+	if err := <-handshake; err != nil {
+		t.Fatalf("client handshake failed: %v", err)
+	}
+	tlsServer.SetDeadline(time.Time{})
+
+	sock.setNonblock()
+	c := &connection{raw: raw, conn: tlsServer, tlsSock: tlsSocketOf(tlsServer), codec: tlsCodec{}}
+	if c.tlsSock != sock {
+		t.Fatal("tls socket not found")
+	}
+
+	// 没有数据时 tls.Conn 读到 errWouldBlock，不阻塞也不报错
+	scratch := make([]byte, readScratchSize)
+	if drained, err := c.readAvailable(scratch); err != nil || !drained {
+		t.Fatalf("expected drained, got drained=%v err=%v", drained, err)
+	}
+
+	// 每个数据包单独一次 Write，大包拆成多条 TLS 记录
+	bodies := [][]byte{[]byte("a"), bytes.Repeat([]byte("b"), 40*1024), []byte("c")}
+	clientConn.held = &bytes.Buffer{}
+	for _, body := range bodies {
+		data, err := EncodePacket(Packet{Version: Version, MsgType: MsgTypeUpstream, Body: body})
+		if err != nil {
+			t.Fatalf("encode packet failed: %v", err)
+		}
+		if _, err := tlsClient.Write(data); err != nil {
+			t.Fatalf("client write failed: %v", err)
+		}
+	}
+	stream := clientConn.held.Bytes()
+
+	// 密文分段写入：不足记录头、记录中间截断、剩余全部
+	var got [][]byte
+	for _, end := range []int{3, len(stream) / 2, len(stream)} {
+		start := len(stream) - clientConn.held.Len()
+		if _, err := client.Write(clientConn.held.Next(end - start)); err != nil {
+			t.Fatalf("client socket write failed: %v", err)
+		}
+
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			if time.Now().After(deadline) {
+				t.Fatalf("timeout reading up to %d bytes", end)
+			}
+			drained, err := c.readAvailable(scratch)
+			if err != nil {
+				t.Fatalf("read failed: %v", err)
+			}
+			for {
+				packet, err := c.nextPacket()
+				if err != nil {
+					t.Fatalf("decode failed: %v", err)
+				}
+				if packet == nil {
+					break
+				}
+				got = append(got, packet.Body)
+			}
+			// 本段密文全部被读走且 tls.Conn 消费完已喂入的数据
+			if drained && len(c.tlsSock.buf) == 0 {
+				break
+			}
+		}
+	}
+
+	if len(got) != len(bodies) {
+		t.Fatalf("expected %d packets, got %d", len(bodies), len(got))
+	}
+	for i := range bodies {
+		if !bytes.Equal(got[i], bodies[i]) {
+			t.Fatalf("packet %d mismatch: %d bytes", i, len(got[i]))
+		}
+	}
+	if c.inbuf != nil {
+		t.Fatalf("expected empty read buffer, got %d bytes", len(c.inbuf))
+	}
+}
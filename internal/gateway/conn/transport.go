@@ -2,8 +2,11 @@ package conn
 
 import (
 	"context"
-	sessionpb "github.com/wsx864321/kim/idl/session"
 	"net"
+	"time"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	sessionpb "github.com/wsx864321/kim/idl/session"
 )
 
 // Transport 底层传输抽象接口
@@ -12,6 +15,8 @@ type Transport interface {
 	Start() error
 	// Stop 停止服务
 	Stop() error
+	// Drain 排空连接：停止接收新连接，通知客户端重连到其他节点，并在 window 内逐步关闭连接
+	Drain(window time.Duration) error
 	// SetHandler 设置事件回调
 	SetHandler(h EventHandler)
 	// Send 发送消息到指定连接
@@ -20,6 +25,8 @@ type Transport interface {
 	BatchSend(ctx context.Context, connIDs []uint64, data []byte) ([]uint64, error)
 	// CloseConn 关闭指定连接
 	CloseConn(ctx context.Context, connID uint64) error
+	// Broadcast 推送消息到满足过滤条件的连接，topic 不为空时只推送给订阅了该主题的连接，返回推送成功的连接数量
+	Broadcast(ctx context.Context, topic string, filter *BroadcastFilter, data []byte) (int, error)
 }
 
 // EventHandler 定义 Transport 生命周期回调
@@ -28,8 +35,10 @@ type EventHandler interface {
 	OnLogin(ctx context.Context, conn net.Conn, payload []byte, gatewayID string) (*sessionpb.Session, error)
 	// OnConnect 连接建立且已鉴权
 	OnConnect(ctx context.Context, conn Connection) error
-	// OnMessage 收到业务消息
-	OnMessage(ctx context.Context, conn Connection, data []byte) error
+	// OnMessage 收到业务消息，返回的结果会回复给客户端，ctx 带有上行请求超时
+	OnMessage(ctx context.Context, conn Connection, data []byte) (*messagepb.UpstreamResponse, error)
+	// OnSync 收到同步消息请求，返回的结果会回复给客户端，ctx 带有上行请求超时
+	OnSync(ctx context.Context, conn Connection, data []byte) (*messagepb.SyncMessagesResp, error)
 	// OnDisconnect 连接断开
 	OnDisconnect(ctx context.Context, conn Connection, reason string)
 	// OnHeartbeat 收到心跳消息
@@ -48,6 +57,8 @@ type Connection interface {
 	PlatformType() PlatformType
 	// DeviceID 返回设备ID
 	DeviceID() string
+	// ProtocolVersion 返回登录时协商的协议版本（v1/v2）
+	ProtocolVersion() byte
 	// RemoteAddr 返回远程地址
 	RemoteAddr() net.Addr
 	// Conn 返回底层连接
@@ -0,0 +1,194 @@
+package conn
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/protobuf/proto"
+)
+
+// UpstreamConfig 上行请求配置
+// v2 的上行请求（MsgTypeUpstream、MsgTypeSync）在头部 RequestID 中带有客户端生成的请求ID，
+// 网关处理完成后回复对应的响应包（MsgTypeUpstreamResponse、MsgTypeSyncResponse），响应带有相同的请求ID；
+// v1 没有请求ID，包体即业务数据，上行消息保持原有行为不回复，其他请求的响应按请求顺序回复
+type UpstreamConfig struct {
+	// Timeout 单个上行请求的处理超时时间，超时回复失败
+	Timeout time.Duration
+	// MaxPending 每个连接排队等待处理的上行请求数量，超过后直接回复失败
+	MaxPending int
+}
+
+// withDefaults 填充未设置的配置
+func (c UpstreamConfig) withDefaults() UpstreamConfig {
+	if c.Timeout <= 0 {
+		c.Timeout = 5 * time.Second
+	}
+	if c.MaxPending <= 0 {
+		c.MaxPending = 64
+	}
+	return c
+}
+
+// upstreamQueue 连接的上行请求队列
+// 同一个连接的请求按顺序处理（保证消息顺序），在独立协程中执行，不阻塞 epoll 工作协程；
+// 协程在队列为空时退出，有请求时再按需启动
+type upstreamQueue struct {
+	mu      sync.Mutex
+	size    int
+	tasks   []func()
+	running bool
+}
+
+func newUpstreamQueue(size int) *upstreamQueue {
+	return &upstreamQueue{size: size}
+}
+
+// submit 提交任务，队列已满时返回 false
+func (q *upstreamQueue) submit(task func()) bool {
+	q.mu.Lock()
+	if len(q.tasks) >= q.size {
+		q.mu.Unlock()
+		return false
+	}
+	q.tasks = append(q.tasks, task)
+	if q.running {
+		q.mu.Unlock()
+		return true
+	}
+	q.running = true
+	q.mu.Unlock()
+
+	go q.run()
+	return true
+}
+
+func (q *upstreamQueue) run() {
+	for {
+		q.mu.Lock()
+		if len(q.tasks) == 0 {
+			q.running = false
+			q.mu.Unlock()
+			return
+		}
+		task := q.tasks[0]
+		q.tasks[0] = nil
+		q.tasks = q.tasks[1:]
+		q.mu.Unlock()
+
+		task()
+	}
+}
+
+// requestKind 请求类数据包（上行消息、同步消息）的处理方式，都按连接串行处理并回复对应类型的响应包
+type requestKind struct {
+	// respType 响应包类型
+	respType MsgType
+	// call 调用上层处理请求
+	call func(ctx context.Context, handler EventHandler, conn Connection, body []byte) (proto.Message, error)
+	// failed 生成失败响应
+	failed func(e *xerr.Error) proto.Message
+}
+
+var (
+	// upstreamRequest 上行消息，响应为 UpstreamResponse
+	upstreamRequest = requestKind{
+		respType: MsgTypeUpstreamResponse,
+		call: func(ctx context.Context, handler EventHandler, conn Connection, body []byte) (proto.Message, error) {
+			resp, err := handler.OnMessage(ctx, conn, body)
+			if err != nil || resp == nil {
+				return nil, err
+			}
+			return resp, nil
+		},
+		failed: func(e *xerr.Error) proto.Message {
+			return &messagepb.UpstreamResponse{Code: e.Code(), Message: e.Error()}
+		},
+	}
+	// syncRequest 同步消息，响应为 SyncMessagesResp
+	syncRequest = requestKind{
+		respType: MsgTypeSyncResponse,
+		call: func(ctx context.Context, handler EventHandler, conn Connection, body []byte) (proto.Message, error) {
+			resp, err := handler.OnSync(ctx, conn, body)
+			if err != nil || resp == nil {
+				return nil, err
+			}
+			return resp, nil
+		},
+		failed: func(e *xerr.Error) proto.Message {
+			return &messagepb.SyncMessagesResp{Code: e.Code(), Message: e.Error()}
+		},
+	}
+)
+
+// handleRequest 处理请求类数据包，处理结果回复给客户端
+func (t *baseTransport) handleRequest(conn *connection, packet *Packet, kind requestKind) {
+	requestID, body := splitRequestID(packet)
+	if !conn.upstream.submit(func() {
+		t.reply(conn, requestID, kind.respType, t.callRequest(conn, body, kind))
+	}) {
+		t.reply(conn, requestID, kind.respType, kind.failed(xerr.ErrTooManyRequests))
+	}
+}
+
+// callRequest 调用上层处理请求，超时或失败时返回对应的错误响应
+func (t *baseTransport) callRequest(conn *connection, body []byte, kind requestKind) proto.Message {
+	if t.handler == nil {
+		return kind.failed(xerr.ErrServiceUnavailable)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.upstreamCfg.Timeout)
+	defer cancel()
+
+	resp, err := kind.call(ctx, t.handler, conn, body)
+	if err != nil {
+		log.Warn(ctx, "request handler failed", log.String("error", err.Error()), log.Uint64("connID", conn.id))
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return kind.failed(xerr.ErrDeadlineExceeded)
+		}
+		return kind.failed(xerr.Convert(err))
+	}
+	if resp == nil {
+		return kind.failed(xerr.OK)
+	}
+	return resp
+}
+
+// reply 回复请求的处理结果，v2 在头部带上请求ID；v1 老客户端不认识上行消息的响应，不回复
+func (t *baseTransport) reply(conn *connection, requestID uint64, respType MsgType, resp proto.Message) {
+	if conn.version != VersionV2 && respType == MsgTypeUpstreamResponse {
+		return
+	}
+
+	body, err := proto.Marshal(resp)
+	if err != nil {
+		log.Error(context.Background(), "marshal response failed", log.String("error", err.Error()))
+		return
+	}
+
+	packet := Packet{MsgType: respType, Body: body}
+	if conn.version == VersionV2 {
+		packet.RequestID = requestID
+	}
+
+	data, err := conn.encode(packet)
+	if err != nil {
+		log.Error(context.Background(), "encode response failed", log.String("error", err.Error()))
+		return
+	}
+	if err := conn.write(data); err != nil {
+		log.Warn(context.Background(), "reply request failed", log.String("error", err.Error()), log.Uint64("connID", conn.id))
+	}
+}
+
+// splitRequestID 解析上行请求的请求ID和业务数据，v1 没有请求ID，整个包体都是业务数据
+func splitRequestID(packet *Packet) (uint64, []byte) {
+	if packet.Version == VersionV2 {
+		return packet.RequestID, packet.Body
+	}
+	return 0, packet.Body
+}
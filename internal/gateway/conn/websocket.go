@@ -0,0 +1,292 @@
+package conn
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/wsx864321/kim/pkg/log"
+)
+
+var (
+	ErrWSInvalidPath   = errors.New("invalid websocket path")
+	ErrWSNotBinaryData = errors.New("websocket frame is not binary")
+	ErrWSInvalidPacket = errors.New("websocket message is not a complete packet")
+)
+
+// WebSocketTransport WebSocket 传输层
+// 与 TCP 使用相同的 Packet 协议，每个 binary frame 承载一个完整的 Packet，
+// 握手完成后底层仍然是 *net.TCPConn，因此同样由 epoll 驱动读取
+type WebSocketTransport struct {
+	*baseTransport
+	port     int
+	path     string
+	ln       *net.TCPListener
+	upgrader ws.Upgrader
+}
+
+// NewWebSocketTransport 创建 WebSocket Transport
+func NewWebSocketTransport(port int, opts ...WebSocketOption) (*WebSocketTransport, error) {
+	ln, err := net.ListenTCP("tcp", &net.TCPAddr{Port: port})
+	if err != nil {
+		return nil, err
+	}
+
+	t := &WebSocketTransport{
+		baseTransport: newBaseTransport(),
+		port:          port,
+		path:          "/ws",
+		ln:            ln,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if err := t.init(); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	t.upgrader = ws.Upgrader{
+		OnRequest: t.checkPath,
+	}
+
+	return t, nil
+}
+
+// Start 启动服务
+func (t *WebSocketTransport) Start() error {
+	if t.isStopped() {
+		return errors.New("transport already stopped")
+	}
+
+	// 启动 accept 处理协程
+	t.acceptLoop()
+
+	t.start()
+
+	return nil
+}
+
+// Stop 停止服务
+func (t *WebSocketTransport) Stop() error {
+	if !atomic.CompareAndSwapInt32(&t.stopped, 0, 1) {
+		return nil
+	}
+
+	t.cancel()
+	t.ln.Close()
+
+	t.shutdown()
+	return nil
+}
+
+// Drain 排空连接：停止 accept，通知客户端重连到其他节点，并在 window 内逐步关闭所有连接
+func (t *WebSocketTransport) Drain(window time.Duration) error {
+	if t.isStopped() {
+		return errors.New("transport already stopped")
+	}
+	if !t.startDraining() {
+		return nil
+	}
+
+	t.ln.Close()
+	t.drain(window)
+	return nil
+}
+
+// acceptLoop accept 循环，多协程处理 accept
+func (t *WebSocketTransport) acceptLoop() {
+	for i := 0; i < runtime.NumCPU(); i++ {
+		t.wg.Add(1)
+		go func() {
+			defer t.wg.Done()
+
+			for {
+				select {
+				case <-t.ctx.Done():
+					return
+				default:
+					conn, err := t.ln.AcceptTCP()
+					if err != nil {
+						if t.isStopped() || t.isDraining() {
+							return
+						}
+						if ne, ok := err.(net.Error); ok && ne.Temporary() {
+							log.Warn(context.Background(), "ws accept temp err", log.String("error", err.Error()))
+							time.Sleep(10 * time.Millisecond)
+							continue
+						}
+						log.Error(context.Background(), "ws accept err", log.String("error", err.Error()))
+						return
+					}
+
+					// 设置 TCP 选项
+					conn.SetNoDelay(true)
+					conn.SetKeepAlive(true)
+					conn.SetKeepAlivePeriod(30 * time.Second)
+
+					// 异步完成握手（避免阻塞 accept）
+					go t.handleUpgrade(conn)
+				}
+			}
+		}()
+	}
+}
+
+// handleUpgrade 完成 WebSocket 握手，之后按普通连接走登录流程
+func (t *WebSocketTransport) handleUpgrade(conn net.Conn) {
+	ctx := context.Background()
+	// 握手超时
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	if _, err := t.upgrader.Upgrade(conn); err != nil {
+		log.Warn(ctx, "websocket upgrade failed", log.String("error", err.Error()), log.String("remote", conn.RemoteAddr().String()))
+		conn.Close()
+		return
+	}
+	conn.SetDeadline(time.Time{})
+
+	t.handleNewConnection(conn, newWSCodec())
+}
+
+// checkPath 校验握手请求路径（忽略 query 参数）
+func (t *WebSocketTransport) checkPath(uri []byte) error {
+	if i := bytes.IndexByte(uri, '?'); i >= 0 {
+		uri = uri[:i]
+	}
+	if string(uri) != t.path {
+		return ws.RejectConnectionError(
+			ws.RejectionStatus(404),
+			ws.RejectionReason(fmt.Sprintf("%s: %s", ErrWSInvalidPath.Error(), uri)),
+		)
+	}
+	return nil
+}
+
+// wsCodec WebSocket 分帧，Packet 放在 binary frame 中传输，每个连接独立一个实例（保存分片状态）
+// 从读缓存中增量解析帧，ping/close 控制帧的应答交给发送队列由写协程写出
+type wsCodec struct {
+	fragmented bool   // 是否正在接收分片消息
+	fragments  []byte // 已收到的分片数据
+	closing    bool   // 已收到 close 帧，等待 close 应答发送完成后断开连接
+}
+
+func newWSCodec() *wsCodec {
+	return &wsCodec{}
+}
+
+func (c *wsCodec) decode(w controlWriter, buf []byte) (*Packet, int, error) {
+	// close 之后对端不应再发送数据，收到的数据直接丢弃
+	if c.closing {
+		return nil, len(buf), nil
+	}
+
+	r := bytes.NewReader(buf)
+	h, err := ws.ReadHeader(r)
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+
+	state := ws.StateServerSide
+	if c.fragmented {
+		state |= ws.StateFragmented
+	}
+	if err := ws.CheckHeader(h, state); err != nil {
+		return nil, 0, err
+	}
+	// 一个消息最多承载一个完整的数据包
+	if h.Length > MaxPacketSize || len(c.fragments)+int(h.Length) > MaxPacketSize {
+		return nil, 0, ErrBodyTooLarge
+	}
+
+	headerLen := len(buf) - r.Len()
+	total := headerLen + int(h.Length)
+	if len(buf) < total {
+		return nil, 0, nil
+	}
+
+	payload := make([]byte, h.Length)
+	copy(payload, buf[headerLen:total])
+	ws.Cipher(payload, h.Mask, 0)
+
+	if h.OpCode.IsControl() {
+		return nil, total, c.handleControl(w, h, payload)
+	}
+
+	if h.OpCode == ws.OpText {
+		return nil, 0, ErrWSNotBinaryData
+	}
+	if !h.Fin {
+		c.fragmented = true
+		c.fragments = append(c.fragments, payload...)
+		return nil, total, nil
+	}
+	if c.fragmented {
+		payload = append(c.fragments, payload...)
+		c.fragmented = false
+		c.fragments = nil
+	}
+
+	// 一个消息承载一个完整的 Packet
+	packet, n, err := tryDecodePacket(payload)
+	if err != nil {
+		return nil, 0, err
+	}
+	if packet == nil || n != len(payload) {
+		return nil, 0, ErrWSInvalidPacket
+	}
+	return packet, total, nil
+}
+
+// handleControl 应答控制帧，收到 close 帧时回复 close，发送完成后断开连接
+func (c *wsCodec) handleControl(w controlWriter, h ws.Header, payload []byte) error {
+	switch h.OpCode {
+	case ws.OpPing:
+		data, err := ws.CompileFrame(ws.NewPongFrame(payload))
+		if err != nil {
+			return err
+		}
+		// 已写入最后一个数据包的连接即将关闭，不再应答
+		if err := w.writeControl(data); err != nil && !errors.Is(err, ErrWriteQueueClosed) {
+			return err
+		}
+		return nil
+	case ws.OpClose:
+		c.closing = true
+		data, err := ws.CompileFrame(ws.NewCloseFrame(ws.NewCloseFrameBody(ws.StatusNormalClosure, "")))
+		if err != nil {
+			return err
+		}
+		if err := w.writeLastControl(data, "websocket closed"); err != nil {
+			if errors.Is(err, ErrWriteQueueClosed) {
+				return io.EOF
+			}
+			return err
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// writePackets 每个 Packet 一个 binary frame，多个 frame 合并为一次写入
+func (c *wsCodec) writePackets(conn net.Conn, batch [][]byte) error {
+	var buf bytes.Buffer
+	for _, data := range batch {
+		if err := ws.WriteFrame(&buf, ws.NewBinaryFrame(data)); err != nil {
+			return err
+		}
+	}
+	_, err := conn.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,191 @@
+package conn
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/gobwas/ws"
+)
+
+// recordControl 记录控制帧应答
+type recordControl struct {
+	written bytes.Buffer
+	// reason 最后一个控制帧之后断开连接的原因
+	reason string
+}
+
+func (w *recordControl) writeControl(data []byte) error {
+	w.written.Write(data)
+	return nil
+}
+
+func (w *recordControl) writeLastControl(data []byte, reason string) error {
+	w.written.Write(data)
+	w.reason = reason
+	return nil
+}
+
+// clientFrame 编码客户端发出的帧（客户端→服务端的帧必须带掩码）
+func clientFrame(t *testing.T, f ws.Frame) []byte {
+	t.Helper()
+	data, err := ws.CompileFrame(ws.MaskFrame(f))
+	if err != nil {
+		t.Fatalf("compile frame failed: %v", err)
+	}
+	return data
+}
+
+func concat(parts ...[]byte) []byte {
+	var buf []byte
+	for _, p := range parts {
+		buf = append(buf, p...)
+	}
+	return buf
+}
+
+func TestWSCodecDecode(t *testing.T) {
+	packet := mustEncode(t, Packet{MsgType: MsgTypeUpstream, Body: []byte("hello")})
+	want := &Packet{Version: Version, MsgType: MsgTypeUpstream, Body: []byte("hello")}
+	binary := clientFrame(t, ws.NewBinaryFrame(packet))
+
+	first := clientFrame(t, ws.NewFrame(ws.OpBinary, false, packet[:5]))
+	middle := clientFrame(t, ws.NewFrame(ws.OpContinuation, false, packet[5:9]))
+	last := clientFrame(t, ws.NewFrame(ws.OpContinuation, true, packet[9:]))
+	ping := clientFrame(t, ws.NewPingFrame([]byte("p")))
+	closeFrame := clientFrame(t, ws.NewCloseFrame(ws.NewCloseFrameBody(ws.StatusNormalClosure, "")))
+
+	frameHeader := func(length int64) []byte {
+		var buf bytes.Buffer
+		if err := ws.WriteHeader(&buf, ws.Header{Fin: true, OpCode: ws.OpBinary, Masked: true, Mask: ws.NewMask(), Length: length}); err != nil {
+			t.Fatalf("write header failed: %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name string
+		buf  []byte
+		// fragments 解码前已经收到的分片数据
+		fragments []byte
+		want      []*Packet
+		consumed  int
+		wantErr   error
+		// reply 期望应答的控制帧类型
+		reply ws.OpCode
+		// closed 期望应答 close 后断开连接
+		closed bool
+	}{
+		{name: "empty"},
+		{name: "partial header", buf: binary[:1]},
+		{name: "partial payload", buf: binary[:len(binary)-1]},
+		{name: "binary", buf: binary, want: []*Packet{want}, consumed: len(binary)},
+		{name: "two frames", buf: concat(binary, binary), want: []*Packet{want, want}, consumed: 2 * len(binary)},
+		{
+			name:     "fragmented",
+			buf:      concat(first, middle, last),
+			want:     []*Packet{want},
+			consumed: len(first) + len(middle) + len(last),
+		},
+		{name: "fragmented waiting for last", buf: concat(first, middle), consumed: len(first) + len(middle)},
+		{name: "fragmented partial last", buf: concat(first, middle, last[:len(last)-1]), consumed: len(first) + len(middle)},
+		{
+			name:     "ping between fragments",
+			buf:      concat(first, ping, middle, last),
+			want:     []*Packet{want},
+			consumed: len(first) + len(ping) + len(middle) + len(last),
+			reply:    ws.OpPong,
+		},
+		{
+			// close 之后的数据被丢弃
+			name:     "close",
+			buf:      concat(closeFrame, binary),
+			consumed: len(closeFrame) + len(binary),
+			reply:    ws.OpClose,
+			closed:   true,
+		},
+		{name: "unmasked", buf: ws.MustCompileFrame(ws.NewBinaryFrame(packet)), wantErr: ws.ErrProtocolMaskRequired},
+		{name: "text", buf: clientFrame(t, ws.NewTextFrame([]byte("hello"))), wantErr: ErrWSNotBinaryData},
+		{name: "continuation without first", buf: last, wantErr: ws.ErrProtocolContinuationUnexpected},
+		{name: "largest v2 packet waiting for payload", buf: frameHeader(MaxPacketSize)},
+		{name: "oversized frame", buf: frameHeader(MaxPacketSize + 1), wantErr: ErrBodyTooLarge},
+		{
+			name:      "oversized fragments",
+			buf:       last,
+			fragments: make([]byte, MaxPacketSize-len(packet[9:])+1),
+			wantErr:   ErrBodyTooLarge,
+		},
+		{name: "incomplete packet", buf: clientFrame(t, ws.NewBinaryFrame(packet[:len(packet)-1])), wantErr: ErrWSInvalidPacket},
+		{name: "two packets in one message", buf: clientFrame(t, ws.NewBinaryFrame(concat(packet, packet))), wantErr: ErrWSInvalidPacket},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec := newWSCodec()
+			if tt.fragments != nil {
+				codec.fragmented = true
+				codec.fragments = tt.fragments
+			}
+			w := &recordControl{}
+
+			// 与读协程一致：循环解码直到数据不足
+			var (
+				packets  []*Packet
+				consumed int
+				err      error
+			)
+			for {
+				var (
+					p *Packet
+					n int
+				)
+				p, n, err = codec.decode(w, tt.buf[consumed:])
+				if err != nil || n == 0 {
+					break
+				}
+				consumed += n
+				if p != nil {
+					packets = append(packets, p)
+				}
+			}
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if consumed != tt.consumed {
+					t.Fatalf("expected %d bytes consumed, got %d", tt.consumed, consumed)
+				}
+				if len(packets) != len(tt.want) {
+					t.Fatalf("expected %d packets, got %d", len(tt.want), len(packets))
+				}
+				for i := range packets {
+					if !packetEqual(packets[i], tt.want[i]) {
+						t.Fatalf("expected packet %+v, got %+v", tt.want[i], packets[i])
+					}
+				}
+			}
+
+			if closed := w.reason != ""; closed != tt.closed {
+				t.Fatalf("expected closed %v, got reason %q", tt.closed, w.reason)
+			}
+			if tt.reply == 0 {
+				if w.written.Len() != 0 {
+					t.Fatalf("unexpected reply: %x", w.written.Bytes())
+				}
+				return
+			}
+			h, err := ws.ReadHeader(&w.written)
+			if err != nil {
+				t.Fatalf("read reply failed: %v", err)
+			}
+			if h.OpCode != tt.reply || h.Masked {
+				t.Fatalf("expected unmasked %v reply, got %+v", tt.reply, h)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package conn
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ErrWriteQueueFull   = errors.New("write queue full")
+	ErrWriteQueueClosed = errors.New("write queue closed")
+)
+
+// OverflowPolicy 写队列满时的处理策略
+type OverflowPolicy int
+
+const (
+	OverflowDisconnect OverflowPolicy = iota // 断开连接（默认），客户端重连后再补齐消息
+	OverflowDropOldest                       // 丢弃队列中最早的数据包
+	OverflowDropNewest                       // 丢弃新写入的数据包
+)
+
+// String 返回策略名称，与配置中的取值一致
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowDropOldest:
+		return "drop_oldest"
+	case OverflowDropNewest:
+		return "drop_newest"
+	default:
+		return "disconnect"
+	}
+}
+
+// ParseOverflowPolicy 解析配置中的溢出策略（disconnect、drop_oldest、drop_newest）
+func ParseOverflowPolicy(s string) (OverflowPolicy, error) {
+	switch s {
+	case "", "disconnect":
+		return OverflowDisconnect, nil
+	case "drop_oldest":
+		return OverflowDropOldest, nil
+	case "drop_newest":
+		return OverflowDropNewest, nil
+	default:
+		return OverflowDisconnect, fmt.Errorf("unknown write queue overflow policy: %s", s)
+	}
+}
+
+// WriteQueueConfig 连接写队列配置
+type WriteQueueConfig struct {
+	// Size 每个连接最多排队的数据包数量
+	Size int
+	// Policy 队列满时的处理策略
+	Policy OverflowPolicy
+	// MaxBatchBytes 一次写出时合并的最大字节数，多个小包合并为一次系统调用
+	MaxBatchBytes int
+	// WriteTimeout 单次写出超时时间，超时视为连接异常并断开
+	WriteTimeout time.Duration
+}
+
+// withDefaults 填充未设置的配置
+func (c WriteQueueConfig) withDefaults() WriteQueueConfig {
+	if c.Size <= 0 {
+		c.Size = 256
+	}
+	if c.MaxBatchBytes <= 0 {
+		c.MaxBatchBytes = 64 * 1024
+	}
+	if c.WriteTimeout <= 0 {
+		c.WriteTimeout = 10 * time.Second
+	}
+	return c
+}
+
+// maxPendingControls 最多排队的控制帧数量，超过时丢弃最早的（如客户端连续 ping 只需应答最近的）
+const maxPendingControls = 4
+
+// writeQueue 连接的有界发送队列
+// 调用方只负责入队，由唯一的写协程按顺序写出，避免多个协程同时写同一个连接导致数据包交错，
+// 也避免慢连接阻塞推送调用方。写协程在队列为空时退出，有数据时再按需启动，空闲连接不占用协程
+// 分帧协议的控制帧（如 WebSocket pong、close）同样由写协程写出，优先于排队的数据包
+type writeQueue struct {
+	mu       sync.Mutex
+	cfg      WriteQueueConfig
+	frames   [][]byte
+	controls [][]byte // 已编码的控制帧，原样写出
+	writing  bool     // 是否有写协程正在发送
+	closed   bool
+	last     bool   // 已写入最后一个数据包，不再接收新的数据包
+	reason   string // 最后一个数据包发送完成后断开连接的原因
+
+	gatewayID string
+	depth     prometheus.Gauge
+	observer  prometheus.Observer
+}
+
+func newWriteQueue(cfg WriteQueueConfig, gatewayID string) *writeQueue {
+	return &writeQueue{
+		cfg:       cfg,
+		frames:    make([][]byte, 0, 8),
+		gatewayID: gatewayID,
+		depth:     writeQueueDepthGauge.WithLabelValues(gatewayID),
+		observer:  writeQueueDepthHistogram.WithLabelValues(gatewayID),
+	}
+}
+
+// push 数据包入队，startWriter 为 true 时调用方需要启动写协程
+func (q *writeQueue) push(data []byte) (startWriter bool, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.last {
+		return false, ErrWriteQueueClosed
+	}
+
+	if len(q.frames) >= q.cfg.Size {
+		writeQueueOverflowCounter.WithLabelValues(q.gatewayID, q.cfg.Policy.String()).Inc()
+		if q.cfg.Policy != OverflowDropOldest {
+			return false, ErrWriteQueueFull
+		}
+		q.frames[0] = nil
+		q.frames = q.frames[1:]
+		q.depth.Dec()
+	}
+
+	q.frames = append(q.frames, data)
+	q.depth.Inc()
+	q.observer.Observe(float64(len(q.frames)))
+	return q.startWriter(), nil
+}
+
+// pushLast 写入最后一个数据包（不受队列长度限制），之后拒绝新的数据包，
+// 写协程发送完后 pop 返回 done，由调用方以 reason 关闭连接
+func (q *writeQueue) pushLast(data []byte, reason string) (startWriter bool, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.last {
+		return false, ErrWriteQueueClosed
+	}
+
+	q.last, q.reason = true, reason
+	q.frames = append(q.frames, data)
+	q.depth.Inc()
+	return q.startWriter(), nil
+}
+
+// pushControl 控制帧入队（不受队列长度限制），写协程优先写出
+func (q *writeQueue) pushControl(data []byte) (startWriter bool, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.last {
+		return false, ErrWriteQueueClosed
+	}
+
+	if len(q.controls) >= maxPendingControls {
+		q.controls[0] = nil
+		q.controls = q.controls[1:]
+	}
+	q.controls = append(q.controls, data)
+	return q.startWriter(), nil
+}
+
+// pushLastControl 写入最后一个控制帧（如 WebSocket close 应答），丢弃未发送的数据包并拒绝新的数据包，
+// 写协程发送完后 pop 返回 done，由调用方以 reason 关闭连接
+func (q *writeQueue) pushLastControl(data []byte, reason string) (startWriter bool, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.last {
+		return false, ErrWriteQueueClosed
+	}
+
+	q.last, q.reason = true, reason
+	q.depth.Sub(float64(len(q.frames)))
+	q.frames = nil
+	q.controls = append(q.controls, data)
+	return q.startWriter(), nil
+}
+
+// startWriter 没有写协程在发送时标记为发送中，返回 true 表示调用方需要启动写协程（调用方持有锁）
+func (q *writeQueue) startWriter() bool {
+	if q.writing {
+		return false
+	}
+	q.writing = true
+	return true
+}
+
+// pop 取出一批待写出的数据，队列为空时写协程退出，done 表示最后一个数据包已经发送完，reason 为断开连接的原因
+// 有控制帧时先取出所有控制帧（control 为 true），否则取出总大小不超过 MaxBatchBytes（至少一个）的数据包
+func (q *writeQueue) pop() (batch [][]byte, control bool, done bool, reason string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.controls) > 0 {
+		batch, q.controls = q.controls, nil
+		return batch, true, false, ""
+	}
+
+	if len(q.frames) == 0 {
+		q.writing = false
+		done = q.last && !q.closed
+		if done {
+			reason = q.reason
+		}
+		return nil, false, done, reason
+	}
+
+	n, size := 0, 0
+	for n < len(q.frames) && (n == 0 || size+len(q.frames[n]) <= q.cfg.MaxBatchBytes) {
+		size += len(q.frames[n])
+		n++
+	}
+
+	batch = make([][]byte, n)
+	copy(batch, q.frames[:n])
+	q.frames = append(q.frames[:0], q.frames[n:]...)
+	q.depth.Sub(float64(n))
+	return batch, false, false, ""
+}
+
+// close 关闭队列，丢弃未发送的数据包
+func (q *writeQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.depth.Sub(float64(len(q.frames)))
+	q.frames = nil
+	q.controls = nil
+}
@@ -0,0 +1,148 @@
+package conn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWriteQueueOverflow(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy OverflowPolicy
+		// frames 写入的数据包，队列长度为 2
+		frames     []string
+		wantErr    error
+		want       []string
+		disconnect bool
+	}{
+		{name: "not full", policy: OverflowDisconnect, frames: []string{"a", "b"}, want: []string{"a", "b"}},
+		{name: "disconnect", policy: OverflowDisconnect, frames: []string{"a", "b", "c"}, wantErr: ErrWriteQueueFull, want: []string{"a", "b"}, disconnect: true},
+		{name: "drop oldest", policy: OverflowDropOldest, frames: []string{"a", "b", "c", "d"}, want: []string{"c", "d"}},
+		{name: "drop newest", policy: OverflowDropNewest, frames: []string{"a", "b", "c", "d"}, wantErr: ErrWriteQueueFull, want: []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newWriteQueue(WriteQueueConfig{Size: 2, Policy: tt.policy, MaxBatchBytes: 1024}, "test")
+			disconnected := false
+			c := &connection{
+				queue:        q,
+				onWriteError: func(error) { disconnected = true },
+			}
+			// 模拟写协程正在发送，数据包只入队不写出
+			q.writing = true
+
+			var err error
+			for _, frame := range tt.frames {
+				if e := c.write([]byte(frame)); e != nil {
+					err = e
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if disconnected != tt.disconnect {
+				t.Fatalf("expected disconnect %v, got %v", tt.disconnect, disconnected)
+			}
+
+			batch, _, done, _ := q.pop()
+			if done || len(batch) != len(tt.want) {
+				t.Fatalf("expected %v, got %q done=%v", tt.want, batch, done)
+			}
+			for i := range batch {
+				if string(batch[i]) != tt.want[i] {
+					t.Fatalf("expected %v, got %q", tt.want, batch)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteQueuePop(t *testing.T) {
+	q := newWriteQueue(WriteQueueConfig{Size: 8, MaxBatchBytes: 4}, "test")
+
+	startWriter, err := q.push([]byte("ab"))
+	if err != nil || !startWriter {
+		t.Fatalf("first push should start writer: start=%v err=%v", startWriter, err)
+	}
+	for _, frame := range []string{"cd", "e", "large"} {
+		if startWriter, err := q.push([]byte(frame)); err != nil || startWriter {
+			t.Fatalf("push %s: start=%v err=%v", frame, startWriter, err)
+		}
+	}
+	if _, err := q.pushLast([]byte("bye"), "bye"); err != nil {
+		t.Fatalf("push last failed: %v", err)
+	}
+	if _, err := q.push([]byte("late")); !errors.Is(err, ErrWriteQueueClosed) {
+		t.Fatalf("expected queue closed after last packet, got %v", err)
+	}
+
+	// 按 MaxBatchBytes 合并，超过上限的单个数据包单独成批
+	for _, want := range [][]string{{"ab", "cd"}, {"e"}, {"large"}, {"bye"}} {
+		batch, _, done, _ := q.pop()
+		if done || len(batch) != len(want) {
+			t.Fatalf("expected %v, got %q done=%v", want, batch, done)
+		}
+		for i := range batch {
+			if string(batch[i]) != want[i] {
+				t.Fatalf("expected %v, got %q", want, batch)
+			}
+		}
+	}
+	if batch, _, done, reason := q.pop(); batch != nil || !done || reason != "bye" {
+		t.Fatalf("expected done after last packet, got %q done=%v reason=%q", batch, done, reason)
+	}
+}
+
+func TestWriteQueueControl(t *testing.T) {
+	q := newWriteQueue(WriteQueueConfig{Size: 2, MaxBatchBytes: 1024}, "test")
+
+	for _, frame := range []string{"a", "b"} {
+		if _, err := q.push([]byte(frame)); err != nil {
+			t.Fatalf("push %s failed: %v", frame, err)
+		}
+	}
+	// 控制帧不受队列长度限制，只保留最近的 maxPendingControls 个
+	for _, frame := range []string{"p1", "p2", "p3", "p4", "p5"} {
+		if _, err := q.pushControl([]byte(frame)); err != nil {
+			t.Fatalf("push control %s failed: %v", frame, err)
+		}
+	}
+
+	// 控制帧优先于数据包写出
+	wantBatches := []struct {
+		frames  []string
+		control bool
+	}{
+		{frames: []string{"p2", "p3", "p4", "p5"}, control: true},
+		{frames: []string{"a", "b"}},
+	}
+	for _, want := range wantBatches {
+		batch, control, done, _ := q.pop()
+		if done || control != want.control || len(batch) != len(want.frames) {
+			t.Fatalf("expected %v control=%v, got %q control=%v done=%v", want.frames, want.control, batch, control, done)
+		}
+		for i := range batch {
+			if string(batch[i]) != want.frames[i] {
+				t.Fatalf("expected %v, got %q", want.frames, batch)
+			}
+		}
+	}
+
+	// 最后一个控制帧丢弃未发送的数据包，之后拒绝写入
+	if _, err := q.push([]byte("c")); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	if _, err := q.pushLastControl([]byte("close"), "websocket closed"); err != nil {
+		t.Fatalf("push last control failed: %v", err)
+	}
+	if _, err := q.pushControl([]byte("p6")); !errors.Is(err, ErrWriteQueueClosed) {
+		t.Fatalf("expected queue closed after last control, got %v", err)
+	}
+	if batch, control, done, _ := q.pop(); !control || done || len(batch) != 1 || string(batch[0]) != "close" {
+		t.Fatalf("expected close control frame, got %q control=%v done=%v", batch, control, done)
+	}
+	if batch, _, done, reason := q.pop(); batch != nil || !done || reason != "websocket closed" {
+		t.Fatalf("expected done after close, got %q done=%v reason=%q", batch, done, reason)
+	}
+}
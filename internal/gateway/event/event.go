@@ -3,13 +3,16 @@ package event
 import (
 	"context"
 	"errors"
+	messagepb "github.com/wsx864321/kim/idl/message"
 	sessionpb "github.com/wsx864321/kim/idl/session"
 	"github.com/wsx864321/kim/internal/gateway/conn"
+	"github.com/wsx864321/kim/internal/gateway/infra/grpc/message"
 	"github.com/wsx864321/kim/internal/gateway/infra/grpc/session"
 	"github.com/wsx864321/kim/internal/gateway/pkg/id"
 	"github.com/wsx864321/kim/pkg/log"
 	"github.com/wsx864321/kim/pkg/xerr"
 	"github.com/wsx864321/kim/pkg/xjson"
+	"google.golang.org/protobuf/proto"
 	"net"
 	"time"
 )
@@ -17,12 +20,14 @@ import (
 // Event 长连接事件
 type Event struct {
 	sessionCli session.ClientInterface
+	messageCli message.ClientInterface
 }
 
 // NewEvent 创建长连接事件处理器
-func NewEvent(sessionCli session.ClientInterface) *Event {
+func NewEvent(sessionCli session.ClientInterface, messageCli message.ClientInterface) *Event {
 	return &Event{
 		sessionCli: sessionCli,
+		messageCli: messageCli,
 	}
 }
 
@@ -70,9 +75,37 @@ func (e *Event) OnConnect(ctx context.Context, conn conn.Connection) error {
 	return nil
 }
 
-func (e *Event) OnMessage(ctx context.Context, conn conn.Connection, data []byte) error {
-	//TODO 需要实现消息转发逻辑
-	return nil
+// OnMessage 处理上行消息，转发给 Message 服务，发送者以连接登录的用户为准
+func (e *Event) OnMessage(ctx context.Context, conn conn.Connection, data []byte) (*messagepb.UpstreamResponse, error) {
+	req := &messagepb.UpstreamRequest{}
+	if err := proto.Unmarshal(data, req); err != nil {
+		log.Warn(ctx, "unmarshal upstream request failed",
+			log.String("user_id", conn.UserID()),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInvalidParams.WithMessage("invalid upstream request")
+	}
+	req.SenderId = conn.UserID()
+	req.DeviceId = conn.DeviceID()
+	req.ConnId = conn.ID()
+
+	return e.messageCli.SendMessage(ctx, req)
+}
+
+// OnSync 处理同步消息请求，转发给 Message 服务，只能同步连接登录用户的收件箱
+func (e *Event) OnSync(ctx context.Context, conn conn.Connection, data []byte) (*messagepb.SyncMessagesResp, error) {
+	req := &messagepb.SyncMessagesReq{}
+	if err := proto.Unmarshal(data, req); err != nil {
+		log.Warn(ctx, "unmarshal sync request failed",
+			log.String("user_id", conn.UserID()),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInvalidParams.WithMessage("invalid sync request")
+	}
+	req.UserId = conn.UserID()
+	req.DeviceId = conn.DeviceID()
+
+	return e.messageCli.SyncMessages(ctx, req)
 }
 
 func (e *Event) OnDisconnect(ctx context.Context, conn conn.Connection, reason string) {
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	gatewaypb "github.com/wsx864321/kim/idl/gateway"
+	sessionpb "github.com/wsx864321/kim/idl/session"
 	"github.com/wsx864321/kim/internal/gateway/conn"
 	"github.com/wsx864321/kim/internal/gateway/infra/grpc/session"
 	"github.com/wsx864321/kim/pkg/log"
@@ -55,22 +56,47 @@ func (h *GatewayHandler) BatchPushMsg(ctx context.Context, req *gatewaypb.BatchP
 		}, nil
 	}
 
-	// 批量发送消息
-	failConns, err := h.transport.BatchSend(ctx, req.GetConnIds(), req.Msg)
-	if err != nil {
-		log.Warn(ctx, "batch push message failed", log.String("error", err.Error()))
+	// 设置了单独消息内容的连接逐个发送，其余连接批量发送相同消息
+	common := make([]uint64, 0, len(req.GetConnIds()))
+	for _, connID := range req.GetConnIds() {
+		if _, ok := req.GetConnMsgs()[connID]; !ok {
+			common = append(common, connID)
+		}
+	}
+	if len(common) > 0 && len(req.Msg) == 0 {
+		log.Warn(ctx, "msg is empty")
 		return &gatewaypb.BatchPushResp{
-			Code:    xerr.ErrInternalServer.Code(),
-			Message: err.Error(),
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "msg is empty",
 		}, nil
 	}
 
-	// 构建结果列表
-	results := make([]*gatewaypb.PushResult, 0, len(req.GetConnIds()))
 	failMap := make(map[uint64]bool)
-	for _, failConn := range failConns {
-		failMap[failConn] = true
+	if len(common) > 0 {
+		failConns, err := h.transport.BatchSend(ctx, common, req.Msg)
+		if err != nil {
+			log.Warn(ctx, "batch push message failed", log.String("error", err.Error()))
+			return &gatewaypb.BatchPushResp{
+				Code:    xerr.ErrInternalServer.Code(),
+				Message: err.Error(),
+			}, nil
+		}
+		for _, failConn := range failConns {
+			failMap[failConn] = true
+		}
 	}
+	for _, connID := range req.GetConnIds() {
+		msg, ok := req.GetConnMsgs()[connID]
+		if !ok {
+			continue
+		}
+		if err := h.transport.Send(ctx, connID, msg); err != nil {
+			failMap[connID] = true
+		}
+	}
+
+	// 构建结果列表
+	results := make([]*gatewaypb.PushResult, 0, len(req.GetConnIds()))
 
 	for _, connID := range req.GetConnIds() {
 		if failMap[connID] {
@@ -115,3 +141,49 @@ func (h *GatewayHandler) CloseConn(ctx context.Context, req *gatewaypb.CloseConn
 		Message: xerr.OK.Error(),
 	}, nil
 }
+
+// Broadcast 广播消息到本节点满足过滤条件的连接（gRPC接口）
+func (h *GatewayHandler) Broadcast(ctx context.Context, req *gatewaypb.BroadcastReq) (*gatewaypb.BroadcastResp, error) {
+	if len(req.GetMsg()) == 0 {
+		return &gatewaypb.BroadcastResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "msg is empty",
+		}, nil
+	}
+
+	delivered, err := h.transport.Broadcast(ctx, req.GetTopic(), toBroadcastFilter(req.GetFilter()), req.GetMsg())
+	if err != nil {
+		log.Warn(ctx, "broadcast message failed",
+			log.String("topic", req.GetTopic()),
+			log.String("error", err.Error()),
+		)
+		return &gatewaypb.BroadcastResp{
+			Code:      xerr.ErrInternalServer.Code(),
+			Message:   err.Error(),
+			Delivered: int64(delivered),
+		}, nil
+	}
+
+	return &gatewaypb.BroadcastResp{
+		Code:      xerr.OK.Code(),
+		Message:   xerr.OK.Error(),
+		Delivered: int64(delivered),
+	}, nil
+}
+
+// toBroadcastFilter 转换广播过滤条件，请求没有携带过滤条件时返回 nil（不过滤）
+func toBroadcastFilter(f *gatewaypb.BroadcastFilter) *conn.BroadcastFilter {
+	if f == nil {
+		return nil
+	}
+
+	filter := &conn.BroadcastFilter{
+		MinAppVersion: f.GetMinAppVersion(),
+		MaxAppVersion: f.GetMaxAppVersion(),
+		Meta:          f.GetMeta(),
+	}
+	for _, deviceType := range f.GetDeviceTypes() {
+		filter.PlatformTypes = append(filter.PlatformTypes, conn.ToPlatformType(sessionpb.DeviceType(deviceType)))
+	}
+	return filter
+}
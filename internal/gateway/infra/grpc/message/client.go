@@ -0,0 +1,41 @@
+package message
+
+import (
+	"context"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/pkg/krpc"
+	"github.com/wsx864321/kim/pkg/krpc/registry"
+	"github.com/wsx864321/kim/pkg/log"
+)
+
+// Client Message client
+type Client struct {
+	cli messagepb.MessageServiceClient
+}
+
+// NewClient 创建 Message 客户端
+func NewClient(r registry.Registrar) *Client {
+	cli, err := krpc.NewKClient(
+		krpc.WithClientServiceName("kim-message"),
+		krpc.WithClientRegistry(r),
+	)
+	if err != nil {
+		log.Error(nil, "create message client failed",
+			log.String("error", err.Error()),
+		)
+		panic(err)
+	}
+
+	return &Client{cli: messagepb.NewMessageServiceClient(cli.Conn())}
+}
+
+// SendMessage 发送消息
+func (c *Client) SendMessage(ctx context.Context, in *messagepb.UpstreamRequest) (*messagepb.UpstreamResponse, error) {
+	return c.cli.SendMessage(ctx, in)
+}
+
+// SyncMessages 同步收件箱中的消息
+func (c *Client) SyncMessages(ctx context.Context, in *messagepb.SyncMessagesReq) (*messagepb.SyncMessagesResp, error) {
+	return c.cli.SyncMessages(ctx, in)
+}
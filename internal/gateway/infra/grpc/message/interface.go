@@ -0,0 +1,15 @@
+package message
+
+import (
+	"context"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+)
+
+// ClientInterface ...
+type ClientInterface interface {
+	// SendMessage 发送消息
+	SendMessage(ctx context.Context, in *messagepb.UpstreamRequest) (*messagepb.UpstreamResponse, error)
+	// SyncMessages 同步收件箱中的消息
+	SyncMessages(ctx context.Context, in *messagepb.SyncMessagesReq) (*messagepb.SyncMessagesResp, error)
+}
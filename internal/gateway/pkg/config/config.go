@@ -38,6 +38,53 @@ func GetGatewayTCPPort() int {
 	return port
 }
 
+// GetGatewayTransports 获取启用的客户端接入协议列表（tcp、ws），默认只开启 tcp
+func GetGatewayTransports() []string {
+	transports := viper.GetStringSlice("gateway.transports")
+	if len(transports) == 0 {
+		return []string{"tcp"}
+	}
+	return transports
+}
+
+// GetGatewayWSPort 获取 Gateway WebSocket 端口
+func GetGatewayWSPort() int {
+	port := viper.GetInt("gateway.ws_port")
+	if port <= 0 {
+		return 8081
+	}
+	return port
+}
+
+// GetGatewayWSPath 获取 Gateway WebSocket 握手路径
+func GetGatewayWSPath() string {
+	path := viper.GetString("gateway.ws_path")
+	if path == "" {
+		return "/ws"
+	}
+	return path
+}
+
+// GetGatewayTLSEnable 获取 TCP 接入是否开启 TLS
+func GetGatewayTLSEnable() bool {
+	return viper.GetBool("gateway.tls.enable")
+}
+
+// GetGatewayTLSCertFile 获取 TLS 证书路径
+func GetGatewayTLSCertFile() string {
+	return viper.GetString("gateway.tls.cert_file")
+}
+
+// GetGatewayTLSKeyFile 获取 TLS 私钥路径
+func GetGatewayTLSKeyFile() string {
+	return viper.GetString("gateway.tls.key_file")
+}
+
+// GetGatewayTLSClientCAFile 获取校验客户端证书的 CA 路径（为空表示不校验客户端证书）
+func GetGatewayTLSClientCAFile() string {
+	return viper.GetString("gateway.tls.client_ca_file")
+}
+
 // GetGatewayID 获取 Gateway 节点ID
 func GetGatewayID() string {
 	id := viper.GetString("gateway.gateway_id")
@@ -74,6 +121,101 @@ func GetNumWorkers() int {
 	return workers
 }
 
+// GetAckWindowSize 获取每个连接未确认推送的最大数量，0 表示不开启可靠投递
+func GetAckWindowSize() int {
+	size := viper.GetInt("gateway.ack.window_size")
+	if size <= 0 {
+		return 0
+	}
+	return size
+}
+
+// GetAckRetransmitTimeout 获取推送首次重传超时时间（毫秒）
+func GetAckRetransmitTimeout() int {
+	timeout := viper.GetInt("gateway.ack.retransmit_timeout_ms")
+	if timeout <= 0 {
+		return 3000 // 默认3秒
+	}
+	return timeout
+}
+
+// GetAckMaxRetries 获取推送最大重传次数
+func GetAckMaxRetries() int {
+	retries := viper.GetInt("gateway.ack.max_retries")
+	if retries <= 0 {
+		return 3
+	}
+	return retries
+}
+
+// GetWriteQueueSize 获取每个连接发送队列的长度
+func GetWriteQueueSize() int {
+	size := viper.GetInt("gateway.write_queue.size")
+	if size <= 0 {
+		return 256
+	}
+	return size
+}
+
+// GetWriteQueueOverflowPolicy 获取发送队列满时的处理策略（disconnect、drop_oldest、drop_newest）
+func GetWriteQueueOverflowPolicy() string {
+	policy := viper.GetString("gateway.write_queue.overflow_policy")
+	if policy == "" {
+		return "disconnect"
+	}
+	return policy
+}
+
+// GetWriteQueueMaxBatchBytes 获取一次合并写出的最大字节数
+func GetWriteQueueMaxBatchBytes() int {
+	size := viper.GetInt("gateway.write_queue.max_batch_bytes")
+	if size <= 0 {
+		return 64 * 1024
+	}
+	return size
+}
+
+// GetWriteTimeout 获取单次写出超时时间（秒）
+func GetWriteTimeout() int {
+	timeout := viper.GetInt("gateway.write_queue.write_timeout")
+	if timeout <= 0 {
+		return 10
+	}
+	return timeout
+}
+
+// GetUpstreamTimeout 获取上行请求处理超时时间（毫秒）
+func GetUpstreamTimeout() int {
+	timeout := viper.GetInt("gateway.upstream.timeout_ms")
+	if timeout <= 0 {
+		return 5000
+	}
+	return timeout
+}
+
+// GetUpstreamMaxPending 获取每个连接排队等待处理的上行请求数量
+func GetUpstreamMaxPending() int {
+	size := viper.GetInt("gateway.upstream.max_pending")
+	if size <= 0 {
+		return 64
+	}
+	return size
+}
+
+// GetDrainWindow 获取下线时排空连接的时间窗口（秒），连接在窗口内分批关闭
+func GetDrainWindow() int {
+	window := viper.GetInt("gateway.drain_window")
+	if window <= 0 {
+		return 30
+	}
+	return window
+}
+
+// GetMetricsPort 获取 prometheus 指标端口，0 表示不开启
+func GetMetricsPort() int {
+	return viper.GetInt("metrics.port")
+}
+
 // GetLogDebug 获取日志 Debug 模式配置
 func GetLogDebug() bool {
 	return viper.GetBool("log.debug")
@@ -2,7 +2,9 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"github.com/wsx864321/kim/internal/gateway/event"
+	"github.com/wsx864321/kim/internal/gateway/infra/grpc/message"
 	"github.com/wsx864321/kim/internal/gateway/infra/grpc/session"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/wsx864321/kim/internal/gateway/handler"
 	"github.com/wsx864321/kim/internal/gateway/pkg/config"
 	"github.com/wsx864321/kim/pkg/krpc"
+	"github.com/wsx864321/kim/pkg/krpc/prome"
 	"github.com/wsx864321/kim/pkg/krpc/registry"
 	"github.com/wsx864321/kim/pkg/krpc/registry/etcd"
 	"github.com/wsx864321/kim/pkg/log"
@@ -31,11 +34,9 @@ func Run(configPath string) {
 
 	ctx := context.Background()
 
-	// 创建TCP Transport
-	tcpTransport, err := createTCPTransport()
-	if err != nil {
-		log.Error(ctx, "create tcp transport failed", log.String("error", err.Error()))
-		panic(err)
+	// 开启 prometheus 指标采集（连接写队列等）
+	if port := config.GetMetricsPort(); port > 0 {
+		prome.StartAgent("0.0.0.0", port)
 	}
 
 	// 创建Etcd注册中心
@@ -44,25 +45,41 @@ func Run(configPath string) {
 	// 创建Session客户端管理器
 	sessionClient := session.NewClient(r)
 
+	// 创建Message客户端，上行消息转发给Message服务
+	messageClient := message.NewClient(r)
+
+	// 创建Transport（按配置同时监听多种协议）
+	transport, err := createTransport()
+	if err != nil {
+		log.Error(ctx, "create transport failed", log.String("error", err.Error()))
+		panic(err)
+	}
+
 	// 创建Handler
-	gatewayHandler := handler.NewGatewayHandler(sessionClient, tcpTransport)
+	gatewayHandler := handler.NewGatewayHandler(sessionClient, transport)
 
 	// 设置Handler到Transport
-	tcpTransport.SetHandler(event.NewEvent(sessionClient))
+	transport.SetHandler(event.NewEvent(sessionClient, messageClient))
 
-	// 启动TCP Transport
-	if err := tcpTransport.Start(); err != nil {
-		log.Error(ctx, "start tcp transport failed", log.String("error", err.Error()))
+	// 启动Transport
+	if err := transport.Start(); err != nil {
+		log.Error(ctx, "start transport failed", log.String("error", err.Error()))
 		panic(err)
 	}
 
-	log.Info(ctx, "tcp transport started", log.Int("port", config.GetGatewayTCPPort()))
+	log.Info(ctx, "transport started", log.Strings("transports", config.GetGatewayTransports()))
 
 	// 创建gRPC服务器，并注册到服务发现
 	grpcServer := krpc.NewPServer(
 		krpc.WithServiceName(config.GetGatewayServiceName()),
 		krpc.WithPort(config.GetGatewayServicePort()),
 		krpc.WithRegistry(r),
+		// Push 服务根据会话中的 gateway_id 找到连接所在的 Gateway 实例
+		krpc.WithMetadata(map[string]string{"gateway_id": config.GetGatewayID()}),
+		// 收到退出信号后先排空客户端连接，期间保持注册让 Push 继续下推，再从注册中心下线并优雅关闭 gRPC
+		krpc.WithDrain(func(ctx context.Context) {
+			drainTransport(ctx, transport)
+		}),
 	)
 
 	// 注册Gateway gRPC服务
@@ -73,7 +90,7 @@ func Run(configPath string) {
 	log.Info(ctx, "gateway server starting",
 		log.String("service_name", config.GetGatewayServiceName()),
 		log.Int("grpc_port", config.GetGatewayServicePort()),
-		log.Int("tcp_port", config.GetGatewayTCPPort()),
+		log.Strings("transports", config.GetGatewayTransports()),
 		log.String("gateway_id", config.GetGatewayID()),
 	)
 
@@ -81,8 +98,51 @@ func Run(configPath string) {
 	grpcServer.Start(ctx)
 }
 
+// drainTransport 通知客户端重连到其他节点，在配置的时间窗口内逐步关闭连接后停止 Transport
+func drainTransport(ctx context.Context, transport conn.Transport) {
+	window := time.Duration(config.GetDrainWindow()) * time.Second
+	log.Info(ctx, "gateway draining", log.String("window", window.String()))
+
+	if err := transport.Drain(window); err != nil {
+		log.Error(ctx, "drain transport failed", log.String("error", err.Error()))
+	}
+	if err := transport.Stop(); err != nil {
+		log.Error(ctx, "stop transport failed", log.String("error", err.Error()))
+	}
+}
+
+// createTransport 根据配置创建组合 Transport
+func createTransport() (conn.Transport, error) {
+	composite := conn.NewCompositeTransport()
+	for _, name := range config.GetGatewayTransports() {
+		var (
+			t   conn.Transport
+			err error
+		)
+		switch name {
+		case "tcp":
+			t, err = createTCPTransport()
+		case "ws":
+			t, err = createWebSocketTransport()
+		default:
+			return nil, fmt.Errorf("unknown transport: %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("create %s transport failed: %w", name, err)
+		}
+		composite.Add(name, t)
+	}
+
+	return composite, nil
+}
+
 // createTCPTransport 创建TCP Transport
 func createTCPTransport() (conn.Transport, error) {
+	writeQueueCfg, err := writeQueueConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	tcpPort := config.GetGatewayTCPPort()
 	gatewayID := config.GetGatewayID()
 	heartbeatTimeout := time.Duration(config.GetHeartbeatTimeout()) * time.Second
@@ -92,6 +152,8 @@ func createTCPTransport() (conn.Transport, error) {
 		conn.WithGatewayID(gatewayID),
 		conn.WithTCPHeartbeatTimeout(heartbeatTimeout),
 		conn.WithRefreshTTLInterval(refreshTTLInterval),
+		conn.WithTCPWriteQueue(writeQueueCfg),
+		conn.WithTCPUpstream(upstreamConfig()),
 	}
 
 	// 设置工作协程数量
@@ -99,9 +161,82 @@ func createTCPTransport() (conn.Transport, error) {
 		opts = append(opts, conn.WithTCPNumWorkers(numWorkers))
 	}
 
+	// 开启 TLS
+	if config.GetGatewayTLSEnable() {
+		opts = append(opts, conn.WithTLS(config.GetGatewayTLSCertFile(), config.GetGatewayTLSKeyFile()))
+		if caFile := config.GetGatewayTLSClientCAFile(); caFile != "" {
+			opts = append(opts, conn.WithTLSClientCA(caFile))
+		}
+	}
+
+	// 开启下行可靠投递
+	if ackCfg := ackConfig(); ackCfg.WindowSize > 0 {
+		opts = append(opts, conn.WithTCPAck(ackCfg))
+	}
+
 	return conn.NewTCPTransport(tcpPort, opts...)
 }
 
+// createWebSocketTransport 创建WebSocket Transport
+func createWebSocketTransport() (conn.Transport, error) {
+	writeQueueCfg, err := writeQueueConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []conn.WebSocketOption{
+		conn.WithWSGatewayID(config.GetGatewayID()),
+		conn.WithWSPath(config.GetGatewayWSPath()),
+		conn.WithWSHeartbeatTimeout(time.Duration(config.GetHeartbeatTimeout()) * time.Second),
+		conn.WithWSRefreshTTLInterval(time.Duration(config.GetRefreshTTLInterval()) * time.Second),
+		conn.WithWSWriteQueue(writeQueueCfg),
+		conn.WithWSUpstream(upstreamConfig()),
+	}
+
+	// 设置工作协程数量
+	if numWorkers := config.GetNumWorkers(); numWorkers > 0 {
+		opts = append(opts, conn.WithWSNumWorkers(numWorkers))
+	}
+
+	// 开启下行可靠投递
+	if ackCfg := ackConfig(); ackCfg.WindowSize > 0 {
+		opts = append(opts, conn.WithWSAck(ackCfg))
+	}
+
+	return conn.NewWebSocketTransport(config.GetGatewayWSPort(), opts...)
+}
+
+// ackConfig 读取下行可靠投递配置
+func ackConfig() conn.AckConfig {
+	return conn.AckConfig{
+		WindowSize:        config.GetAckWindowSize(),
+		RetransmitTimeout: time.Duration(config.GetAckRetransmitTimeout()) * time.Millisecond,
+		MaxRetries:        config.GetAckMaxRetries(),
+	}
+}
+
+// upstreamConfig 读取上行请求配置
+func upstreamConfig() conn.UpstreamConfig {
+	return conn.UpstreamConfig{
+		Timeout:    time.Duration(config.GetUpstreamTimeout()) * time.Millisecond,
+		MaxPending: config.GetUpstreamMaxPending(),
+	}
+}
+
+// writeQueueConfig 读取连接发送队列配置
+func writeQueueConfig() (conn.WriteQueueConfig, error) {
+	policy, err := conn.ParseOverflowPolicy(config.GetWriteQueueOverflowPolicy())
+	if err != nil {
+		return conn.WriteQueueConfig{}, err
+	}
+	return conn.WriteQueueConfig{
+		Size:          config.GetWriteQueueSize(),
+		Policy:        policy,
+		MaxBatchBytes: config.GetWriteQueueMaxBatchBytes(),
+		WriteTimeout:  time.Duration(config.GetWriteTimeout()) * time.Second,
+	}, nil
+}
+
 // createEtcdRegistry 创建 Etcd 注册中心
 func createEtcdRegistry() registry.Registrar {
 	r, err := etcd.NewETCDRegister(etcd.WithEndpoints(config.GetRegistryEndpoints()))
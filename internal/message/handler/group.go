@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+
+	grouppb "github.com/wsx864321/kim/idl/group"
+	"github.com/wsx864321/kim/internal/message/logic"
+	"github.com/wsx864321/kim/pkg/xerr"
+)
+
+// GroupHandler Group 服务处理器
+type GroupHandler struct {
+	service *logic.GroupService
+
+	grouppb.UnimplementedGroupServiceServer
+}
+
+// NewGroupHandler 创建 GroupHandler 实例
+func NewGroupHandler(service *logic.GroupService) *GroupHandler {
+	return &GroupHandler{
+		service: service,
+	}
+}
+
+// CreateGroup 创建群组
+func (h *GroupHandler) CreateGroup(ctx context.Context, req *grouppb.CreateGroupReq) (*grouppb.CreateGroupResp, error) {
+	if req.OperatorId == "" {
+		return &grouppb.CreateGroupResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "operator_id is empty",
+		}, nil
+	}
+
+	group, err := h.service.CreateGroup(ctx, req)
+	if err != nil {
+		return &grouppb.CreateGroupResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &grouppb.CreateGroupResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    group,
+	}, nil
+}
+
+// GetGroup 获取群组信息
+func (h *GroupHandler) GetGroup(ctx context.Context, req *grouppb.GetGroupReq) (*grouppb.GetGroupResp, error) {
+	if req.GroupId == 0 {
+		return &grouppb.GetGroupResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "group_id is empty",
+		}, nil
+	}
+
+	group, err := h.service.GetGroup(ctx, req.GroupId)
+	if err != nil {
+		return &grouppb.GetGroupResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &grouppb.GetGroupResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    group,
+	}, nil
+}
+
+// UpdateGroup 修改群组信息
+func (h *GroupHandler) UpdateGroup(ctx context.Context, req *grouppb.UpdateGroupReq) (*grouppb.UpdateGroupResp, error) {
+	if req.OperatorId == "" || req.GroupId == 0 {
+		return &grouppb.UpdateGroupResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "operator_id and group_id are required",
+		}, nil
+	}
+
+	group, err := h.service.UpdateGroup(ctx, req)
+	if err != nil {
+		return &grouppb.UpdateGroupResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &grouppb.UpdateGroupResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    group,
+	}, nil
+}
+
+// DismissGroup 解散群组
+func (h *GroupHandler) DismissGroup(ctx context.Context, req *grouppb.DismissGroupReq) (*grouppb.DismissGroupResp, error) {
+	if req.OperatorId == "" || req.GroupId == 0 {
+		return &grouppb.DismissGroupResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "operator_id and group_id are required",
+		}, nil
+	}
+
+	if err := h.service.DismissGroup(ctx, req); err != nil {
+		return &grouppb.DismissGroupResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &grouppb.DismissGroupResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+	}, nil
+}
+
+// AddMembers 添加群成员
+func (h *GroupHandler) AddMembers(ctx context.Context, req *grouppb.AddMembersReq) (*grouppb.AddMembersResp, error) {
+	if req.OperatorId == "" || req.GroupId == 0 || len(req.UserIds) == 0 {
+		return &grouppb.AddMembersResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "operator_id, group_id and user_ids are required",
+		}, nil
+	}
+
+	if err := h.service.AddMembers(ctx, req); err != nil {
+		return &grouppb.AddMembersResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &grouppb.AddMembersResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+	}, nil
+}
+
+// RemoveMembers 移除群成员
+func (h *GroupHandler) RemoveMembers(ctx context.Context, req *grouppb.RemoveMembersReq) (*grouppb.RemoveMembersResp, error) {
+	if req.OperatorId == "" || req.GroupId == 0 || len(req.UserIds) == 0 {
+		return &grouppb.RemoveMembersResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "operator_id, group_id and user_ids are required",
+		}, nil
+	}
+
+	if err := h.service.RemoveMembers(ctx, req); err != nil {
+		return &grouppb.RemoveMembersResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &grouppb.RemoveMembersResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+	}, nil
+}
+
+// GetMembers 获取群成员列表
+func (h *GroupHandler) GetMembers(ctx context.Context, req *grouppb.GetMembersReq) (*grouppb.GetMembersResp, error) {
+	if req.GroupId == 0 {
+		return &grouppb.GetMembersResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "group_id is empty",
+		}, nil
+	}
+
+	members, err := h.service.GetMembers(ctx, req.GroupId)
+	if err != nil {
+		return &grouppb.GetMembersResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &grouppb.GetMembersResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    &grouppb.GetMembersData{Members: members},
+	}, nil
+}
+
+// SetMemberRole 设置成员角色
+func (h *GroupHandler) SetMemberRole(ctx context.Context, req *grouppb.SetMemberRoleReq) (*grouppb.SetMemberRoleResp, error) {
+	if req.OperatorId == "" || req.GroupId == 0 || req.UserId == "" {
+		return &grouppb.SetMemberRoleResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "operator_id, group_id and user_id are required",
+		}, nil
+	}
+
+	if err := h.service.SetMemberRole(ctx, req); err != nil {
+		return &grouppb.SetMemberRoleResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &grouppb.SetMemberRoleResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+	}, nil
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"context"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/internal/message/logic"
+	"github.com/wsx864321/kim/pkg/xerr"
+)
+
+// MessageHandler Message 服务处理器
+type MessageHandler struct {
+	service *logic.MessageService
+
+	messagepb.UnimplementedMessageServiceServer
+}
+
+// NewMessageHandler 创建 MessageHandler 实例
+func NewMessageHandler(service *logic.MessageService) *MessageHandler {
+	return &MessageHandler{
+		service: service,
+	}
+}
+
+// SendMessage 发送消息
+func (h *MessageHandler) SendMessage(ctx context.Context, req *messagepb.UpstreamRequest) (*messagepb.UpstreamResponse, error) {
+	if req.SenderId == "" {
+		return &messagepb.UpstreamResponse{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "sender_id is empty",
+		}, nil
+	}
+
+	if len(req.Payload) == 0 {
+		return &messagepb.UpstreamResponse{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "payload is empty",
+		}, nil
+	}
+
+	result, err := h.service.SendMessage(ctx, req)
+	if err != nil {
+		return &messagepb.UpstreamResponse{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &messagepb.UpstreamResponse{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    result,
+	}, nil
+}
+
+// SyncMessages 同步收件箱中的消息
+func (h *MessageHandler) SyncMessages(ctx context.Context, req *messagepb.SyncMessagesReq) (*messagepb.SyncMessagesResp, error) {
+	if req.UserId == "" {
+		return &messagepb.SyncMessagesResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "user_id is empty",
+		}, nil
+	}
+
+	if req.SinceSeq < 0 {
+		return &messagepb.SyncMessagesResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "since_seq must not be negative",
+		}, nil
+	}
+
+	data, err := h.service.SyncMessages(ctx, req)
+	if err != nil {
+		return &messagepb.SyncMessagesResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &messagepb.SyncMessagesResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    data,
+	}, nil
+}
+
+// GetUnread 获取用户各会话的未读数
+func (h *MessageHandler) GetUnread(ctx context.Context, req *messagepb.GetUnreadReq) (*messagepb.GetUnreadResp, error) {
+	if req.UserId == "" {
+		return &messagepb.GetUnreadResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "user_id is empty",
+		}, nil
+	}
+
+	data, err := h.service.GetUnread(ctx, req.UserId)
+	if err != nil {
+		return &messagepb.GetUnreadResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &messagepb.GetUnreadResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    data,
+	}, nil
+}
+
+// ListConversations 分页获取用户的会话列表
+func (h *MessageHandler) ListConversations(ctx context.Context, req *messagepb.ListConversationsReq) (*messagepb.ListConversationsResp, error) {
+	if req.UserId == "" {
+		return &messagepb.ListConversationsResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "user_id is empty",
+		}, nil
+	}
+
+	if req.Offset < 0 {
+		return &messagepb.ListConversationsResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "offset must not be negative",
+		}, nil
+	}
+
+	data, err := h.service.ListConversations(ctx, req)
+	if err != nil {
+		return &messagepb.ListConversationsResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &messagepb.ListConversationsResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    data,
+	}, nil
+}
+
+// SetConversationPinned 设置会话置顶
+func (h *MessageHandler) SetConversationPinned(ctx context.Context, req *messagepb.SetConversationPinnedReq) (*messagepb.SetConversationPinnedResp, error) {
+	if req.UserId == "" || req.ConversationId == 0 {
+		return &messagepb.SetConversationPinnedResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "user_id and conversation_id are required",
+		}, nil
+	}
+
+	if err := h.service.SetConversationPinned(ctx, req); err != nil {
+		return &messagepb.SetConversationPinnedResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &messagepb.SetConversationPinnedResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+	}, nil
+}
+
+// SetConversationMuted 设置会话免打扰
+func (h *MessageHandler) SetConversationMuted(ctx context.Context, req *messagepb.SetConversationMutedReq) (*messagepb.SetConversationMutedResp, error) {
+	if req.UserId == "" || req.ConversationId == 0 {
+		return &messagepb.SetConversationMutedResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "user_id and conversation_id are required",
+		}, nil
+	}
+
+	if err := h.service.SetConversationMuted(ctx, req); err != nil {
+		return &messagepb.SetConversationMutedResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &messagepb.SetConversationMutedResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+	}, nil
+}
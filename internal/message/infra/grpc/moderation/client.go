@@ -0,0 +1,36 @@
+package moderation
+
+import (
+	"context"
+
+	moderationpb "github.com/wsx864321/kim/idl/moderation"
+	"github.com/wsx864321/kim/pkg/krpc"
+	"github.com/wsx864321/kim/pkg/krpc/registry"
+	"github.com/wsx864321/kim/pkg/log"
+)
+
+// Client 外部内容审核服务 client
+type Client struct {
+	cli moderationpb.ModerationServiceClient
+}
+
+// NewClient 创建外部内容审核服务客户端，serviceName 为审核服务在注册中心的名称
+func NewClient(r registry.Registrar, serviceName string) *Client {
+	cli, err := krpc.NewKClient(
+		krpc.WithClientServiceName(serviceName),
+		krpc.WithClientRegistry(r),
+	)
+	if err != nil {
+		log.Error(nil, "create moderation client failed",
+			log.String("error", err.Error()),
+		)
+		panic(err)
+	}
+
+	return &Client{cli: moderationpb.NewModerationServiceClient(cli.Conn())}
+}
+
+// Moderate 审核一个消息内容元素
+func (c *Client) Moderate(ctx context.Context, in *moderationpb.ModerateReq) (*moderationpb.ModerateResp, error) {
+	return c.cli.Moderate(ctx, in)
+}
@@ -0,0 +1,13 @@
+package moderation
+
+import (
+	"context"
+
+	moderationpb "github.com/wsx864321/kim/idl/moderation"
+)
+
+// ClientInterface ...
+type ClientInterface interface {
+	// Moderate 审核一个消息内容元素
+	Moderate(ctx context.Context, in *moderationpb.ModerateReq) (*moderationpb.ModerateResp, error)
+}
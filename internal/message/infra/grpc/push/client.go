@@ -0,0 +1,41 @@
+package push
+
+import (
+	"context"
+
+	pushpb "github.com/wsx864321/kim/idl/push"
+	"github.com/wsx864321/kim/pkg/krpc"
+	"github.com/wsx864321/kim/pkg/krpc/registry"
+	"github.com/wsx864321/kim/pkg/log"
+)
+
+// Client Push client
+type Client struct {
+	cli pushpb.PushServiceClient
+}
+
+// NewClient 创建 Push 客户端
+func NewClient(r registry.Registrar) *Client {
+	cli, err := krpc.NewKClient(
+		krpc.WithClientServiceName("kim-push"),
+		krpc.WithClientRegistry(r),
+	)
+	if err != nil {
+		log.Error(nil, "create push client failed",
+			log.String("error", err.Error()),
+		)
+		panic(err)
+	}
+
+	return &Client{cli: pushpb.NewPushServiceClient(cli.Conn())}
+}
+
+// PushMsg 推送消息到指定用户
+func (c *Client) PushMsg(ctx context.Context, in *pushpb.PushReq) (*pushpb.PushResp, error) {
+	return c.cli.PushMsg(ctx, in)
+}
+
+// BatchPushMsg 批量推送消息到多个用户
+func (c *Client) BatchPushMsg(ctx context.Context, in *pushpb.BatchPushReq) (*pushpb.BatchPushResp, error) {
+	return c.cli.BatchPushMsg(ctx, in)
+}
@@ -0,0 +1,15 @@
+package push
+
+import (
+	"context"
+
+	pushpb "github.com/wsx864321/kim/idl/push"
+)
+
+// ClientInterface ...
+type ClientInterface interface {
+	// PushMsg 推送消息到指定用户
+	PushMsg(ctx context.Context, in *pushpb.PushReq) (*pushpb.PushResp, error)
+	// BatchPushMsg 批量推送消息到多个用户
+	BatchPushMsg(ctx context.Context, in *pushpb.BatchPushReq) (*pushpb.BatchPushResp, error)
+}
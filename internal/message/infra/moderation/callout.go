@@ -0,0 +1,95 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	moderationpb "github.com/wsx864321/kim/idl/moderation"
+	"github.com/wsx864321/kim/internal/message/infra/grpc/moderation"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/protobuf/proto"
+)
+
+// Callout 调用外部审核服务（实现 moderation.ModerationService）审核内容元素
+type Callout struct {
+	cli      moderation.ClientInterface
+	timeout  time.Duration
+	failOpen bool
+}
+
+// NewCallout 创建外部审核拦截器，failOpen 为 true 时审核服务调用失败视为通过，否则消息不投递
+func NewCallout(cli moderation.ClientInterface, timeout time.Duration, failOpen bool) *Callout {
+	return &Callout{
+		cli:      cli,
+		timeout:  timeout,
+		failOpen: failOpen,
+	}
+}
+
+// Name 拦截器名称
+func (c *Callout) Name() string {
+	return "callout"
+}
+
+// Intercept 将内容元素发送给外部审核服务审核
+func (c *Callout) Intercept(ctx context.Context, msg *messagepb.Message, elem *messagepb.MessageContent) (Decision, error) {
+	decision, err := c.moderate(ctx, msg, elem)
+	if err != nil && c.failOpen {
+		log.Warn(ctx, "moderation callout failed, accept message",
+			log.Int64("cli_msg_id", msg.CliMsgId),
+			log.String("sender_id", msg.SenderId),
+			log.String("error", err.Error()),
+		)
+		return Accept(), nil
+	}
+	return decision, err
+}
+
+// moderate 调用外部审核服务并转换审核结果
+func (c *Callout) moderate(ctx context.Context, msg *messagepb.Message, elem *messagepb.MessageContent) (Decision, error) {
+	data, err := proto.Marshal(elem)
+	if err != nil {
+		return Decision{}, err
+	}
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	resp, err := c.cli.Moderate(ctx, &moderationpb.ModerateReq{
+		SenderId:    msg.SenderId,
+		ReceiverId:  msg.ReceiverId,
+		GroupId:     msg.GroupId,
+		MsgType:     int32(msg.MsgType),
+		ElementType: int32(elem.ElementType),
+		Element:     data,
+	})
+	if err != nil {
+		return Decision{}, err
+	}
+	if resp.Code != xerr.OK.Code() || resp.Data == nil {
+		return Decision{}, fmt.Errorf("moderate failed, code: %d, message: %s", resp.Code, resp.Message)
+	}
+
+	switch resp.Data.Action {
+	case moderationpb.ModerateAction_MODERATE_ACTION_ACCEPT:
+		return Accept(), nil
+	case moderationpb.ModerateAction_MODERATE_ACTION_REJECT:
+		if resp.Data.Reason == "" {
+			return Reject(xerr.ErrMessageRejected), nil
+		}
+		return Reject(xerr.ErrMessageRejected.WithMessage(resp.Data.Reason)), nil
+	case moderationpb.ModerateAction_MODERATE_ACTION_REWRITE:
+		content := &messagepb.MessageContent{}
+		if err := proto.Unmarshal(resp.Data.Element, content); err != nil {
+			return Decision{}, fmt.Errorf("invalid rewritten element: %w", err)
+		}
+		return Rewrite(content), nil
+	default:
+		return Decision{}, fmt.Errorf("unknown moderate action: %d", resp.Data.Action)
+	}
+}
@@ -0,0 +1,52 @@
+package moderation
+
+import (
+	"context"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/pkg/xerr"
+)
+
+// Action 审核结果
+type Action int
+
+const (
+	// ActionAccept 通过
+	ActionAccept Action = iota
+	// ActionReject 拒绝，整条消息不投递
+	ActionReject
+	// ActionRewrite 改写，使用 Decision.Content 替换原内容元素
+	ActionRewrite
+)
+
+// Decision 拦截器对一个内容元素的审核决定
+type Decision struct {
+	Action Action
+	// Content 改写后的内容元素（ActionRewrite）
+	Content *messagepb.MessageContent
+	// Err 拒绝时返回给发送者的错误（ActionReject），为空时返回 xerr.ErrMessageRejected
+	Err *xerr.Error
+}
+
+// Accept 通过
+func Accept() Decision {
+	return Decision{Action: ActionAccept}
+}
+
+// Reject 拒绝，err 为返回给发送者的错误
+func Reject(err *xerr.Error) Decision {
+	return Decision{Action: ActionReject, Err: err}
+}
+
+// Rewrite 使用 content 替换原内容元素
+func Rewrite(content *messagepb.MessageContent) Decision {
+	return Decision{Action: ActionRewrite, Content: content}
+}
+
+// Interceptor 内容审核拦截器，消息投递前按顺序审核每个内容元素，前一个拦截器改写后的元素交给下一个拦截器
+type Interceptor interface {
+	// Name 拦截器名称，用于日志
+	Name() string
+	// Intercept 审核内容元素，不能修改 elem；返回 error 表示审核本身失败（如外部服务不可用），消息不投递
+	Intercept(ctx context.Context, msg *messagepb.Message, elem *messagepb.MessageContent) (Decision, error)
+}
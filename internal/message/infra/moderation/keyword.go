@@ -0,0 +1,222 @@
+package moderation
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/protobuf/proto"
+)
+
+// regexPrefix 词表中以该前缀开头的行按正则表达式匹配，其余行按关键词匹配，匹配时均忽略大小写
+const regexPrefix = "re:"
+
+// KeywordFilter 关键词过滤：文本（文本内容、文件名、@提醒展示文本）命中屏蔽词表时拒绝消息，
+// 命中替换词表时将命中部分替换为 *；词表从文件加载，文件修改后由 Watch 自动重新加载
+type KeywordFilter struct {
+	blockFile string
+	maskFile  string
+
+	mu       sync.RWMutex
+	block    *regexp.Regexp
+	mask     *regexp.Regexp
+	modTimes map[string]time.Time
+}
+
+// NewKeywordFilter 创建关键词过滤器并加载词表，文件路径为空表示不使用该词表
+func NewKeywordFilter(blockFile, maskFile string) (*KeywordFilter, error) {
+	f := &KeywordFilter{
+		blockFile: blockFile,
+		maskFile:  maskFile,
+		modTimes:  make(map[string]time.Time),
+	}
+	if err := f.Reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Name 拦截器名称
+func (f *KeywordFilter) Name() string {
+	return "keyword"
+}
+
+// Reload 重新加载词表，加载失败时保留原词表
+func (f *KeywordFilter) Reload() error {
+	block, blockTime, err := loadWordList(f.blockFile)
+	if err != nil {
+		return err
+	}
+	mask, maskTime, err := loadWordList(f.maskFile)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.block, f.mask = block, mask
+	f.modTimes[f.blockFile], f.modTimes[f.maskFile] = blockTime, maskTime
+	return nil
+}
+
+// Watch 每隔 interval 检查词表文件的修改时间，有变化时重新加载，ctx 取消后返回
+func (f *KeywordFilter) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !f.changed() {
+			continue
+		}
+		if err := f.Reload(); err != nil {
+			log.Error(ctx, "reload keyword lists failed", log.String("error", err.Error()))
+			continue
+		}
+		log.Info(ctx, "keyword lists reloaded",
+			log.String("block_file", f.blockFile),
+			log.String("mask_file", f.maskFile),
+		)
+	}
+}
+
+// changed 词表文件的修改时间是否变化
+func (f *KeywordFilter) changed() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for _, path := range []string{f.blockFile, f.maskFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Equal(f.modTimes[path]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Intercept 审核内容元素中的文本
+func (f *KeywordFilter) Intercept(ctx context.Context, msg *messagepb.Message, elem *messagepb.MessageContent) (Decision, error) {
+	f.mu.RLock()
+	block, mask := f.block, f.mask
+	f.mu.RUnlock()
+
+	texts := elementTexts(elem)
+	if len(texts) == 0 {
+		return Accept(), nil
+	}
+
+	if block != nil {
+		for _, text := range texts {
+			if block.MatchString(*text) {
+				return Reject(xerr.ErrMessageRejected.WithMessage("message contains blocked words")), nil
+			}
+		}
+	}
+
+	if mask == nil {
+		return Accept(), nil
+	}
+	rewritten := proto.Clone(elem).(*messagepb.MessageContent)
+	changed := false
+	for _, text := range elementTexts(rewritten) {
+		masked := mask.ReplaceAllStringFunc(*text, func(s string) string {
+			return strings.Repeat("*", utf8.RuneCountInString(s))
+		})
+		if masked != *text {
+			*text = masked
+			changed = true
+		}
+	}
+	if !changed {
+		return Accept(), nil
+	}
+	return Rewrite(rewritten), nil
+}
+
+// elementTexts 返回内容元素中需要过滤的文本字段
+func elementTexts(elem *messagepb.MessageContent) []*string {
+	switch c := elem.Content.(type) {
+	case *messagepb.MessageContent_Text:
+		if c.Text != nil {
+			return []*string{&c.Text.Text}
+		}
+	case *messagepb.MessageContent_FileC:
+		if c.FileC != nil {
+			return []*string{&c.FileC.FileName}
+		}
+	case *messagepb.MessageContent_Mention:
+		if c.Mention != nil {
+			return []*string{&c.Mention.DisplayText}
+		}
+	}
+	return nil
+}
+
+// loadWordList 加载词表文件并编译为一个正则表达式，每行一个关键词或 re: 开头的正则表达式，# 开头的行为注释；
+// 文件路径为空或词表为空时返回 nil
+func loadWordList(path string) (*regexp.Regexp, time.Time, error) {
+	if path == "" {
+		return nil, time.Time{}, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		if strings.HasPrefix(word, regexPrefix) {
+			pattern := strings.TrimPrefix(word, regexPrefix)
+			if _, err := regexp.Compile(pattern); err != nil {
+				return nil, time.Time{}, fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+			patterns = append(patterns, "(?:"+pattern+")")
+			continue
+		}
+		patterns = append(patterns, regexp.QuoteMeta(word))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, time.Time{}, err
+	}
+	if len(patterns) == 0 {
+		return nil, info.ModTime(), nil
+	}
+
+	re, err := regexp.Compile("(?i)(?:" + strings.Join(patterns, "|") + ")")
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return re, info.ModTime(), nil
+}
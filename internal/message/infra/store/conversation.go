@@ -0,0 +1,424 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// conversationListKey 会话列表 Key 格式: kim:convs:{user_id}
+	// zset，score 为会话 updated_at（置顶会话加上 pinnedScore），member 为会话ID
+	conversationListKey = "kim:convs:{%s}"
+	// conversationInfoKey 会话信息 Key 格式: kim:convs:info:{user_id}
+	// hash，field 为 "会话ID:info"（序列化后的会话）、"会话ID:seq"、"会话ID:pin"、"会话ID:mute"、"会话ID:mention"
+	conversationInfoKey = "kim:convs:info:{%s}"
+	// singleConversationKey 单聊会话ID Key 格式: kim:convs:single:{len(a)}:{a}:{b}，a、b 为排序后的双方用户ID，
+	// 带上 a 的长度保证用户ID中包含分隔符时也不会冲突；会话ID一旦分配不能改变，Key 不设置过期时间
+	singleConversationKey = "kim:convs:single:%d:%s:%s"
+
+	// pinnedScore 置顶会话 score 的偏移量，大于任何毫秒时间戳，score 仍在 float64 精确表示的范围内
+	pinnedScore = int64(1) << 50
+)
+
+// updateConversationLuaScript 序号前进时更新会话信息、活跃时间和@提醒位置，超过保留数量时删除最不活跃的会话
+// KEYS[1]: 会话列表 Key
+// KEYS[2]: 会话信息 Key
+// ARGV[1]: 会话ID
+// ARGV[2]: 最新消息序号
+// ARGV[3]: 序列化后的会话
+// ARGV[4]: updated_at
+// ARGV[5]: 保留的会话数量
+// ARGV[6]: 过期时间（秒）
+// ARGV[7]: pinnedScore
+// ARGV[8]: @提醒的消息序号（0 表示不是@提醒）
+const updateConversationLuaScript = `
+local cur = tonumber(redis.call('HGET', KEYS[2], ARGV[1] .. ':seq') or '0')
+if tonumber(ARGV[2]) <= cur then
+	return 0
+end
+redis.call('HSET', KEYS[2], ARGV[1] .. ':info', ARGV[3], ARGV[1] .. ':seq', ARGV[2])
+if tonumber(ARGV[8]) > 0 then
+	redis.call('HSET', KEYS[2], ARGV[1] .. ':mention', ARGV[8])
+end
+local score = tonumber(ARGV[4])
+if redis.call('HGET', KEYS[2], ARGV[1] .. ':pin') == '1' then
+	score = score + tonumber(ARGV[7])
+end
+redis.call('ZADD', KEYS[1], score, ARGV[1])
+local n = redis.call('ZCARD', KEYS[1]) - tonumber(ARGV[5])
+if n > 0 then
+	for _, c in ipairs(redis.call('ZRANGE', KEYS[1], 0, n - 1)) do
+		redis.call('HDEL', KEYS[2], c .. ':info', c .. ':seq', c .. ':pin', c .. ':mute', c .. ':mention')
+	end
+	redis.call('ZREMRANGEBYRANK', KEYS[1], 0, n - 1)
+end
+redis.call('EXPIRE', KEYS[1], ARGV[6])
+redis.call('EXPIRE', KEYS[2], ARGV[6])
+return 1
+`
+
+// setConversationFlagLuaScript 设置会话标记，置顶时同时调整 score
+// KEYS[1]: 会话列表 Key
+// KEYS[2]: 会话信息 Key
+// ARGV[1]: 会话ID
+// ARGV[2]: 标记名（pin、mute）
+// ARGV[3]: 1 设置，0 清除
+// ARGV[4]: pinnedScore
+const setConversationFlagLuaScript = `
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if not score then
+	return 0
+end
+if ARGV[3] == '1' then
+	redis.call('HSET', KEYS[2], ARGV[1] .. ':' .. ARGV[2], '1')
+else
+	redis.call('HDEL', KEYS[2], ARGV[1] .. ':' .. ARGV[2])
+end
+if ARGV[2] == 'pin' then
+	score = tonumber(score)
+	local base = tonumber(ARGV[4])
+	if ARGV[3] == '1' and score < base then
+		redis.call('ZADD', KEYS[1], score + base, ARGV[1])
+	elseif ARGV[3] == '0' and score >= base then
+		redis.call('ZADD', KEYS[1], score - base, ARGV[1])
+	end
+end
+return 1
+`
+
+// RedisConversationStore 基于 Redis zset + hash 的用户会话列表，每个用户保留最近活跃的 size 个会话
+type RedisConversationStore struct {
+	redis        redis.UniversalClient
+	size         int
+	retention    time.Duration
+	updateScript *redis.Script
+	flagScript   *redis.Script
+}
+
+// NewRedisConversationStore 创建 Redis 会话列表存储
+func NewRedisConversationStore(cli redis.UniversalClient, size int, retention time.Duration) *RedisConversationStore {
+	return &RedisConversationStore{
+		redis:        cli,
+		size:         size,
+		retention:    retention,
+		updateScript: redis.NewScript(updateConversationLuaScript),
+		flagScript:   redis.NewScript(setConversationFlagLuaScript),
+	}
+}
+
+// Update 以会话最新一条消息更新用户的会话
+func (s *RedisConversationStore) Update(ctx context.Context, userID string, conv *messagepb.Conversation) error {
+	raw, err := proto.Marshal(conv)
+	if err != nil {
+		return fmt.Errorf("marshal conversation failed: %w", err)
+	}
+
+	err = s.updateScript.Run(ctx, s.redis, []string{buildConversationListKey(userID), buildConversationInfoKey(userID)},
+		conv.ConversationId,
+		conv.LastSeq,
+		raw,
+		conv.UpdatedAt,
+		s.size,
+		int64(s.retention.Seconds()),
+		pinnedScore,
+		conv.MentionSeq,
+	).Err()
+	if err != nil {
+		return fmt.Errorf("update conversation failed: %w", err)
+	}
+	return nil
+}
+
+// SetPinned 设置会话置顶
+func (s *RedisConversationStore) SetPinned(ctx context.Context, userID string, conversationID int64, pinned bool) error {
+	return s.setFlag(ctx, userID, conversationID, "pin", pinned)
+}
+
+// SetMuted 设置会话免打扰
+func (s *RedisConversationStore) SetMuted(ctx context.Context, userID string, conversationID int64, muted bool) error {
+	return s.setFlag(ctx, userID, conversationID, "mute", muted)
+}
+
+// setFlag 设置会话标记
+func (s *RedisConversationStore) setFlag(ctx context.Context, userID string, conversationID int64, flag string, on bool) error {
+	value := 0
+	if on {
+		value = 1
+	}
+	n, err := s.flagScript.Run(ctx, s.redis, []string{buildConversationListKey(userID), buildConversationInfoKey(userID)},
+		conversationID,
+		flag,
+		value,
+		pinnedScore,
+	).Int64()
+	if err != nil {
+		return fmt.Errorf("set conversation %s failed: %w", flag, err)
+	}
+	if n == 0 {
+		return ErrConversationNotFound
+	}
+	return nil
+}
+
+// List 分页获取会话
+func (s *RedisConversationStore) List(ctx context.Context, userID string, offset, limit int) ([]*messagepb.Conversation, bool, error) {
+	// 多取一个用于判断是否还有下一页
+	ids, err := s.redis.ZRevRange(ctx, buildConversationListKey(userID), int64(offset), int64(offset+limit)).Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("list conversations failed: %w", err)
+	}
+	hasMore := len(ids) > limit
+	if hasMore {
+		ids = ids[:limit]
+	}
+	if len(ids) == 0 {
+		return nil, false, nil
+	}
+
+	const n = 4
+	fields := make([]string, 0, len(ids)*n)
+	for _, id := range ids {
+		fields = append(fields, id+":info", id+":pin", id+":mute", id+":mention")
+	}
+	vals, err := s.redis.HMGet(ctx, buildConversationInfoKey(userID), fields...).Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("get conversations failed: %w", err)
+	}
+
+	convs := make([]*messagepb.Conversation, 0, len(ids))
+	for i := range ids {
+		raw, ok := vals[i*n].(string)
+		if !ok {
+			continue
+		}
+		conv := &messagepb.Conversation{}
+		if err := proto.Unmarshal([]byte(raw), conv); err != nil {
+			return nil, false, fmt.Errorf("unmarshal conversation failed: %w", err)
+		}
+		conv.Pinned = vals[i*n+1] != nil
+		conv.Muted = vals[i*n+2] != nil
+		if mention, ok := vals[i*n+3].(string); ok {
+			conv.MentionSeq, _ = strconv.ParseInt(mention, 10, 64)
+		}
+		convs = append(convs, conv)
+	}
+	return convs, hasMore, nil
+}
+
+// buildConversationListKey 构建会话列表 Key
+func buildConversationListKey(userID string) string {
+	return fmt.Sprintf(conversationListKey, userID)
+}
+
+// buildConversationInfoKey 构建会话信息 Key
+func buildConversationInfoKey(userID string) string {
+	return fmt.Sprintf(conversationInfoKey, userID)
+}
+
+// SingleID 获取两个用户之间的单聊会话ID，不存在时用 newID 分配
+func (s *RedisConversationStore) SingleID(ctx context.Context, userA, userB string, newID func() int64) (int64, error) {
+	key := buildSingleConversationKey(userA, userB)
+	id, err := s.redis.Get(ctx, key).Int64()
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return 0, fmt.Errorf("get single conversation id failed: %w", err)
+	}
+
+	id = newID()
+	ok, err := s.redis.SetNX(ctx, key, id, 0).Result()
+	if err != nil {
+		return 0, fmt.Errorf("set single conversation id failed: %w", err)
+	}
+	if ok {
+		return id, nil
+	}
+	// 对方同时发送消息已经分配了会话ID
+	id, err = s.redis.Get(ctx, key).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("get single conversation id failed: %w", err)
+	}
+	return id, nil
+}
+
+// GetSingleID 查询两个用户之间已分配的单聊会话ID，未分配时返回 ErrConversationNotFound
+func (s *RedisConversationStore) GetSingleID(ctx context.Context, userA, userB string) (int64, error) {
+	id, err := s.redis.Get(ctx, buildSingleConversationKey(userA, userB)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, ErrConversationNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get single conversation id failed: %w", err)
+	}
+	return id, nil
+}
+
+// buildSingleConversationKey 构建单聊会话ID Key
+func buildSingleConversationKey(userA, userB string) string {
+	if userA > userB {
+		userA, userB = userB, userA
+	}
+	return fmt.Sprintf(singleConversationKey, len(userA), userA, userB)
+}
+
+// MemoryConversationStore 内存会话列表，每个用户保留最近活跃的 size 个会话
+type MemoryConversationStore struct {
+	mu    sync.RWMutex
+	size  int
+	convs map[string]map[int64]*messagepb.Conversation
+	// singles key: 排序后的双方用户ID，value: 单聊会话ID
+	singles map[[2]string]int64
+}
+
+// NewMemoryConversationStore 创建内存会话列表存储
+func NewMemoryConversationStore(size int) *MemoryConversationStore {
+	return &MemoryConversationStore{
+		size:    size,
+		convs:   make(map[string]map[int64]*messagepb.Conversation),
+		singles: make(map[[2]string]int64),
+	}
+}
+
+// SingleID 获取两个用户之间的单聊会话ID，不存在时用 newID 分配
+func (s *MemoryConversationStore) SingleID(_ context.Context, userA, userB string, newID func() int64) (int64, error) {
+	if userA > userB {
+		userA, userB = userB, userA
+	}
+	key := [2]string{userA, userB}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, ok := s.singles[key]
+	if !ok {
+		id = newID()
+		s.singles[key] = id
+	}
+	return id, nil
+}
+
+// GetSingleID 查询两个用户之间已分配的单聊会话ID，未分配时返回 ErrConversationNotFound
+func (s *MemoryConversationStore) GetSingleID(_ context.Context, userA, userB string) (int64, error) {
+	if userA > userB {
+		userA, userB = userB, userA
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, ok := s.singles[[2]string{userA, userB}]
+	if !ok {
+		return 0, ErrConversationNotFound
+	}
+	return id, nil
+}
+
+// Update 以会话最新一条消息更新用户的会话
+func (s *MemoryConversationStore) Update(_ context.Context, userID string, conv *messagepb.Conversation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	convs := s.convs[userID]
+	if convs == nil {
+		convs = make(map[int64]*messagepb.Conversation)
+		s.convs[userID] = convs
+	}
+
+	old, ok := convs[conv.ConversationId]
+	if ok && conv.LastSeq <= old.LastSeq {
+		return nil
+	}
+	conv = proto.Clone(conv).(*messagepb.Conversation)
+	if ok {
+		conv.Pinned = old.Pinned
+		conv.Muted = old.Muted
+		if conv.MentionSeq == 0 {
+			conv.MentionSeq = old.MentionSeq
+		}
+	}
+	convs[conv.ConversationId] = conv
+
+	if len(convs) > s.size {
+		sorted := sortConversations(convs)
+		for _, c := range sorted[s.size:] {
+			delete(convs, c.ConversationId)
+		}
+	}
+	return nil
+}
+
+// SetPinned 设置会话置顶
+func (s *MemoryConversationStore) SetPinned(_ context.Context, userID string, conversationID int64, pinned bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conv, ok := s.convs[userID][conversationID]
+	if !ok {
+		return ErrConversationNotFound
+	}
+	conv.Pinned = pinned
+	return nil
+}
+
+// SetMuted 设置会话免打扰
+func (s *MemoryConversationStore) SetMuted(_ context.Context, userID string, conversationID int64, muted bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conv, ok := s.convs[userID][conversationID]
+	if !ok {
+		return ErrConversationNotFound
+	}
+	conv.Muted = muted
+	return nil
+}
+
+// List 分页获取会话
+func (s *MemoryConversationStore) List(_ context.Context, userID string, offset, limit int) ([]*messagepb.Conversation, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sorted := sortConversations(s.convs[userID])
+	if offset >= len(sorted) {
+		return nil, false, nil
+	}
+	end := offset + limit
+	hasMore := end < len(sorted)
+	if !hasMore {
+		end = len(sorted)
+	}
+
+	convs := make([]*messagepb.Conversation, 0, end-offset)
+	for _, conv := range sorted[offset:end] {
+		convs = append(convs, proto.Clone(conv).(*messagepb.Conversation))
+	}
+	return convs, hasMore, nil
+}
+
+// sortConversations 置顶会话在前，其余按 updated_at 倒序
+func sortConversations(convs map[int64]*messagepb.Conversation) []*messagepb.Conversation {
+	sorted := make([]*messagepb.Conversation, 0, len(convs))
+	for _, conv := range convs {
+		sorted = append(sorted, conv)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Pinned != sorted[j].Pinned {
+			return sorted[i].Pinned
+		}
+		if sorted[i].UpdatedAt != sorted[j].UpdatedAt {
+			return sorted[i].UpdatedAt > sorted[j].UpdatedAt
+		}
+		return sorted[i].ConversationId > sorted[j].ConversationId
+	})
+	return sorted
+}
@@ -0,0 +1,220 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/pkg/log"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// dedupKey 去重 Key 格式: kim:msg:dedup:{sender_id}:{cli_msg_id}
+	// 值为空表示消息处理中，否则为序列化后的 UpstreamResult
+	dedupKey = "kim:msg:dedup:{%s}:%d"
+
+	// dedupPendingTTL 处理中状态的过期时间，实例异常退出时去重键不会长时间占用
+	dedupPendingTTL = 30 * time.Second
+)
+
+// RedisDeduper 基于 Redis 的上行消息去重
+type RedisDeduper struct {
+	redis redis.UniversalClient
+	ttl   time.Duration
+}
+
+// NewRedisDeduper 创建 Redis 去重器，ttl 为去重窗口
+func NewRedisDeduper(cli redis.UniversalClient, ttl time.Duration) *RedisDeduper {
+	return &RedisDeduper{redis: cli, ttl: ttl}
+}
+
+// Acquire 占用去重键
+func (d *RedisDeduper) Acquire(ctx context.Context, senderID string, cliMsgID int64) (*messagepb.UpstreamResult, bool, error) {
+	key := buildDedupKey(senderID, cliMsgID)
+	ok, err := d.redis.SetNX(ctx, key, "", dedupPendingTTL).Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("acquire dedup key failed: %w", err)
+	}
+	if ok {
+		return nil, true, nil
+	}
+
+	val, err := d.redis.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			// 刚好过期或被释放，按处理中返回，客户端稍后重试
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("get dedup key failed: %w", err)
+	}
+	if len(val) == 0 {
+		return nil, false, nil
+	}
+
+	result := &messagepb.UpstreamResult{}
+	if err := proto.Unmarshal(val, result); err != nil {
+		return nil, false, fmt.Errorf("unmarshal dedup result failed: %w", err)
+	}
+	return result, false, nil
+}
+
+// Commit 记录处理结果，去重窗口从此时开始计算
+func (d *RedisDeduper) Commit(ctx context.Context, senderID string, cliMsgID int64, result *messagepb.UpstreamResult) error {
+	raw, err := proto.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("marshal dedup result failed: %w", err)
+	}
+	if err := d.redis.Set(ctx, buildDedupKey(senderID, cliMsgID), raw, d.ttl).Err(); err != nil {
+		return fmt.Errorf("commit dedup key failed: %w", err)
+	}
+	return nil
+}
+
+// Release 释放去重键
+func (d *RedisDeduper) Release(ctx context.Context, senderID string, cliMsgID int64) error {
+	if err := d.redis.Del(ctx, buildDedupKey(senderID, cliMsgID)).Err(); err != nil {
+		return fmt.Errorf("release dedup key failed: %w", err)
+	}
+	return nil
+}
+
+// buildDedupKey 构建去重 Key
+func buildDedupKey(senderID string, cliMsgID int64) string {
+	return fmt.Sprintf(dedupKey, senderID, cliMsgID)
+}
+
+// dedupEntry 内存去重记录
+type dedupEntry struct {
+	result   *messagepb.UpstreamResult // nil 表示处理中
+	expireAt time.Time
+}
+
+// dedupMemKey 内存去重键
+type dedupMemKey struct {
+	senderID string
+	cliMsgID int64
+}
+
+// MemoryDeduper 内存上行消息去重，只能覆盖重试落在同一实例上的情况
+type MemoryDeduper struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[dedupMemKey]*dedupEntry
+	lastSweep time.Time
+}
+
+// NewMemoryDeduper 创建内存去重器，ttl 为去重窗口
+func NewMemoryDeduper(ttl time.Duration) *MemoryDeduper {
+	return &MemoryDeduper{
+		ttl:       ttl,
+		entries:   make(map[dedupMemKey]*dedupEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// Acquire 占用去重键
+func (d *MemoryDeduper) Acquire(_ context.Context, senderID string, cliMsgID int64) (*messagepb.UpstreamResult, bool, error) {
+	now := time.Now()
+	key := dedupMemKey{senderID: senderID, cliMsgID: cliMsgID}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.sweep(now)
+	if e, ok := d.entries[key]; ok && now.Before(e.expireAt) {
+		return e.result, false, nil
+	}
+	d.entries[key] = &dedupEntry{expireAt: now.Add(dedupPendingTTL)}
+	return nil, true, nil
+}
+
+// Commit 记录处理结果
+func (d *MemoryDeduper) Commit(_ context.Context, senderID string, cliMsgID int64, result *messagepb.UpstreamResult) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.entries[dedupMemKey{senderID: senderID, cliMsgID: cliMsgID}] = &dedupEntry{
+		result:   proto.Clone(result).(*messagepb.UpstreamResult),
+		expireAt: time.Now().Add(d.ttl),
+	}
+	return nil
+}
+
+// Release 释放去重键
+func (d *MemoryDeduper) Release(_ context.Context, senderID string, cliMsgID int64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.entries, dedupMemKey{senderID: senderID, cliMsgID: cliMsgID})
+	return nil
+}
+
+// sweep 定期清理过期记录，调用方需持有锁
+func (d *MemoryDeduper) sweep(now time.Time) {
+	if now.Sub(d.lastSweep) < dedupPendingTTL {
+		return
+	}
+	d.lastSweep = now
+	for key, e := range d.entries {
+		if !now.Before(e.expireAt) {
+			delete(d.entries, key)
+		}
+	}
+}
+
+// FallbackDeduper Redis 不可用时退回到本地内存去重，保证发送不因去重存储故障而失败
+type FallbackDeduper struct {
+	primary  Deduper
+	fallback Deduper
+}
+
+// NewFallbackDeduper 创建带内存兜底的去重器
+func NewFallbackDeduper(primary, fallback Deduper) *FallbackDeduper {
+	return &FallbackDeduper{primary: primary, fallback: fallback}
+}
+
+// Acquire 占用去重键，主存储失败时使用兜底存储
+func (d *FallbackDeduper) Acquire(ctx context.Context, senderID string, cliMsgID int64) (*messagepb.UpstreamResult, bool, error) {
+	result, acquired, err := d.primary.Acquire(ctx, senderID, cliMsgID)
+	if err == nil {
+		return result, acquired, nil
+	}
+	log.Warn(ctx, "dedup acquire failed, fallback to memory",
+		log.String("sender_id", senderID),
+		log.Int64("cli_msg_id", cliMsgID),
+		log.String("error", err.Error()),
+	)
+	return d.fallback.Acquire(ctx, senderID, cliMsgID)
+}
+
+// Commit 记录处理结果，主存储失败时写入兜底存储，并删除主存储中处理中的键，
+// 避免重试在 dedupPendingTTL 内一直被当作处理中；结果只保存在本实例内存，Redis 恢复后的重试会被重新受理
+func (d *FallbackDeduper) Commit(ctx context.Context, senderID string, cliMsgID int64, result *messagepb.UpstreamResult) error {
+	if err := d.primary.Commit(ctx, senderID, cliMsgID, result); err != nil {
+		log.Warn(ctx, "dedup commit failed, fallback to memory",
+			log.String("sender_id", senderID),
+			log.Int64("cli_msg_id", cliMsgID),
+			log.String("error", err.Error()),
+		)
+		if err := d.primary.Release(ctx, senderID, cliMsgID); err != nil {
+			log.Warn(ctx, "release pending dedup key failed",
+				log.String("sender_id", senderID),
+				log.Int64("cli_msg_id", cliMsgID),
+				log.String("error", err.Error()),
+			)
+		}
+		return d.fallback.Commit(ctx, senderID, cliMsgID, result)
+	}
+	return nil
+}
+
+// Release 释放去重键，两边都释放
+func (d *FallbackDeduper) Release(ctx context.Context, senderID string, cliMsgID int64) error {
+	_ = d.fallback.Release(ctx, senderID, cliMsgID)
+	return d.primary.Release(ctx, senderID, cliMsgID)
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+)
+
+// fakeDeduper 在内存去重器上模拟主存储故障，记录释放的键
+type fakeDeduper struct {
+	*MemoryDeduper
+
+	acquireErr error
+	commitErr  error
+	releaseErr error
+	released   int
+}
+
+func (d *fakeDeduper) Acquire(ctx context.Context, senderID string, cliMsgID int64) (*messagepb.UpstreamResult, bool, error) {
+	if d.acquireErr != nil {
+		return nil, false, d.acquireErr
+	}
+	return d.MemoryDeduper.Acquire(ctx, senderID, cliMsgID)
+}
+
+func (d *fakeDeduper) Commit(ctx context.Context, senderID string, cliMsgID int64, result *messagepb.UpstreamResult) error {
+	if d.commitErr != nil {
+		return d.commitErr
+	}
+	return d.MemoryDeduper.Commit(ctx, senderID, cliMsgID, result)
+}
+
+func (d *fakeDeduper) Release(ctx context.Context, senderID string, cliMsgID int64) error {
+	d.released++
+	if d.releaseErr != nil {
+		return d.releaseErr
+	}
+	return d.MemoryDeduper.Release(ctx, senderID, cliMsgID)
+}
+
+func TestFallbackDeduperCommit(t *testing.T) {
+	errRedis := errors.New("redis unavailable")
+	tests := []struct {
+		name       string
+		commitErr  error
+		releaseErr error
+		// wantPending 主存储中是否仍保留处理中的键
+		wantPending bool
+		wantRelease int
+		// wantLocal 结果是否写入兜底存储
+		wantLocal bool
+	}{
+		{name: "commit ok"},
+		{name: "commit failed", commitErr: errRedis, wantRelease: 1, wantLocal: true},
+		{name: "release failed", commitErr: errRedis, releaseErr: errRedis, wantPending: true, wantRelease: 1, wantLocal: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			primary := &fakeDeduper{MemoryDeduper: NewMemoryDeduper(time.Minute), commitErr: tt.commitErr, releaseErr: tt.releaseErr}
+			fallback := NewMemoryDeduper(time.Minute)
+			d := NewFallbackDeduper(primary, fallback)
+
+			if _, acquired, err := d.Acquire(ctx, "alice", 1); err != nil || !acquired {
+				t.Fatalf("expected acquired, got %v %v", acquired, err)
+			}
+			result := &messagepb.UpstreamResult{MsgId: 100, Seq: 1}
+			if err := d.Commit(ctx, "alice", 1, result); err != nil {
+				t.Fatalf("commit failed: %v", err)
+			}
+			if primary.released != tt.wantRelease {
+				t.Fatalf("expected %d releases, got %d", tt.wantRelease, primary.released)
+			}
+
+			// 主存储的状态：提交成功时返回结果，释放后可以重新占用，释放失败时仍处理中
+			got, acquired, err := primary.MemoryDeduper.Acquire(ctx, "alice", 1)
+			switch {
+			case err != nil:
+				t.Fatalf("primary acquire failed: %v", err)
+			case tt.wantPending && (acquired || got != nil):
+				t.Fatalf("expected pending key, got %v %v", got, acquired)
+			case !tt.wantPending && tt.commitErr != nil && !acquired:
+				t.Fatal("expected pending key released")
+			case tt.commitErr == nil && (acquired || got.GetMsgId() != 100):
+				t.Fatalf("expected committed result, got %v %v", got, acquired)
+			}
+
+			// 主存储不可用时由兜底存储返回结果
+			primary.acquireErr = errRedis
+			got, acquired, err = d.Acquire(ctx, "alice", 1)
+			if err != nil {
+				t.Fatalf("fallback acquire failed: %v", err)
+			}
+			if tt.wantLocal && (acquired || got.GetMsgId() != 100) {
+				t.Fatalf("expected local result, got %v %v", got, acquired)
+			}
+			if !tt.wantLocal && !acquired {
+				t.Fatalf("expected nothing in fallback, got %v", got)
+			}
+		})
+	}
+}
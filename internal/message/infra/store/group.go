@@ -0,0 +1,317 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	grouppb "github.com/wsx864321/kim/idl/group"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// groupKey 群组信息 Key 格式: kim:group:{group_id}
+	groupKey = "kim:group:{%d}"
+	// groupMembersKey 群成员 Key 格式: kim:group:members:{group_id}
+	// hash，field 为用户ID，value 为序列化后的群成员
+	groupMembersKey = "kim:group:members:{%d}"
+)
+
+// RedisGroupStore 基于 Redis 的群组存储，群组信息和成员使用相同的 hash tag，保证在同一个 slot
+type RedisGroupStore struct {
+	redis redis.UniversalClient
+}
+
+// NewRedisGroupStore 创建 Redis 群组存储
+func NewRedisGroupStore(cli redis.UniversalClient) *RedisGroupStore {
+	return &RedisGroupStore{redis: cli}
+}
+
+// CreateGroup 创建群组并写入初始成员
+func (s *RedisGroupStore) CreateGroup(ctx context.Context, group *grouppb.Group, members []*grouppb.GroupMember) error {
+	raw, err := proto.Marshal(group)
+	if err != nil {
+		return fmt.Errorf("marshal group failed: %w", err)
+	}
+	values, err := marshalMembers(members)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, buildGroupKey(group.GroupId), raw, 0)
+		pipe.HSet(ctx, buildGroupMembersKey(group.GroupId), values...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("create group failed: %w", err)
+	}
+	return nil
+}
+
+// GetGroup 获取群组信息
+func (s *RedisGroupStore) GetGroup(ctx context.Context, groupID int64) (*grouppb.Group, error) {
+	var (
+		getCmd *redis.StringCmd
+		lenCmd *redis.IntCmd
+	)
+	_, err := s.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		getCmd = pipe.Get(ctx, buildGroupKey(groupID))
+		lenCmd = pipe.HLen(ctx, buildGroupMembersKey(groupID))
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrGroupNotFound
+		}
+		return nil, fmt.Errorf("get group failed: %w", err)
+	}
+
+	group := &grouppb.Group{}
+	if err := proto.Unmarshal([]byte(getCmd.Val()), group); err != nil {
+		return nil, fmt.Errorf("unmarshal group failed: %w", err)
+	}
+	group.MemberCount = int32(lenCmd.Val())
+	return group, nil
+}
+
+// UpdateGroup 更新群组信息
+func (s *RedisGroupStore) UpdateGroup(ctx context.Context, group *grouppb.Group) error {
+	raw, err := proto.Marshal(group)
+	if err != nil {
+		return fmt.Errorf("marshal group failed: %w", err)
+	}
+	ok, err := s.redis.SetXX(ctx, buildGroupKey(group.GroupId), raw, redis.KeepTTL).Result()
+	if err != nil {
+		return fmt.Errorf("update group failed: %w", err)
+	}
+	if !ok {
+		return ErrGroupNotFound
+	}
+	return nil
+}
+
+// DeleteGroup 删除群组及全部成员
+func (s *RedisGroupStore) DeleteGroup(ctx context.Context, groupID int64) error {
+	if err := s.redis.Del(ctx, buildGroupKey(groupID), buildGroupMembersKey(groupID)).Err(); err != nil {
+		return fmt.Errorf("delete group failed: %w", err)
+	}
+	return nil
+}
+
+// AddMembers 添加或更新群成员
+func (s *RedisGroupStore) AddMembers(ctx context.Context, groupID int64, members []*grouppb.GroupMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+	values, err := marshalMembers(members)
+	if err != nil {
+		return err
+	}
+	if err := s.redis.HSet(ctx, buildGroupMembersKey(groupID), values...).Err(); err != nil {
+		return fmt.Errorf("add group members failed: %w", err)
+	}
+	return nil
+}
+
+// RemoveMembers 移除群成员
+func (s *RedisGroupStore) RemoveMembers(ctx context.Context, groupID int64, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	if err := s.redis.HDel(ctx, buildGroupMembersKey(groupID), userIDs...).Err(); err != nil {
+		return fmt.Errorf("remove group members failed: %w", err)
+	}
+	return nil
+}
+
+// GetMember 获取群成员
+func (s *RedisGroupStore) GetMember(ctx context.Context, groupID int64, userID string) (*grouppb.GroupMember, error) {
+	val, err := s.redis.HGet(ctx, buildGroupMembersKey(groupID), userID).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("get group member failed: %w", err)
+	}
+
+	member := &grouppb.GroupMember{}
+	if err := proto.Unmarshal(val, member); err != nil {
+		return nil, fmt.Errorf("unmarshal group member failed: %w", err)
+	}
+	return member, nil
+}
+
+// GetMembers 获取全部群成员
+func (s *RedisGroupStore) GetMembers(ctx context.Context, groupID int64) ([]*grouppb.GroupMember, error) {
+	vals, err := s.redis.HGetAll(ctx, buildGroupMembersKey(groupID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get group members failed: %w", err)
+	}
+
+	members := make([]*grouppb.GroupMember, 0, len(vals))
+	for _, val := range vals {
+		member := &grouppb.GroupMember{}
+		if err := proto.Unmarshal([]byte(val), member); err != nil {
+			return nil, fmt.Errorf("unmarshal group member failed: %w", err)
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// marshalMembers 将群成员序列化为 HSET 参数
+func marshalMembers(members []*grouppb.GroupMember) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(members)*2)
+	for _, member := range members {
+		raw, err := proto.Marshal(member)
+		if err != nil {
+			return nil, fmt.Errorf("marshal group member failed: %w", err)
+		}
+		values = append(values, member.UserId, raw)
+	}
+	return values, nil
+}
+
+// buildGroupKey 构建群组信息 Key
+func buildGroupKey(groupID int64) string {
+	return fmt.Sprintf(groupKey, groupID)
+}
+
+// buildGroupMembersKey 构建群成员 Key
+func buildGroupMembersKey(groupID int64) string {
+	return fmt.Sprintf(groupMembersKey, groupID)
+}
+
+// memoryGroup 内存群组
+type memoryGroup struct {
+	group   *grouppb.Group
+	members map[string]*grouppb.GroupMember
+}
+
+// MemoryGroupStore 内存群组存储
+type MemoryGroupStore struct {
+	mu     sync.RWMutex
+	groups map[int64]*memoryGroup
+}
+
+// NewMemoryGroupStore 创建内存群组存储
+func NewMemoryGroupStore() *MemoryGroupStore {
+	return &MemoryGroupStore{groups: make(map[int64]*memoryGroup)}
+}
+
+// CreateGroup 创建群组并写入初始成员
+func (s *MemoryGroupStore) CreateGroup(_ context.Context, group *grouppb.Group, members []*grouppb.GroupMember) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g := &memoryGroup{
+		group:   proto.Clone(group).(*grouppb.Group),
+		members: make(map[string]*grouppb.GroupMember, len(members)),
+	}
+	for _, member := range members {
+		g.members[member.UserId] = proto.Clone(member).(*grouppb.GroupMember)
+	}
+	s.groups[group.GroupId] = g
+	return nil
+}
+
+// GetGroup 获取群组信息
+func (s *MemoryGroupStore) GetGroup(_ context.Context, groupID int64) (*grouppb.Group, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	g, ok := s.groups[groupID]
+	if !ok {
+		return nil, ErrGroupNotFound
+	}
+	group := proto.Clone(g.group).(*grouppb.Group)
+	group.MemberCount = int32(len(g.members))
+	return group, nil
+}
+
+// UpdateGroup 更新群组信息
+func (s *MemoryGroupStore) UpdateGroup(_ context.Context, group *grouppb.Group) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.groups[group.GroupId]
+	if !ok {
+		return ErrGroupNotFound
+	}
+	g.group = proto.Clone(group).(*grouppb.Group)
+	return nil
+}
+
+// DeleteGroup 删除群组及全部成员
+func (s *MemoryGroupStore) DeleteGroup(_ context.Context, groupID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.groups, groupID)
+	return nil
+}
+
+// AddMembers 添加或更新群成员
+func (s *MemoryGroupStore) AddMembers(_ context.Context, groupID int64, members []*grouppb.GroupMember) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.groups[groupID]
+	if !ok {
+		return ErrGroupNotFound
+	}
+	for _, member := range members {
+		g.members[member.UserId] = proto.Clone(member).(*grouppb.GroupMember)
+	}
+	return nil
+}
+
+// RemoveMembers 移除群成员
+func (s *MemoryGroupStore) RemoveMembers(_ context.Context, groupID int64, userIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.groups[groupID]
+	if !ok {
+		return nil
+	}
+	for _, userID := range userIDs {
+		delete(g.members, userID)
+	}
+	return nil
+}
+
+// GetMember 获取群成员
+func (s *MemoryGroupStore) GetMember(_ context.Context, groupID int64, userID string) (*grouppb.GroupMember, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	g, ok := s.groups[groupID]
+	if !ok {
+		return nil, ErrMemberNotFound
+	}
+	member, ok := g.members[userID]
+	if !ok {
+		return nil, ErrMemberNotFound
+	}
+	return proto.Clone(member).(*grouppb.GroupMember), nil
+}
+
+// GetMembers 获取全部群成员
+func (s *MemoryGroupStore) GetMembers(_ context.Context, groupID int64) ([]*grouppb.GroupMember, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	g, ok := s.groups[groupID]
+	if !ok {
+		return nil, nil
+	}
+	members := make([]*grouppb.GroupMember, 0, len(g.members))
+	for _, member := range g.members {
+		members = append(members, proto.Clone(member).(*grouppb.GroupMember))
+	}
+	return members, nil
+}
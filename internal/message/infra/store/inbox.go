@@ -0,0 +1,188 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// inboxSeqKey 收件箱序号 Key 格式: kim:inbox:seq:{user_id}
+	inboxSeqKey = "kim:inbox:seq:{%s}"
+	// inboxKey 收件箱 Key 格式: kim:inbox:{user_id}
+	// zset，score 为收件箱序号，member 为序列化后的消息
+	inboxKey = "kim:inbox:{%s}"
+)
+
+// appendInboxLuaScript 原子地分配收件箱序号并写入消息，避免并发写入时同步位点越过还未写入的消息。
+// 只有收件箱 zset 会过期，序号 Key 永不过期（PERSIST 清除旧版本设置的过期时间），
+// 否则序号从 1 重新开始，客户端的同步位点会越过新消息导致消息丢失
+// KEYS[1]: 收件箱序号 Key
+// KEYS[2]: 收件箱 Key
+// ARGV[1]: 序列化后的消息
+// ARGV[2]: 收件箱保留条数
+// ARGV[3]: 收件箱过期时间（秒）
+const appendInboxLuaScript = `
+local seq = redis.call('INCR', KEYS[1])
+redis.call('ZADD', KEYS[2], seq, ARGV[1])
+redis.call('ZREMRANGEBYRANK', KEYS[2], 0, -tonumber(ARGV[2]) - 1)
+redis.call('PERSIST', KEYS[1])
+redis.call('EXPIRE', KEYS[2], ARGV[3])
+return seq
+`
+
+// RedisInbox 基于 Redis zset 的用户收件箱，每个用户保留最近 size 条消息
+type RedisInbox struct {
+	redis     redis.UniversalClient
+	size      int
+	retention time.Duration
+	script    *redis.Script
+}
+
+// NewRedisInbox 创建 Redis 收件箱
+func NewRedisInbox(cli redis.UniversalClient, size int, retention time.Duration) *RedisInbox {
+	return &RedisInbox{
+		redis:     cli,
+		size:      size,
+		retention: retention,
+		script:    redis.NewScript(appendInboxLuaScript),
+	}
+}
+
+// Append 将消息追加到用户收件箱
+func (i *RedisInbox) Append(ctx context.Context, userID string, msg *messagepb.Message) (int64, error) {
+	raw, err := proto.Marshal(msg)
+	if err != nil {
+		return 0, fmt.Errorf("marshal message failed: %w", err)
+	}
+
+	seq, err := i.script.Run(ctx, i.redis, []string{buildInboxSeqKey(userID), buildInboxKey(userID)},
+		raw,
+		i.size,
+		int64(i.retention.Seconds()),
+	).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("append inbox failed: %w", err)
+	}
+	return seq, nil
+}
+
+// Fetch 获取收件箱序号大于 sinceSeq 的消息
+func (i *RedisInbox) Fetch(ctx context.Context, userID string, sinceSeq int64, limit int) ([]*messagepb.Message, int64, error) {
+	var (
+		rangeCmd *redis.ZSliceCmd
+		seqCmd   *redis.StringCmd
+	)
+	_, err := i.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		rangeCmd = pipe.ZRangeByScoreWithScores(ctx, buildInboxKey(userID), &redis.ZRangeBy{
+			Min:   "(" + strconv.FormatInt(sinceSeq, 10),
+			Max:   "+inf",
+			Count: int64(limit),
+		})
+		seqCmd = pipe.Get(ctx, buildInboxSeqKey(userID))
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, 0, fmt.Errorf("fetch inbox failed: %w", err)
+	}
+
+	maxSeq, err := seqCmd.Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, 0, fmt.Errorf("get inbox seq failed: %w", err)
+	}
+
+	msgs := make([]*messagepb.Message, 0, len(rangeCmd.Val()))
+	for _, z := range rangeCmd.Val() {
+		member, _ := z.Member.(string)
+		msg := &messagepb.Message{}
+		if err := proto.Unmarshal([]byte(member), msg); err != nil {
+			return nil, 0, fmt.Errorf("unmarshal message failed: %w", err)
+		}
+		msg.UserSeq = int64(z.Score)
+		msgs = append(msgs, msg)
+	}
+	return msgs, maxSeq, nil
+}
+
+// buildInboxSeqKey 构建收件箱序号 Key
+func buildInboxSeqKey(userID string) string {
+	return fmt.Sprintf(inboxSeqKey, userID)
+}
+
+// buildInboxKey 构建收件箱 Key
+func buildInboxKey(userID string) string {
+	return fmt.Sprintf(inboxKey, userID)
+}
+
+// memoryInbox 单个用户的内存收件箱
+type memoryInbox struct {
+	seq  int64
+	msgs []*messagepb.Message // 按收件箱序号升序
+}
+
+// MemoryInbox 内存用户收件箱，每个用户保留最近 size 条消息
+type MemoryInbox struct {
+	mu      sync.RWMutex
+	size    int
+	inboxes map[string]*memoryInbox
+}
+
+// NewMemoryInbox 创建内存收件箱
+func NewMemoryInbox(size int) *MemoryInbox {
+	return &MemoryInbox{
+		size:    size,
+		inboxes: make(map[string]*memoryInbox),
+	}
+}
+
+// Append 将消息追加到用户收件箱
+func (i *MemoryInbox) Append(_ context.Context, userID string, msg *messagepb.Message) (int64, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	inbox, ok := i.inboxes[userID]
+	if !ok {
+		inbox = &memoryInbox{}
+		i.inboxes[userID] = inbox
+	}
+
+	inbox.seq++
+	msg = proto.Clone(msg).(*messagepb.Message)
+	msg.UserSeq = inbox.seq
+	inbox.msgs = append(inbox.msgs, msg)
+	if len(inbox.msgs) > i.size {
+		inbox.msgs = append(inbox.msgs[:0:0], inbox.msgs[len(inbox.msgs)-i.size:]...)
+	}
+	return inbox.seq, nil
+}
+
+// Fetch 获取收件箱序号大于 sinceSeq 的消息
+func (i *MemoryInbox) Fetch(_ context.Context, userID string, sinceSeq int64, limit int) ([]*messagepb.Message, int64, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	inbox, ok := i.inboxes[userID]
+	if !ok {
+		return nil, 0, nil
+	}
+
+	start := sort.Search(len(inbox.msgs), func(n int) bool { return inbox.msgs[n].UserSeq > sinceSeq })
+	end := len(inbox.msgs)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+
+	msgs := make([]*messagepb.Message, 0, end-start)
+	for _, msg := range inbox.msgs[start:end] {
+		msgs = append(msgs, proto.Clone(msg).(*messagepb.Message))
+	}
+	return msgs, inbox.seq, nil
+}
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	grouppb "github.com/wsx864321/kim/idl/group"
+	messagepb "github.com/wsx864321/kim/idl/message"
+)
+
+var (
+	ErrGroupNotFound  = errors.New("group not found")
+	ErrMemberNotFound = errors.New("group member not found")
+
+	ErrConversationNotFound = errors.New("conversation not found")
+)
+
+// Sequencer 会话序号分配器
+type Sequencer interface {
+	// NextSeq 为会话分配下一个序号，同一会话内严格递增
+	NextSeq(ctx context.Context, conversationID int64) (int64, error)
+	// CurrentSeq 获取会话当前已分配的最大序号，会话没有消息时返回 0
+	CurrentSeq(ctx context.Context, conversationID int64) (int64, error)
+}
+
+// MessageStore 消息存储，按会话保存最近的消息
+type MessageStore interface {
+	// SaveMessage 保存消息（消息必须已分配会话ID和序号），同一序号的消息已存在时覆盖
+	SaveMessage(ctx context.Context, msg *messagepb.Message) error
+	// GetMessages 按序号升序获取会话内序号大于 afterSeq 的消息，最多 limit 条
+	GetMessages(ctx context.Context, conversationID, afterSeq int64, limit int) ([]*messagepb.Message, error)
+}
+
+// Deduper 上行消息去重，以 (sender_id, cli_msg_id) 为键记录已受理消息的结果
+type Deduper interface {
+	// Acquire 占用去重键，首次占用返回 acquired=true；
+	// 已存在时返回已受理消息的结果，消息还在处理中时 result 为 nil
+	Acquire(ctx context.Context, senderID string, cliMsgID int64) (result *messagepb.UpstreamResult, acquired bool, err error)
+	// Commit 记录消息的处理结果，去重窗口内的重试都返回该结果
+	Commit(ctx context.Context, senderID string, cliMsgID int64, result *messagepb.UpstreamResult) error
+	// Release 处理失败时释放去重键，允许客户端重试
+	Release(ctx context.Context, senderID string, cliMsgID int64) error
+}
+
+// Inbox 用户收件箱，保存发给用户的消息，设备按收件箱序号增量同步（离线消息）
+type Inbox interface {
+	// Append 将消息追加到用户收件箱，返回分配的收件箱序号（每个用户独立递增）
+	Append(ctx context.Context, userID string, msg *messagepb.Message) (int64, error)
+	// Fetch 按收件箱序号升序获取序号大于 sinceSeq 的消息（已填充 user_seq），最多 limit 条，同时返回收件箱当前最大序号
+	Fetch(ctx context.Context, userID string, sinceSeq int64, limit int) (msgs []*messagepb.Message, maxSeq int64, err error)
+}
+
+// ReadStore 用户在各会话中的已读位置和未读数
+type ReadStore interface {
+	// IncrUnread 用户在会话中的未读数加一
+	IncrUnread(ctx context.Context, userID string, conversationID int64) error
+	// MarkRead 更新用户在会话中的已读位置（只前进不后退）并将未读数设为 unread，
+	// readSeq 不大于当前已读位置时不做修改并返回 false
+	MarkRead(ctx context.Context, userID string, conversationID, readSeq, unread int64) (bool, error)
+	// GetReadSeq 获取用户在会话中的已读位置
+	GetReadSeq(ctx context.Context, userID string, conversationID int64) (int64, error)
+	// GetReadSeqs 获取用户全部会话的已读位置
+	GetReadSeqs(ctx context.Context, userID string) (map[int64]int64, error)
+	// GetUnread 获取用户有未读消息的会话及未读数
+	GetUnread(ctx context.Context, userID string) (map[int64]int64, error)
+}
+
+// Tombstones 消息撤回和删除标记，同步收件箱时据此隐藏收件箱中保存的消息副本
+type Tombstones interface {
+	// Recall 标记消息已撤回，对所有用户生效
+	Recall(ctx context.Context, msgID int64) error
+	// Delete 标记消息被用户删除，只对该用户生效
+	Delete(ctx context.Context, userID string, msgID int64) error
+	// Filter 返回 msgIDs 中已撤回的消息和被该用户删除的消息
+	Filter(ctx context.Context, userID string, msgIDs []int64) (recalled, deleted map[int64]bool, err error)
+}
+
+// ConversationStore 用户的会话列表
+type ConversationStore interface {
+	// Update 以会话最新一条消息更新用户的会话（不存在时创建），last_seq 不大于已记录的序号时忽略，
+	// 置顶、免打扰标记保持不变，mention_seq 为 0 时保留原来的@提醒位置
+	Update(ctx context.Context, userID string, conv *messagepb.Conversation) error
+	// SetPinned 设置会话置顶，会话不存在时返回 ErrConversationNotFound
+	SetPinned(ctx context.Context, userID string, conversationID int64, pinned bool) error
+	// SetMuted 设置会话免打扰，会话不存在时返回 ErrConversationNotFound
+	SetMuted(ctx context.Context, userID string, conversationID int64, muted bool) error
+	// List 分页获取会话，置顶会话在前，其余按 updated_at 倒序（不填充 unread、read_seq）
+	List(ctx context.Context, userID string, offset, limit int) (convs []*messagepb.Conversation, hasMore bool, err error)
+	// SingleID 获取两个用户之间的单聊会话ID（与参数顺序无关），不存在时用 newID 分配并永久保存，并发分配时以先保存的为准
+	SingleID(ctx context.Context, userA, userB string, newID func() int64) (int64, error)
+	// GetSingleID 查询两个用户之间已分配的单聊会话ID（与参数顺序无关），未分配时返回 ErrConversationNotFound
+	GetSingleID(ctx context.Context, userA, userB string) (int64, error)
+}
+
+// GroupStore 群组和群成员存储
+type GroupStore interface {
+	// CreateGroup 创建群组并写入初始成员
+	CreateGroup(ctx context.Context, group *grouppb.Group, members []*grouppb.GroupMember) error
+	// GetGroup 获取群组信息（member_count 为当前成员数量），不存在时返回 ErrGroupNotFound
+	GetGroup(ctx context.Context, groupID int64) (*grouppb.Group, error)
+	// UpdateGroup 更新群组信息
+	UpdateGroup(ctx context.Context, group *grouppb.Group) error
+	// DeleteGroup 删除群组及全部成员
+	DeleteGroup(ctx context.Context, groupID int64) error
+	// AddMembers 添加或更新群成员
+	AddMembers(ctx context.Context, groupID int64, members []*grouppb.GroupMember) error
+	// RemoveMembers 移除群成员
+	RemoveMembers(ctx context.Context, groupID int64, userIDs []string) error
+	// GetMember 获取群成员，不是成员时返回 ErrMemberNotFound
+	GetMember(ctx context.Context, groupID int64, userID string) (*grouppb.GroupMember, error)
+	// GetMembers 获取全部群成员
+	GetMembers(ctx context.Context, groupID int64) ([]*grouppb.GroupMember, error)
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"google.golang.org/protobuf/proto"
+)
+
+// MemorySequencer 内存会话序号分配器，用于测试和单机部署，重启后序号从头开始
+type MemorySequencer struct {
+	mu   sync.Mutex
+	seqs map[int64]int64
+}
+
+// NewMemorySequencer 创建内存会话序号分配器
+func NewMemorySequencer() *MemorySequencer {
+	return &MemorySequencer{seqs: make(map[int64]int64)}
+}
+
+// NextSeq 为会话分配下一个序号
+func (s *MemorySequencer) NextSeq(_ context.Context, conversationID int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seqs[conversationID]++
+	return s.seqs[conversationID], nil
+}
+
+// CurrentSeq 获取会话当前已分配的最大序号
+func (s *MemorySequencer) CurrentSeq(_ context.Context, conversationID int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seqs[conversationID], nil
+}
+
+// MemoryMessageStore 内存消息存储，每个会话保留最近 historySize 条消息
+type MemoryMessageStore struct {
+	mu          sync.RWMutex
+	historySize int
+	msgs        map[int64][]*messagepb.Message // 按序号升序
+}
+
+// NewMemoryMessageStore 创建内存消息存储
+func NewMemoryMessageStore(historySize int) *MemoryMessageStore {
+	return &MemoryMessageStore{
+		historySize: historySize,
+		msgs:        make(map[int64][]*messagepb.Message),
+	}
+}
+
+// SaveMessage 保存消息
+func (s *MemoryMessageStore) SaveMessage(_ context.Context, msg *messagepb.Message) error {
+	msg = proto.Clone(msg).(*messagepb.Message)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := s.msgs[msg.ConversationId]
+	i := sort.Search(len(list), func(i int) bool { return list[i].Seq >= msg.Seq })
+	if i < len(list) && list[i].Seq == msg.Seq {
+		list[i] = msg
+	} else {
+		list = append(list, nil)
+		copy(list[i+1:], list[i:])
+		list[i] = msg
+	}
+	if len(list) > s.historySize {
+		list = append(list[:0:0], list[len(list)-s.historySize:]...)
+	}
+	s.msgs[msg.ConversationId] = list
+	return nil
+}
+
+// GetMessages 按序号升序获取会话内序号大于 afterSeq 的消息
+func (s *MemoryMessageStore) GetMessages(_ context.Context, conversationID, afterSeq int64, limit int) ([]*messagepb.Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := s.msgs[conversationID]
+	i := sort.Search(len(list), func(i int) bool { return list[i].Seq > afterSeq })
+	end := len(list)
+	if limit > 0 && i+limit < end {
+		end = i + limit
+	}
+
+	msgs := make([]*messagepb.Message, 0, end-i)
+	for _, msg := range list[i:end] {
+		msgs = append(msgs, proto.Clone(msg).(*messagepb.Message))
+	}
+	return msgs, nil
+}
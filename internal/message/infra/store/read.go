@@ -0,0 +1,226 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// readSeqKey 已读位置 Key 格式: kim:read:{user_id}
+	// hash，field 为会话ID，value 为已读到的会话序号
+	readSeqKey = "kim:read:{%s}"
+	// unreadKey 未读数 Key 格式: kim:unread:{user_id}
+	// hash，field 为会话ID，value 为未读数，未读数为 0 的会话不保存
+	unreadKey = "kim:unread:{%s}"
+)
+
+// markReadLuaScript 已读位置只前进不后退，前进时同时更新未读数
+// KEYS[1]: 已读位置 Key
+// KEYS[2]: 未读数 Key
+// ARGV[1]: 会话ID
+// ARGV[2]: 已读位置
+// ARGV[3]: 未读数
+// ARGV[4]: 过期时间（秒）
+const markReadLuaScript = `
+local cur = tonumber(redis.call('HGET', KEYS[1], ARGV[1]) or '0')
+if tonumber(ARGV[2]) <= cur then
+	return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+if tonumber(ARGV[3]) > 0 then
+	redis.call('HSET', KEYS[2], ARGV[1], ARGV[3])
+	redis.call('EXPIRE', KEYS[2], ARGV[4])
+else
+	redis.call('HDEL', KEYS[2], ARGV[1])
+end
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+return 1
+`
+
+// RedisReadStore 基于 Redis hash 的已读位置和未读数存储
+type RedisReadStore struct {
+	redis     redis.UniversalClient
+	retention time.Duration
+	script    *redis.Script
+}
+
+// NewRedisReadStore 创建 Redis 已读位置和未读数存储
+func NewRedisReadStore(cli redis.UniversalClient, retention time.Duration) *RedisReadStore {
+	return &RedisReadStore{
+		redis:     cli,
+		retention: retention,
+		script:    redis.NewScript(markReadLuaScript),
+	}
+}
+
+// IncrUnread 会话未读数加一
+func (s *RedisReadStore) IncrUnread(ctx context.Context, userID string, conversationID int64) error {
+	key := buildUnreadKey(userID)
+	_, err := s.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(ctx, key, strconv.FormatInt(conversationID, 10), 1)
+		pipe.Expire(ctx, key, s.retention)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("incr unread failed: %w", err)
+	}
+	return nil
+}
+
+// MarkRead 更新已读位置和未读数
+func (s *RedisReadStore) MarkRead(ctx context.Context, userID string, conversationID, readSeq, unread int64) (bool, error) {
+	n, err := s.script.Run(ctx, s.redis, []string{buildReadSeqKey(userID), buildUnreadKey(userID)},
+		conversationID,
+		readSeq,
+		unread,
+		int64(s.retention.Seconds()),
+	).Int64()
+	if err != nil {
+		return false, fmt.Errorf("mark read failed: %w", err)
+	}
+	return n == 1, nil
+}
+
+// GetReadSeq 获取会话已读位置
+func (s *RedisReadStore) GetReadSeq(ctx context.Context, userID string, conversationID int64) (int64, error) {
+	seq, err := s.redis.HGet(ctx, buildReadSeqKey(userID), strconv.FormatInt(conversationID, 10)).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("get read seq failed: %w", err)
+	}
+	return seq, nil
+}
+
+// GetReadSeqs 获取全部会话已读位置
+func (s *RedisReadStore) GetReadSeqs(ctx context.Context, userID string) (map[int64]int64, error) {
+	vals, err := s.redis.HGetAll(ctx, buildReadSeqKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get read seqs failed: %w", err)
+	}
+	return parseInt64Hash(vals), nil
+}
+
+// GetUnread 获取有未读消息的会话及未读数
+func (s *RedisReadStore) GetUnread(ctx context.Context, userID string) (map[int64]int64, error) {
+	vals, err := s.redis.HGetAll(ctx, buildUnreadKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get unread failed: %w", err)
+	}
+	return parseInt64Hash(vals), nil
+}
+
+// parseInt64Hash 解析 field、value 都是整数的 hash，忽略无法解析的字段
+func parseInt64Hash(vals map[string]string) map[int64]int64 {
+	result := make(map[int64]int64, len(vals))
+	for field, val := range vals {
+		k, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			continue
+		}
+		v, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// buildReadSeqKey 构建已读位置 Key
+func buildReadSeqKey(userID string) string {
+	return fmt.Sprintf(readSeqKey, userID)
+}
+
+// buildUnreadKey 构建未读数 Key
+func buildUnreadKey(userID string) string {
+	return fmt.Sprintf(unreadKey, userID)
+}
+
+// MemoryReadStore 内存已读位置和未读数存储
+type MemoryReadStore struct {
+	mu       sync.RWMutex
+	readSeqs map[string]map[int64]int64
+	unread   map[string]map[int64]int64
+}
+
+// NewMemoryReadStore 创建内存已读位置和未读数存储
+func NewMemoryReadStore() *MemoryReadStore {
+	return &MemoryReadStore{
+		readSeqs: make(map[string]map[int64]int64),
+		unread:   make(map[string]map[int64]int64),
+	}
+}
+
+// IncrUnread 会话未读数加一
+func (s *MemoryReadStore) IncrUnread(_ context.Context, userID string, conversationID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.unread[userID] == nil {
+		s.unread[userID] = make(map[int64]int64)
+	}
+	s.unread[userID][conversationID]++
+	return nil
+}
+
+// MarkRead 更新已读位置和未读数
+func (s *MemoryReadStore) MarkRead(_ context.Context, userID string, conversationID, readSeq, unread int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if readSeq <= s.readSeqs[userID][conversationID] {
+		return false, nil
+	}
+	if s.readSeqs[userID] == nil {
+		s.readSeqs[userID] = make(map[int64]int64)
+	}
+	s.readSeqs[userID][conversationID] = readSeq
+
+	if unread > 0 {
+		if s.unread[userID] == nil {
+			s.unread[userID] = make(map[int64]int64)
+		}
+		s.unread[userID][conversationID] = unread
+	} else {
+		delete(s.unread[userID], conversationID)
+	}
+	return true, nil
+}
+
+// GetReadSeq 获取会话已读位置
+func (s *MemoryReadStore) GetReadSeq(_ context.Context, userID string, conversationID int64) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.readSeqs[userID][conversationID], nil
+}
+
+// GetReadSeqs 获取全部会话已读位置
+func (s *MemoryReadStore) GetReadSeqs(_ context.Context, userID string) (map[int64]int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return copyInt64Map(s.readSeqs[userID]), nil
+}
+
+// GetUnread 获取有未读消息的会话及未读数
+func (s *MemoryReadStore) GetUnread(_ context.Context, userID string) (map[int64]int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return copyInt64Map(s.unread[userID]), nil
+}
+
+// copyInt64Map 复制 map
+func copyInt64Map(m map[int64]int64) map[int64]int64 {
+	result := make(map[int64]int64, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
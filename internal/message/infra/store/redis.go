@@ -0,0 +1,119 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// conversationSeqKey 会话序号 Key 格式: kim:conv:seq:{conversation_id}
+	conversationSeqKey = "kim:conv:seq:{%d}"
+	// conversationMsgsKey 会话消息 Key 格式: kim:conv:msgs:{conversation_id}
+	// zset，score 为会话序号，member 为序列化后的消息
+	conversationMsgsKey = "kim:conv:msgs:{%d}"
+)
+
+// RedisSequencer 基于 Redis INCR 的会话序号分配器
+type RedisSequencer struct {
+	redis redis.UniversalClient
+}
+
+// NewRedisSequencer 创建 Redis 会话序号分配器
+func NewRedisSequencer(cli redis.UniversalClient) *RedisSequencer {
+	return &RedisSequencer{redis: cli}
+}
+
+// NextSeq 为会话分配下一个序号
+func (s *RedisSequencer) NextSeq(ctx context.Context, conversationID int64) (int64, error) {
+	seq, err := s.redis.Incr(ctx, buildConversationSeqKey(conversationID)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("incr conversation seq failed: %w", err)
+	}
+	return seq, nil
+}
+
+// CurrentSeq 获取会话当前已分配的最大序号
+func (s *RedisSequencer) CurrentSeq(ctx context.Context, conversationID int64) (int64, error) {
+	seq, err := s.redis.Get(ctx, buildConversationSeqKey(conversationID)).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, fmt.Errorf("get conversation seq failed: %w", err)
+	}
+	return seq, nil
+}
+
+// RedisMessageStore 基于 Redis zset 的消息存储，每个会话保留最近 historySize 条消息
+type RedisMessageStore struct {
+	redis       redis.UniversalClient
+	historySize int64
+	retention   time.Duration
+}
+
+// NewRedisMessageStore 创建 Redis 消息存储
+func NewRedisMessageStore(cli redis.UniversalClient, historySize int, retention time.Duration) *RedisMessageStore {
+	return &RedisMessageStore{
+		redis:       cli,
+		historySize: int64(historySize),
+		retention:   retention,
+	}
+}
+
+// SaveMessage 保存消息，同一序号的消息已存在时覆盖，超过保留条数的旧消息被裁剪
+func (s *RedisMessageStore) SaveMessage(ctx context.Context, msg *messagepb.Message) error {
+	raw, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal message failed: %w", err)
+	}
+
+	key := buildConversationMsgsKey(msg.ConversationId)
+	seq := strconv.FormatInt(msg.Seq, 10)
+	_, err = s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, key, seq, seq)
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(msg.Seq), Member: raw})
+		pipe.ZRemRangeByRank(ctx, key, 0, -s.historySize-1)
+		pipe.Expire(ctx, key, s.retention)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save message failed: %w", err)
+	}
+	return nil
+}
+
+// GetMessages 按序号升序获取会话内序号大于 afterSeq 的消息
+func (s *RedisMessageStore) GetMessages(ctx context.Context, conversationID, afterSeq int64, limit int) ([]*messagepb.Message, error) {
+	vals, err := s.redis.ZRangeByScore(ctx, buildConversationMsgsKey(conversationID), &redis.ZRangeBy{
+		Min:   "(" + strconv.FormatInt(afterSeq, 10),
+		Max:   "+inf",
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get messages failed: %w", err)
+	}
+
+	msgs := make([]*messagepb.Message, 0, len(vals))
+	for _, val := range vals {
+		msg := &messagepb.Message{}
+		if err := proto.Unmarshal([]byte(val), msg); err != nil {
+			return nil, fmt.Errorf("unmarshal message failed: %w", err)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// buildConversationSeqKey 构建会话序号 Key
+func buildConversationSeqKey(conversationID int64) string {
+	return fmt.Sprintf(conversationSeqKey, conversationID)
+}
+
+// buildConversationMsgsKey 构建会话消息 Key
+func buildConversationMsgsKey(conversationID int64) string {
+	return fmt.Sprintf(conversationMsgsKey, conversationID)
+}
@@ -0,0 +1,153 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// recalledKey 撤回标记 Key 格式: kim:recalled:{msg_id}
+	recalledKey = "kim:recalled:{%d}"
+	// deletedKey 用户删除的消息 Key 格式: kim:deleted:{user_id}
+	// set，member 为消息ID
+	deletedKey = "kim:deleted:{%s}"
+)
+
+// RedisTombstones 基于 Redis 的撤回和删除标记，保留时间和收件箱一致
+type RedisTombstones struct {
+	redis     redis.UniversalClient
+	retention time.Duration
+}
+
+// NewRedisTombstones 创建 Redis 撤回和删除标记存储
+func NewRedisTombstones(cli redis.UniversalClient, retention time.Duration) *RedisTombstones {
+	return &RedisTombstones{
+		redis:     cli,
+		retention: retention,
+	}
+}
+
+// Recall 标记消息已撤回
+func (t *RedisTombstones) Recall(ctx context.Context, msgID int64) error {
+	if err := t.redis.Set(ctx, buildRecalledKey(msgID), 1, t.retention).Err(); err != nil {
+		return fmt.Errorf("set recalled failed: %w", err)
+	}
+	return nil
+}
+
+// Delete 标记消息被用户删除
+func (t *RedisTombstones) Delete(ctx context.Context, userID string, msgID int64) error {
+	key := buildDeletedKey(userID)
+	_, err := t.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, key, msgID)
+		pipe.Expire(ctx, key, t.retention)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("add deleted failed: %w", err)
+	}
+	return nil
+}
+
+// Filter 返回已撤回和被用户删除的消息，撤回标记分布在不同的 slot，通过 pipeline 逐条查询
+func (t *RedisTombstones) Filter(ctx context.Context, userID string, msgIDs []int64) (map[int64]bool, map[int64]bool, error) {
+	if len(msgIDs) == 0 {
+		return nil, nil, nil
+	}
+
+	recalledCmds := make([]*redis.IntCmd, len(msgIDs))
+	members := make([]interface{}, len(msgIDs))
+	for i, msgID := range msgIDs {
+		members[i] = strconv.FormatInt(msgID, 10)
+	}
+	var deletedCmd *redis.BoolSliceCmd
+	_, err := t.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, msgID := range msgIDs {
+			recalledCmds[i] = pipe.Exists(ctx, buildRecalledKey(msgID))
+		}
+		deletedCmd = pipe.SMIsMember(ctx, buildDeletedKey(userID), members...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("filter tombstones failed: %w", err)
+	}
+
+	recalled := make(map[int64]bool)
+	deleted := make(map[int64]bool)
+	for i, msgID := range msgIDs {
+		if recalledCmds[i].Val() > 0 {
+			recalled[msgID] = true
+		}
+		if deletedCmd.Val()[i] {
+			deleted[msgID] = true
+		}
+	}
+	return recalled, deleted, nil
+}
+
+// buildRecalledKey 构建撤回标记 Key
+func buildRecalledKey(msgID int64) string {
+	return fmt.Sprintf(recalledKey, msgID)
+}
+
+// buildDeletedKey 构建用户删除的消息 Key
+func buildDeletedKey(userID string) string {
+	return fmt.Sprintf(deletedKey, userID)
+}
+
+// MemoryTombstones 内存撤回和删除标记
+type MemoryTombstones struct {
+	mu       sync.RWMutex
+	recalled map[int64]bool
+	deleted  map[string]map[int64]bool
+}
+
+// NewMemoryTombstones 创建内存撤回和删除标记存储
+func NewMemoryTombstones() *MemoryTombstones {
+	return &MemoryTombstones{
+		recalled: make(map[int64]bool),
+		deleted:  make(map[string]map[int64]bool),
+	}
+}
+
+// Recall 标记消息已撤回
+func (t *MemoryTombstones) Recall(_ context.Context, msgID int64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.recalled[msgID] = true
+	return nil
+}
+
+// Delete 标记消息被用户删除
+func (t *MemoryTombstones) Delete(_ context.Context, userID string, msgID int64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.deleted[userID] == nil {
+		t.deleted[userID] = make(map[int64]bool)
+	}
+	t.deleted[userID][msgID] = true
+	return nil
+}
+
+// Filter 返回已撤回和被用户删除的消息
+func (t *MemoryTombstones) Filter(_ context.Context, userID string, msgIDs []int64) (map[int64]bool, map[int64]bool, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	recalled := make(map[int64]bool)
+	deleted := make(map[int64]bool)
+	for _, msgID := range msgIDs {
+		if t.recalled[msgID] {
+			recalled[msgID] = true
+		}
+		if t.deleted[userID][msgID] {
+			deleted[msgID] = true
+		}
+	}
+	return recalled, deleted, nil
+}
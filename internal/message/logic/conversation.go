@@ -0,0 +1,181 @@
+package logic
+
+import (
+	"context"
+	"errors"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/internal/message/infra/store"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/protobuf/proto"
+)
+
+// conversationID 获取消息所属的会话ID
+// 群聊为群ID；单聊为双方用户第一次通信时分配的会话ID，与发送方向无关，双方得到同一个会话ID，
+// 会话ID与群ID使用同一个ID生成器，不会冲突
+func (s *MessageService) conversationID(ctx context.Context, msg *messagepb.Message) (int64, *xerr.Error) {
+	if msg.GroupId != 0 {
+		return msg.GroupId, nil
+	}
+
+	id, err := s.convStore.SingleID(ctx, msg.SenderId, msg.ReceiverId, s.idGen.NextID)
+	if err != nil {
+		log.Error(ctx, "get single conversation id failed",
+			log.String("sender_id", msg.SenderId),
+			log.String("receiver_id", msg.ReceiverId),
+			log.String("error", err.Error()),
+		)
+		return 0, xerr.ErrInternalServer
+	}
+	return id, nil
+}
+
+// existingConversationID 获取消息所属的已有会话ID，单聊双方没有通信过时返回 ErrConversationNotFound，不分配会话ID；
+// 只有发送消息时分配会话ID，已读回执、撤回等操作不能为任意用户对创建会话
+func (s *MessageService) existingConversationID(ctx context.Context, msg *messagepb.Message) (int64, *xerr.Error) {
+	if msg.GroupId != 0 {
+		return msg.GroupId, nil
+	}
+
+	id, err := s.convStore.GetSingleID(ctx, msg.SenderId, msg.ReceiverId)
+	if errors.Is(err, store.ErrConversationNotFound) {
+		return 0, xerr.ErrConversationNotFound
+	}
+	if err != nil {
+		log.Error(ctx, "get single conversation id failed",
+			log.String("sender_id", msg.SenderId),
+			log.String("receiver_id", msg.ReceiverId),
+			log.String("error", err.Error()),
+		)
+		return 0, xerr.ErrInternalServer
+	}
+	return id, nil
+}
+
+const (
+	// defaultConversationLimit 会话列表默认每页数量
+	defaultConversationLimit = 20
+	// maxConversationLimit 会话列表每页最大数量
+	maxConversationLimit = 100
+)
+
+// conversationOf 以消息为最新消息构建用户视角的会话，群消息@了该用户时记录@提醒位置
+func conversationOf(userID string, msg *messagepb.Message) *messagepb.Conversation {
+	last := proto.Clone(msg).(*messagepb.Message)
+	last.UserSeq = 0
+
+	conv := &messagepb.Conversation{
+		ConversationId: msg.ConversationId,
+		GroupId:        msg.GroupId,
+		LastMessage:    last,
+		LastSeq:        msg.Seq,
+		UpdatedAt:      msg.ServerTs,
+	}
+	if msg.GroupId == 0 {
+		conv.PeerId = msg.ReceiverId
+		if userID == msg.ReceiverId {
+			conv.PeerId = msg.SenderId
+		}
+	} else if userID != msg.SenderId && isMentioned(msg, userID) {
+		conv.MentionSeq = msg.Seq
+	}
+	return conv
+}
+
+// updateConversation 用消息更新用户会话列表中的会话，失败只记录日志
+func updateConversation(ctx context.Context, convStore store.ConversationStore, userID string, msg *messagepb.Message) {
+	if err := convStore.Update(ctx, userID, conversationOf(userID, msg)); err != nil {
+		log.Warn(ctx, "update conversation failed",
+			log.String("user_id", userID),
+			log.Int64("conversation_id", msg.ConversationId),
+			log.String("error", err.Error()),
+		)
+	}
+}
+
+// ListConversations 分页获取用户的会话列表，填充未读数和已读位置，隐藏已撤回、已删除的最新消息内容
+func (s *MessageService) ListConversations(ctx context.Context, req *messagepb.ListConversationsReq) (*messagepb.ListConversationsData, *xerr.Error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultConversationLimit
+	}
+	if limit > maxConversationLimit {
+		limit = maxConversationLimit
+	}
+
+	convs, hasMore, err := s.convStore.List(ctx, req.UserId, int(req.Offset), limit)
+	if err != nil {
+		log.Error(ctx, "list conversations failed",
+			log.String("user_id", req.UserId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+
+	unread, err := s.readStore.GetUnread(ctx, req.UserId)
+	if err != nil {
+		log.Error(ctx, "get unread failed",
+			log.String("user_id", req.UserId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+	readSeqs, err := s.readStore.GetReadSeqs(ctx, req.UserId)
+	if err != nil {
+		log.Error(ctx, "get read seqs failed",
+			log.String("user_id", req.UserId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+
+	lastMsgs := make([]*messagepb.Message, 0, len(convs))
+	for _, conv := range convs {
+		conv.Unread = unread[conv.ConversationId]
+		conv.ReadSeq = readSeqs[conv.ConversationId]
+		if conv.LastMessage != nil {
+			lastMsgs = append(lastMsgs, conv.LastMessage)
+		}
+	}
+	if err := s.applyTombstones(ctx, req.UserId, lastMsgs); err != nil {
+		log.Error(ctx, "filter tombstones failed",
+			log.String("user_id", req.UserId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+
+	return &messagepb.ListConversationsData{
+		Conversations: convs,
+		HasMore:       hasMore,
+	}, nil
+}
+
+// SetConversationPinned 设置会话置顶
+func (s *MessageService) SetConversationPinned(ctx context.Context, req *messagepb.SetConversationPinnedReq) *xerr.Error {
+	err := s.convStore.SetPinned(ctx, req.UserId, req.ConversationId, req.Pinned)
+	return s.convertConversationError(ctx, req.UserId, req.ConversationId, err)
+}
+
+// SetConversationMuted 设置会话免打扰
+func (s *MessageService) SetConversationMuted(ctx context.Context, req *messagepb.SetConversationMutedReq) *xerr.Error {
+	err := s.convStore.SetMuted(ctx, req.UserId, req.ConversationId, req.Muted)
+	return s.convertConversationError(ctx, req.UserId, req.ConversationId, err)
+}
+
+// convertConversationError 转换会话存储错误
+func (s *MessageService) convertConversationError(ctx context.Context, userID string, conversationID int64, err error) *xerr.Error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, store.ErrConversationNotFound) {
+		return xerr.ErrConversationNotFound
+	}
+	log.Error(ctx, "update conversation flag failed",
+		log.String("user_id", userID),
+		log.Int64("conversation_id", conversationID),
+		log.String("error", err.Error()),
+	)
+	return xerr.ErrInternalServer
+}
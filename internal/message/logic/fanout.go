@@ -0,0 +1,238 @@
+package logic
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	pushpb "github.com/wsx864321/kim/idl/push"
+	"github.com/wsx864321/kim/internal/message/infra/grpc/push"
+	"github.com/wsx864321/kim/internal/message/infra/store"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/protobuf/proto"
+)
+
+// pushBatchSize 每次调用 Push 服务 BatchPushMsg 的目标数量
+const pushBatchSize = 500
+
+// FanoutConfig 群消息扩散配置
+type FanoutConfig struct {
+	// AsyncThreshold 接收人数超过该值时异步推送，发送方不等待推送完成（收件箱始终在请求内写入）
+	AsyncThreshold int
+	// Workers 异步推送的工作协程数量
+	Workers int
+	// QueueSize 异步推送任务队列长度，队列满时在请求内同步推送
+	QueueSize int
+	// Concurrency 单次扩散中并发写收件箱、请求 Push 服务的数量
+	Concurrency int
+	// Timeout 异步推送任务的超时时间
+	Timeout time.Duration
+}
+
+// withDefaults 填充未设置的配置
+func (c FanoutConfig) withDefaults() FanoutConfig {
+	if c.AsyncThreshold <= 0 {
+		c.AsyncThreshold = 200
+	}
+	if c.Workers <= 0 {
+		c.Workers = 8
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = 1024
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = 16
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 30 * time.Second
+	}
+	return c
+}
+
+// fanoutJob 异步推送任务
+type fanoutJob struct {
+	msg     *messagepb.Message
+	targets []*pushpb.PushTarget
+}
+
+// Fanout 群消息扩散（包括群聊的撤回通知和@提醒）：先在请求内把消息写入每个接收人的收件箱，
+// 群聊消息同时增加接收人的会话未读数并更新会话列表；再通过 Push 服务的 BatchPushMsg 推送给在线设备，
+// 每个接收人收到的消息带有自己的收件箱序号。收件箱写入后消息不会因为服务重启丢失，异步推送只影响实时性
+type Fanout struct {
+	pushClient push.ClientInterface
+	inbox      store.Inbox
+	readStore  store.ReadStore
+	convStore  store.ConversationStore
+	cfg        FanoutConfig
+	jobs       chan fanoutJob
+}
+
+// NewFanout 创建群消息扩散器并启动异步推送工作协程
+func NewFanout(pushClient push.ClientInterface, inbox store.Inbox, readStore store.ReadStore, convStore store.ConversationStore, cfg FanoutConfig) *Fanout {
+	cfg = cfg.withDefaults()
+	f := &Fanout{
+		pushClient: pushClient,
+		inbox:      inbox,
+		readStore:  readStore,
+		convStore:  convStore,
+		cfg:        cfg,
+		jobs:       make(chan fanoutJob, cfg.QueueSize),
+	}
+	for i := 0; i < cfg.Workers; i++ {
+		go f.worker()
+	}
+	return f
+}
+
+// Dispatch 扩散消息给接收人：收件箱在请求内写入，人数较少时同步推送，超过阈值时放入队列异步推送
+func (f *Fanout) Dispatch(ctx context.Context, msg *messagepb.Message, userIDs []string) {
+	targets := f.persist(ctx, msg, userIDs)
+	if len(targets) <= f.cfg.AsyncThreshold {
+		f.push(ctx, msg, targets)
+		return
+	}
+
+	select {
+	case f.jobs <- fanoutJob{msg: msg, targets: targets}:
+	default:
+		log.Warn(ctx, "fanout queue is full, push synchronously",
+			log.Int64("msg_id", msg.MsgId),
+			log.Int("receivers", len(targets)),
+		)
+		f.push(ctx, msg, targets)
+	}
+}
+
+// worker 异步推送工作协程
+func (f *Fanout) worker() {
+	for job := range f.jobs {
+		ctx, cancel := context.WithTimeout(context.Background(), f.cfg.Timeout)
+		f.push(ctx, job.msg, job.targets)
+		cancel()
+	}
+}
+
+// persist 写入接收人的收件箱并生成推送目标：每个接收人的消息带有自己的收件箱序号（msg.UserSeq），
+// 写入失败的接收人仍然推送不带序号的消息；@提醒只推送在线设备，离线用户通过会话列表的 mention_seq 获知，不写收件箱
+func (f *Fanout) persist(ctx context.Context, msg *messagepb.Message, userIDs []string) []*pushpb.PushTarget {
+	targets := make([]*pushpb.PushTarget, len(userIDs))
+	f.parallel(len(userIDs), func(i int) {
+		userID := userIDs[i]
+		targets[i] = &pushpb.PushTarget{UserId: userID}
+
+		// 群聊消息增加接收人的未读数并更新会话列表
+		if msg.MsgType == messagepb.MessageType_MESSAGE_TYPE_GROUP_CHAT {
+			if err := f.readStore.IncrUnread(ctx, userID, msg.ConversationId); err != nil {
+				log.Warn(ctx, "incr unread failed",
+					log.String("user_id", userID),
+					log.Int64("conversation_id", msg.ConversationId),
+					log.String("error", err.Error()),
+				)
+			}
+			updateConversation(ctx, f.convStore, userID, msg)
+		}
+
+		if msg.MsgType == messagepb.MessageType_MESSAGE_TYPE_MENTION {
+			return
+		}
+		userSeq, err := f.inbox.Append(ctx, userID, msg)
+		if err != nil {
+			log.Error(ctx, "append inbox failed",
+				log.String("user_id", userID),
+				log.Int64("msg_id", msg.MsgId),
+				log.String("error", err.Error()),
+			)
+			return
+		}
+
+		userMsg := proto.Clone(msg).(*messagepb.Message)
+		userMsg.UserSeq = userSeq
+		data, err := proto.Marshal(userMsg)
+		if err != nil {
+			log.Error(ctx, "marshal message failed", log.String("error", err.Error()))
+			return
+		}
+		targets[i].Msg = data
+	})
+	return targets
+}
+
+// push 按批调用 Push 服务 BatchPushMsg 推送给接收人的在线设备，没有单独消息内容的目标推送原始消息
+func (f *Fanout) push(ctx context.Context, msg *messagepb.Message, targets []*pushpb.PushTarget) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		log.Error(ctx, "marshal message failed", log.String("error", err.Error()))
+		return
+	}
+
+	batches := (len(targets) + pushBatchSize - 1) / pushBatchSize
+	var (
+		mu        sync.Mutex
+		delivered int
+	)
+	f.parallel(batches, func(i int) {
+		batch := targets[i*pushBatchSize : min((i+1)*pushBatchSize, len(targets))]
+		resp, err := f.pushClient.BatchPushMsg(ctx, &pushpb.BatchPushReq{
+			Targets:  batch,
+			Msg:      data,
+			Priority: isPriority(msg),
+		})
+		if err != nil {
+			log.Warn(ctx, "batch push message failed",
+				log.Int64("msg_id", msg.MsgId),
+				log.Int("targets", len(batch)),
+				log.String("error", err.Error()),
+			)
+			return
+		}
+		if resp.Code != xerr.OK.Code() {
+			log.Warn(ctx, "batch push message failed",
+				log.Int64("msg_id", msg.MsgId),
+				log.Int("code", int(resp.Code)),
+				log.String("message", resp.Message),
+			)
+			return
+		}
+
+		n := 0
+		for _, result := range resp.Results {
+			if result.Code == xerr.OK.Code() {
+				n++
+			}
+		}
+		mu.Lock()
+		delivered += n
+		mu.Unlock()
+	})
+
+	log.Debug(ctx, "group message fanout",
+		log.Int64("group_id", msg.GroupId),
+		log.Int64("msg_id", msg.MsgId),
+		log.Int("receivers", len(targets)),
+		log.Int("delivered", delivered),
+	)
+}
+
+// parallel 以有限并发执行 n 个任务并等待全部完成
+func (f *Fanout) parallel(n int, fn func(i int)) {
+	if n == 0 {
+		return
+	}
+
+	sem := make(chan struct{}, f.cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,232 @@
+package logic
+
+import (
+	"context"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	grouppb "github.com/wsx864321/kim/idl/group"
+	messagepb "github.com/wsx864321/kim/idl/message"
+	pushpb "github.com/wsx864321/kim/idl/push"
+	"github.com/wsx864321/kim/internal/message/infra/store"
+	"github.com/wsx864321/kim/internal/message/pkg/id"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/protobuf/proto"
+)
+
+// groupTest 群聊测试环境：alice 为群主，bob、carol、dave 为成员
+type groupTest struct {
+	s       *MessageService
+	group   *grouppb.Group
+	inbox   store.Inbox
+	conv    store.ConversationStore
+	pushCli *fakePushClient
+}
+
+func newGroupTest(t *testing.T) *groupTest {
+	ctx := context.Background()
+	inbox := store.NewMemoryInbox(100)
+	groupStore := store.NewMemoryGroupStore()
+	readStore := store.NewMemoryReadStore()
+	convStore := store.NewMemoryConversationStore(100)
+	idGen := id.NewGenerator(1)
+
+	pushCli := &fakePushClient{}
+	fanout := NewFanout(pushCli, inbox, readStore, convStore, FanoutConfig{})
+	s := NewMessageService(pushCli, store.NewMemorySequencer(), store.NewMemoryMessageStore(100),
+		store.NewMemoryDeduper(time.Minute), inbox, readStore, store.NewMemoryTombstones(), convStore, idGen, WithGroup(groupStore, fanout))
+
+	group, xe := NewGroupService(groupStore, idGen, 100).CreateGroup(ctx, &grouppb.CreateGroupReq{
+		OperatorId: "alice",
+		Name:       "test",
+		MemberIds:  []string{"bob", "carol", "dave"},
+	})
+	if xe != nil {
+		t.Fatalf("CreateGroup failed: %v", xe)
+	}
+	return &groupTest{s: s, group: group, inbox: inbox, conv: convStore, pushCli: pushCli}
+}
+
+func groupRequest(sender string, groupID int64, elems ...*messagepb.MessageContent) *messagepb.UpstreamRequest {
+	elems = append(elems, &messagepb.MessageContent{
+		ElementType: messagepb.MessageElementType_CONTENT_ELEMENT_TEXT,
+		Content:     &messagepb.MessageContent_Text{Text: &messagepb.TextElement{Text: "hi"}},
+	})
+	payload, _ := proto.Marshal(&messagepb.Message{
+		GroupId: groupID,
+		MsgBody: elems,
+	})
+	return &messagepb.UpstreamRequest{
+		MsgType:  messagepb.MessageType_MESSAGE_TYPE_GROUP_CHAT,
+		Payload:  payload,
+		SenderId: sender,
+	}
+}
+
+func TestSendGroupMessageFanout(t *testing.T) {
+	ctx := context.Background()
+	gt := newGroupTest(t)
+	s, group, inbox := gt.s, gt.group, gt.inbox
+
+	result, xe := s.SendMessage(ctx, groupRequest("alice", group.GroupId))
+	if xe != nil {
+		t.Fatalf("SendMessage failed: %v", xe)
+	}
+	if result.ConversationId != group.GroupId || result.Seq != 1 {
+		t.Fatalf("unexpected result: %v", result)
+	}
+
+	// 所有成员（包括在线成员）都写入收件箱，发送者的收件箱用于多端同步
+	userSeqs := make(map[string]int64)
+	for _, userID := range []string{"alice", "bob", "carol", "dave"} {
+		msgs, _, err := inbox.Fetch(ctx, userID, 0, 10)
+		if err != nil {
+			t.Fatalf("Fetch failed: %v", err)
+		}
+		if len(msgs) != 1 || msgs[0].MsgId != result.MsgId {
+			t.Fatalf("inbox of %s: got %v", userID, msgs)
+		}
+		userSeqs[userID] = msgs[0].UserSeq
+	}
+
+	// 发送者以外的成员一次批量推送，每个成员的消息带有自己的收件箱序号
+	reqs := gt.pushCli.batchReqs
+	if len(reqs) != 1 || len(reqs[0].Targets) != 3 {
+		t.Fatalf("unexpected batch pushes: %v", reqs)
+	}
+	for _, target := range reqs[0].Targets {
+		var pushed messagepb.Message
+		if err := proto.Unmarshal(target.Msg, &pushed); err != nil {
+			t.Fatalf("unmarshal pushed message failed: %v", err)
+		}
+		if target.UserId == "alice" || pushed.MsgId != result.MsgId || pushed.UserSeq == 0 || pushed.UserSeq != userSeqs[target.UserId] {
+			t.Fatalf("unexpected push to %s: %v", target.UserId, &pushed)
+		}
+	}
+
+	// 非群成员不能发送
+	_, xe = s.SendMessage(ctx, groupRequest("eve", group.GroupId))
+	if xe == nil || xe.Code() != xerr.ErrGroupNotMember.Code() {
+		t.Fatalf("expected ErrGroupNotMember, got %v", xe)
+	}
+}
+
+func TestFanoutPushBinaryMessage(t *testing.T) {
+	ctx := context.Background()
+	pushCli := &fakePushClient{}
+	f := NewFanout(pushCli, store.NewMemoryInbox(100), store.NewMemoryReadStore(), store.NewMemoryConversationStore(100), FanoutConfig{})
+
+	// msg_id 的 varint 编码以 0x80 开头，序列化后的消息不是合法的 UTF-8
+	msg := &messagepb.Message{MsgId: 1 << 62, GroupId: 1, MsgType: messagepb.MessageType_MESSAGE_TYPE_MENTION}
+	f.push(ctx, msg, []*pushpb.PushTarget{{UserId: "bob"}, {UserId: "carol"}})
+
+	if len(pushCli.batchReqs) != 1 {
+		t.Fatalf("unexpected batch pushes: %v", pushCli.batchReqs)
+	}
+	req := pushCli.batchReqs[0]
+	if utf8.Valid(req.Msg) {
+		t.Fatalf("expected binary message, got %q", req.Msg)
+	}
+
+	data, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatalf("marshal BatchPushReq failed: %v", err)
+	}
+	var decoded pushpb.BatchPushReq
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal BatchPushReq failed: %v", err)
+	}
+	var pushed messagepb.Message
+	if err := proto.Unmarshal(decoded.Msg, &pushed); err != nil || !proto.Equal(&pushed, msg) {
+		t.Fatalf("unexpected pushed message: %v %v", &pushed, err)
+	}
+}
+
+func mentionElement(atAll bool, userIDs ...string) *messagepb.MessageContent {
+	return &messagepb.MessageContent{
+		ElementType: messagepb.MessageElementType_CONTENT_ELEMENT_MENTION,
+		Content:     &messagepb.MessageContent_Mention{Mention: &messagepb.MentionElement{AtUsers: userIDs, AtAll: atAll}},
+	}
+}
+
+func TestGroupMentions(t *testing.T) {
+	ctx := context.Background()
+	gt := newGroupTest(t)
+	s, group := gt.s, gt.group
+
+	// 普通成员不能@所有人，被@的用户必须是群成员
+	if _, xe := s.SendMessage(ctx, groupRequest("bob", group.GroupId, mentionElement(true))); xe == nil || xe.Code() != xerr.ErrGroupPermissionDenied.Code() {
+		t.Fatalf("expected ErrGroupPermissionDenied, got %v", xe)
+	}
+	if _, xe := s.SendMessage(ctx, groupRequest("alice", group.GroupId, mentionElement(false, "eve"))); xe == nil || xe.Code() != xerr.ErrMessageInvalid.Code() {
+		t.Fatalf("expected ErrMessageInvalid, got %v", xe)
+	}
+
+	result, xe := s.SendMessage(ctx, groupRequest("alice", group.GroupId, mentionElement(false, "bob", "dave")))
+	if xe != nil {
+		t.Fatalf("SendMessage failed: %v", xe)
+	}
+
+	// 被@的 bob、dave 额外收到一条@提醒，@提醒不带收件箱序号
+	reqs := gt.pushCli.batchReqs
+	if len(reqs) != 2 || len(reqs[1].Targets) != 2 {
+		t.Fatalf("unexpected batch pushes: %v", reqs)
+	}
+	if reqs[0].Priority || !reqs[1].Priority {
+		t.Fatalf("only the mention push should be priority: %v %v", reqs[0].Priority, reqs[1].Priority)
+	}
+	for _, target := range reqs[1].Targets {
+		if len(target.Msg) != 0 || (target.UserId != "bob" && target.UserId != "dave") {
+			t.Fatalf("unexpected mention target: %v", target)
+		}
+	}
+	var mention messagepb.Message
+	_ = proto.Unmarshal(reqs[1].Msg, &mention)
+	if mention.MsgType != messagepb.MessageType_MESSAGE_TYPE_MENTION || mention.Seq != result.Seq {
+		t.Fatalf("unexpected mention: %v", &mention)
+	}
+
+	// 被@的用户会话记录@提醒位置，收件箱只有消息本身
+	for userID, want := range map[string]int64{"bob": result.Seq, "carol": 0, "dave": result.Seq} {
+		convs, _, _ := gt.conv.List(ctx, userID, 0, 10)
+		if len(convs) != 1 || convs[0].MentionSeq != want {
+			t.Fatalf("unexpected conversation of %s: %v", userID, convs)
+		}
+	}
+	msgs, _, _ := gt.inbox.Fetch(ctx, "dave", 0, 10)
+	if len(msgs) != 1 || msgs[0].MsgType != messagepb.MessageType_MESSAGE_TYPE_GROUP_CHAT {
+		t.Fatalf("unexpected inbox of dave: %v", msgs)
+	}
+}
+
+func TestGroupMentionAll(t *testing.T) {
+	ctx := context.Background()
+	gt := newGroupTest(t)
+	s, group := gt.s, gt.group
+
+	result, xe := s.SendMessage(ctx, groupRequest("alice", group.GroupId, mentionElement(true)))
+	if xe != nil {
+		t.Fatalf("SendMessage failed: %v", xe)
+	}
+
+	// @所有人不再单独扩散@提醒，群消息本身标记 at_all 并作为高优先级推送
+	reqs := gt.pushCli.batchReqs
+	if len(reqs) != 1 || len(reqs[0].Targets) != 3 || !reqs[0].Priority {
+		t.Fatalf("unexpected batch pushes: %v", reqs)
+	}
+	for _, target := range reqs[0].Targets {
+		var pushed messagepb.Message
+		if err := proto.Unmarshal(target.Msg, &pushed); err != nil || !pushed.AtAll || pushed.MsgType != messagepb.MessageType_MESSAGE_TYPE_GROUP_CHAT {
+			t.Fatalf("unexpected push to %s: %v %v", target.UserId, &pushed, err)
+		}
+	}
+
+	// 所有成员的会话都记录@提醒位置
+	for _, userID := range []string{"bob", "carol", "dave"} {
+		convs, _, _ := gt.conv.List(ctx, userID, 0, 10)
+		if len(convs) != 1 || convs[0].MentionSeq != result.Seq {
+			t.Fatalf("unexpected conversation of %s: %v", userID, convs)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	grouppb "github.com/wsx864321/kim/idl/group"
+	"github.com/wsx864321/kim/internal/message/infra/store"
+	"github.com/wsx864321/kim/internal/message/pkg/id"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+)
+
+// GroupService 群组业务逻辑服务
+type GroupService struct {
+	groupStore store.GroupStore
+	idGen      *id.Generator
+	maxMembers int
+}
+
+// NewGroupService 创建 GroupService 实例，maxMembers 为群成员数量上限
+func NewGroupService(groupStore store.GroupStore, idGen *id.Generator, maxMembers int) *GroupService {
+	return &GroupService{
+		groupStore: groupStore,
+		idGen:      idGen,
+		maxMembers: maxMembers,
+	}
+}
+
+// CreateGroup 创建群组，操作者为群主
+func (s *GroupService) CreateGroup(ctx context.Context, req *grouppb.CreateGroupReq) (*grouppb.Group, *xerr.Error) {
+	now := time.Now().Unix()
+	members := []*grouppb.GroupMember{{
+		UserId:   req.OperatorId,
+		Role:     grouppb.MemberRole_MEMBER_ROLE_OWNER,
+		JoinedAt: now,
+	}}
+	seen := map[string]bool{req.OperatorId: true}
+	for _, userID := range req.MemberIds {
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		members = append(members, &grouppb.GroupMember{
+			UserId:   userID,
+			Role:     grouppb.MemberRole_MEMBER_ROLE_MEMBER,
+			JoinedAt: now,
+		})
+	}
+	if len(members) > s.maxMembers {
+		return nil, xerr.ErrGroupFull.WithMessage(fmt.Sprintf("too many members: %d > %d", len(members), s.maxMembers))
+	}
+
+	group := &grouppb.Group{
+		GroupId:   s.idGen.NextID(),
+		Name:      req.Name,
+		OwnerId:   req.OperatorId,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.groupStore.CreateGroup(ctx, group, members); err != nil {
+		log.Error(ctx, "create group failed",
+			log.String("operator_id", req.OperatorId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+
+	group.MemberCount = int32(len(members))
+	return group, nil
+}
+
+// GetGroup 获取群组信息
+func (s *GroupService) GetGroup(ctx context.Context, groupID int64) (*grouppb.Group, *xerr.Error) {
+	group, err := s.groupStore.GetGroup(ctx, groupID)
+	if err != nil {
+		return nil, s.convertError(ctx, groupID, err)
+	}
+	return group, nil
+}
+
+// UpdateGroup 修改群组信息，群主和管理员可以操作
+func (s *GroupService) UpdateGroup(ctx context.Context, req *grouppb.UpdateGroupReq) (*grouppb.Group, *xerr.Error) {
+	if _, xe := s.checkRole(ctx, req.GroupId, req.OperatorId, grouppb.MemberRole_MEMBER_ROLE_ADMIN); xe != nil {
+		return nil, xe
+	}
+
+	group, err := s.groupStore.GetGroup(ctx, req.GroupId)
+	if err != nil {
+		return nil, s.convertError(ctx, req.GroupId, err)
+	}
+	group.Name = req.Name
+	group.UpdatedAt = time.Now().Unix()
+
+	if err := s.groupStore.UpdateGroup(ctx, group); err != nil {
+		return nil, s.convertError(ctx, req.GroupId, err)
+	}
+	return group, nil
+}
+
+// DismissGroup 解散群组，只有群主可以操作
+func (s *GroupService) DismissGroup(ctx context.Context, req *grouppb.DismissGroupReq) *xerr.Error {
+	if _, xe := s.checkRole(ctx, req.GroupId, req.OperatorId, grouppb.MemberRole_MEMBER_ROLE_OWNER); xe != nil {
+		return xe
+	}
+
+	if err := s.groupStore.DeleteGroup(ctx, req.GroupId); err != nil {
+		return s.convertError(ctx, req.GroupId, err)
+	}
+	return nil
+}
+
+// AddMembers 添加群成员，群成员均可邀请，已是成员的用户忽略
+func (s *GroupService) AddMembers(ctx context.Context, req *grouppb.AddMembersReq) *xerr.Error {
+	if _, xe := s.checkRole(ctx, req.GroupId, req.OperatorId, grouppb.MemberRole_MEMBER_ROLE_MEMBER); xe != nil {
+		return xe
+	}
+
+	existing, err := s.groupStore.GetMembers(ctx, req.GroupId)
+	if err != nil {
+		return s.convertError(ctx, req.GroupId, err)
+	}
+	seen := make(map[string]bool, len(existing))
+	for _, member := range existing {
+		seen[member.UserId] = true
+	}
+
+	now := time.Now().Unix()
+	members := make([]*grouppb.GroupMember, 0, len(req.UserIds))
+	for _, userID := range req.UserIds {
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		members = append(members, &grouppb.GroupMember{
+			UserId:   userID,
+			Role:     grouppb.MemberRole_MEMBER_ROLE_MEMBER,
+			JoinedAt: now,
+		})
+	}
+	if len(existing)+len(members) > s.maxMembers {
+		return xerr.ErrGroupFull
+	}
+
+	if err := s.groupStore.AddMembers(ctx, req.GroupId, members); err != nil {
+		return s.convertError(ctx, req.GroupId, err)
+	}
+	return nil
+}
+
+// RemoveMembers 移除群成员，群主和管理员可以移除普通成员（群主可以移除管理员），成员可以移除自己（退群），群主不能退群
+func (s *GroupService) RemoveMembers(ctx context.Context, req *grouppb.RemoveMembersReq) *xerr.Error {
+	operator, xe := s.checkRole(ctx, req.GroupId, req.OperatorId, grouppb.MemberRole_MEMBER_ROLE_MEMBER)
+	if xe != nil {
+		return xe
+	}
+
+	for _, userID := range req.UserIds {
+		member, err := s.groupStore.GetMember(ctx, req.GroupId, userID)
+		if err != nil {
+			if errors.Is(err, store.ErrMemberNotFound) {
+				continue
+			}
+			return s.convertError(ctx, req.GroupId, err)
+		}
+		if member.Role == grouppb.MemberRole_MEMBER_ROLE_OWNER {
+			return xerr.ErrGroupPermissionDenied.WithMessage("owner cannot be removed, dismiss the group instead")
+		}
+		if userID != req.OperatorId && operator.Role <= member.Role {
+			return xerr.ErrGroupPermissionDenied
+		}
+	}
+
+	if err := s.groupStore.RemoveMembers(ctx, req.GroupId, req.UserIds); err != nil {
+		return s.convertError(ctx, req.GroupId, err)
+	}
+	return nil
+}
+
+// GetMembers 获取群成员列表
+func (s *GroupService) GetMembers(ctx context.Context, groupID int64) ([]*grouppb.GroupMember, *xerr.Error) {
+	if _, err := s.groupStore.GetGroup(ctx, groupID); err != nil {
+		return nil, s.convertError(ctx, groupID, err)
+	}
+	members, err := s.groupStore.GetMembers(ctx, groupID)
+	if err != nil {
+		return nil, s.convertError(ctx, groupID, err)
+	}
+	return members, nil
+}
+
+// SetMemberRole 设置成员角色，只有群主可以操作，只能设置为普通成员或管理员
+func (s *GroupService) SetMemberRole(ctx context.Context, req *grouppb.SetMemberRoleReq) *xerr.Error {
+	if req.Role != grouppb.MemberRole_MEMBER_ROLE_MEMBER && req.Role != grouppb.MemberRole_MEMBER_ROLE_ADMIN {
+		return xerr.ErrInvalidParams.WithMessage("role must be member or admin")
+	}
+	if _, xe := s.checkRole(ctx, req.GroupId, req.OperatorId, grouppb.MemberRole_MEMBER_ROLE_OWNER); xe != nil {
+		return xe
+	}
+
+	member, err := s.groupStore.GetMember(ctx, req.GroupId, req.UserId)
+	if err != nil {
+		return s.convertError(ctx, req.GroupId, err)
+	}
+	if member.Role == grouppb.MemberRole_MEMBER_ROLE_OWNER {
+		return xerr.ErrGroupPermissionDenied.WithMessage("cannot change owner role")
+	}
+	member.Role = req.Role
+
+	if err := s.groupStore.AddMembers(ctx, req.GroupId, []*grouppb.GroupMember{member}); err != nil {
+		return s.convertError(ctx, req.GroupId, err)
+	}
+	return nil
+}
+
+// checkRole 检查操作者是群成员且角色不低于 minRole
+func (s *GroupService) checkRole(ctx context.Context, groupID int64, userID string, minRole grouppb.MemberRole) (*grouppb.GroupMember, *xerr.Error) {
+	if _, err := s.groupStore.GetGroup(ctx, groupID); err != nil {
+		return nil, s.convertError(ctx, groupID, err)
+	}
+	member, err := s.groupStore.GetMember(ctx, groupID, userID)
+	if err != nil {
+		return nil, s.convertError(ctx, groupID, err)
+	}
+	if member.Role < minRole {
+		return nil, xerr.ErrGroupPermissionDenied
+	}
+	return member, nil
+}
+
+// convertError 将存储错误转换为错误码
+func (s *GroupService) convertError(ctx context.Context, groupID int64, err error) *xerr.Error {
+	switch {
+	case errors.Is(err, store.ErrGroupNotFound):
+		return xerr.ErrGroupNotFound
+	case errors.Is(err, store.ErrMemberNotFound):
+		return xerr.ErrGroupNotMember
+	default:
+		log.Error(ctx, "group store failed",
+			log.Int64("group_id", groupID),
+			log.String("error", err.Error()),
+		)
+		return xerr.ErrInternalServer
+	}
+}
@@ -0,0 +1,113 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	grouppb "github.com/wsx864321/kim/idl/group"
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/pkg/xerr"
+)
+
+// mentions 收集消息@的用户（MentionElement 和 Message.at_users）以及是否@所有人
+func mentions(msg *messagepb.Message) ([]string, bool) {
+	atAll := msg.AtAll
+	seen := make(map[string]bool)
+	var userIDs []string
+	add := func(userID string) {
+		if userID == "" || seen[userID] {
+			return
+		}
+		seen[userID] = true
+		userIDs = append(userIDs, userID)
+	}
+
+	for _, elem := range msg.MsgBody {
+		mention := elem.GetMention()
+		if mention == nil {
+			continue
+		}
+		atAll = atAll || mention.AtAll
+		for _, userID := range mention.AtUsers {
+			add(userID)
+		}
+	}
+	for _, userID := range msg.AtUsers {
+		add(strconv.FormatInt(userID, 10))
+	}
+	return userIDs, atAll
+}
+
+// isMentioned 消息是否@了用户（包括@所有人）
+func isMentioned(msg *messagepb.Message, userID string) bool {
+	userIDs, atAll := mentions(msg)
+	if atAll {
+		return true
+	}
+	for _, id := range userIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// isPriority 是否高优先级推送：@提醒和@所有人的群消息在会话免打扰时也推送
+func isPriority(msg *messagepb.Message) bool {
+	return msg.MsgType == messagepb.MessageType_MESSAGE_TYPE_MENTION ||
+		(msg.MsgType == messagepb.MessageType_MESSAGE_TYPE_GROUP_CHAT && msg.AtAll)
+}
+
+// checkGroupMessage 检查群消息：发送者和被@的用户都是群成员，@所有人只允许群主和管理员；
+// 消息内容中的@所有人记录到 msg.at_all，扩散时整条群消息作为高优先级推送
+func (s *MessageService) checkGroupMessage(ctx context.Context, msg *messagepb.Message) *xerr.Error {
+	sender, err := s.groupMember(ctx, msg.GroupId, msg.SenderId)
+	if err != nil {
+		return err
+	}
+
+	userIDs, atAll := mentions(msg)
+	if atAll && sender.Role != grouppb.MemberRole_MEMBER_ROLE_OWNER && sender.Role != grouppb.MemberRole_MEMBER_ROLE_ADMIN {
+		return xerr.ErrGroupPermissionDenied.WithMessage("only owner and admins can mention all")
+	}
+	for _, userID := range userIDs {
+		if _, err := s.groupMember(ctx, msg.GroupId, userID); err != nil {
+			if err.Code() == xerr.ErrGroupNotMember.Code() {
+				return xerr.ErrMessageInvalid.WithMessage(fmt.Sprintf("mentioned user %s is not a group member", userID))
+			}
+			return err
+		}
+	}
+	msg.AtAll = atAll
+	return nil
+}
+
+// notifyMentions 向被@的成员（发送者除外）推送高优先级的@提醒，会话免打扰时也推送；
+// @所有人时群消息本身已经是高优先级推送，不再重复扩散@提醒
+func (s *MessageService) notifyMentions(ctx context.Context, msg *messagepb.Message) {
+	userIDs, atAll := mentions(msg)
+	if atAll {
+		return
+	}
+
+	receivers := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userID != msg.SenderId {
+			receivers = append(receivers, userID)
+		}
+	}
+	if len(receivers) == 0 {
+		return
+	}
+
+	s.fanout.Dispatch(ctx, &messagepb.Message{
+		SenderId:       msg.SenderId,
+		GroupId:        msg.GroupId,
+		ConversationId: msg.ConversationId,
+		MsgType:        messagepb.MessageType_MESSAGE_TYPE_MENTION,
+		MsgId:          msg.MsgId,
+		ServerTs:       msg.ServerTs,
+		Seq:            msg.Seq,
+	}, receivers)
+}
@@ -0,0 +1,400 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	grouppb "github.com/wsx864321/kim/idl/group"
+	messagepb "github.com/wsx864321/kim/idl/message"
+	pushpb "github.com/wsx864321/kim/idl/push"
+	"github.com/wsx864321/kim/internal/message/infra/grpc/push"
+	"github.com/wsx864321/kim/internal/message/infra/moderation"
+	"github.com/wsx864321/kim/internal/message/infra/store"
+	"github.com/wsx864321/kim/internal/message/pkg/id"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/protobuf/proto"
+)
+
+// MessageService Message 业务逻辑服务
+type MessageService struct {
+	pushClient    push.ClientInterface
+	sequencer     store.Sequencer
+	msgStore      store.MessageStore
+	deduper       store.Deduper
+	inbox         store.Inbox
+	readStore     store.ReadStore
+	tombstones    store.Tombstones
+	convStore     store.ConversationStore
+	groupStore    store.GroupStore
+	fanout        *Fanout
+	interceptors  []moderation.Interceptor
+	idGen         *id.Generator
+	maxElements   int
+	maxTextLength int
+	maxSyncLimit  int
+	recallWindow  time.Duration
+}
+
+// Option MessageService 配置选项
+type Option func(*MessageService)
+
+// WithMaxElements 设置单条消息最多包含的内容元素数量
+func WithMaxElements(n int) Option {
+	return func(s *MessageService) {
+		s.maxElements = n
+	}
+}
+
+// WithMaxTextLength 设置文本元素的最大长度（字符数）
+func WithMaxTextLength(n int) Option {
+	return func(s *MessageService) {
+		s.maxTextLength = n
+	}
+}
+
+// WithMaxSyncLimit 设置单次同步最多返回的消息条数
+func WithMaxSyncLimit(n int) Option {
+	return func(s *MessageService) {
+		s.maxSyncLimit = n
+	}
+}
+
+// WithRecallWindow 设置消息发出后允许撤回的时间窗口
+func WithRecallWindow(d time.Duration) Option {
+	return func(s *MessageService) {
+		s.recallWindow = d
+	}
+}
+
+// WithGroup 开启群聊消息，群消息通过 fanout 扩散给群成员
+func WithGroup(groupStore store.GroupStore, fanout *Fanout) Option {
+	return func(s *MessageService) {
+		s.groupStore = groupStore
+		s.fanout = fanout
+	}
+}
+
+// WithModeration 设置内容审核拦截器，消息投递前按顺序审核每个内容元素
+func WithModeration(interceptors ...moderation.Interceptor) Option {
+	return func(s *MessageService) {
+		s.interceptors = append(s.interceptors, interceptors...)
+	}
+}
+
+// NewMessageService 创建 MessageService 实例
+func NewMessageService(pushClient push.ClientInterface, sequencer store.Sequencer, msgStore store.MessageStore, deduper store.Deduper, inbox store.Inbox, readStore store.ReadStore, tombstones store.Tombstones, convStore store.ConversationStore, idGen *id.Generator, opts ...Option) *MessageService {
+	s := &MessageService{
+		pushClient:    pushClient,
+		sequencer:     sequencer,
+		msgStore:      msgStore,
+		deduper:       deduper,
+		inbox:         inbox,
+		readStore:     readStore,
+		tombstones:    tombstones,
+		convStore:     convStore,
+		idGen:         idGen,
+		maxElements:   20,
+		maxTextLength: 5000,
+		maxSyncLimit:  200,
+		recallWindow:  2 * time.Minute,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// SendMessage 发送消息：解析校验并审核消息、分配服务端消息ID和会话序号并保存，写入接收方收件箱后通过 Push 服务投递，
+// 同时同步到发送者的其他设备
+func (s *MessageService) SendMessage(ctx context.Context, req *messagepb.UpstreamRequest) (*messagepb.UpstreamResult, *xerr.Error) {
+	msg := &messagepb.Message{}
+	if err := proto.Unmarshal(req.Payload, msg); err != nil {
+		log.Warn(ctx, "unmarshal message failed",
+			log.String("sender_id", req.SenderId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrMessageInvalid.WithMessage("invalid payload")
+	}
+
+	// 消息类型以请求为准，发送者以网关填充的为准
+	if msg.MsgType == messagepb.MessageType_MESSAGE_TYPE_UNkNOW {
+		msg.MsgType = req.MsgType
+	}
+	if msg.MsgType != req.MsgType {
+		return nil, xerr.ErrMessageInvalid.WithMessage("msg_type mismatch")
+	}
+	msg.SenderId = req.SenderId
+
+	if err := s.validateMessage(msg); err != nil {
+		log.Warn(ctx, "invalid message",
+			log.String("sender_id", req.SenderId),
+			log.Int64("cli_msg_id", msg.CliMsgId),
+			log.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	// 已读回执、撤回、删除只修改已有消息的状态并通知相关用户，不分配会话序号
+	switch msg.MsgType {
+	case messagepb.MessageType_MESSAGE_TYPE_READ_RECEIPT:
+		return s.markRead(ctx, msg, req.ConnId)
+	case messagepb.MessageType_MESSAGE_TYPE_RECALL:
+		return s.recall(ctx, msg, req.ConnId)
+	case messagepb.MessageType_MESSAGE_TYPE_DELETE:
+		return s.delete(ctx, msg, req.ConnId)
+	}
+
+	if msg.MsgType == messagepb.MessageType_MESSAGE_TYPE_GROUP_CHAT {
+		if err := s.checkGroupMessage(ctx, msg); err != nil {
+			return nil, err
+		}
+	}
+
+	// 客户端重试的消息直接返回首次受理的结果
+	if msg.CliMsgId != 0 {
+		result, acquired, err := s.deduper.Acquire(ctx, msg.SenderId, msg.CliMsgId)
+		if err != nil {
+			log.Error(ctx, "dedup acquire failed",
+				log.String("sender_id", msg.SenderId),
+				log.Int64("cli_msg_id", msg.CliMsgId),
+				log.String("error", err.Error()),
+			)
+			return nil, xerr.ErrInternalServer
+		}
+		if !acquired {
+			if result == nil {
+				return nil, xerr.ErrMessageInProgress
+			}
+			log.Info(ctx, "duplicate message",
+				log.String("sender_id", msg.SenderId),
+				log.Int64("cli_msg_id", msg.CliMsgId),
+				log.Int64("msg_id", result.MsgId),
+			)
+			return result, nil
+		}
+	}
+
+	// 审核拒绝的消息不保存，客户端修改后可以使用相同的 cli_msg_id 重新发送
+	xe := s.moderate(ctx, msg)
+	var result *messagepb.UpstreamResult
+	if xe == nil {
+		result, xe = s.accept(ctx, msg)
+	}
+	if xe != nil {
+		// 失败时消息还没有保存，释放去重键允许客户端重试
+		if msg.CliMsgId != 0 {
+			if err := s.deduper.Release(ctx, msg.SenderId, msg.CliMsgId); err != nil {
+				log.Warn(ctx, "dedup release failed", log.String("error", err.Error()))
+			}
+		}
+		return nil, xe
+	}
+	if msg.CliMsgId != 0 {
+		if err := s.deduper.Commit(ctx, msg.SenderId, msg.CliMsgId, result); err != nil {
+			log.Warn(ctx, "dedup commit failed", log.String("error", err.Error()))
+		}
+	}
+
+	// 消息已保存即视为发送成功，投递失败时接收方上线后通过同步获取
+	if msg.MsgType == messagepb.MessageType_MESSAGE_TYPE_GROUP_CHAT {
+		s.deliverGroup(ctx, msg)
+	} else {
+		s.deliver(ctx, msg.ReceiverId, msg, 0)
+	}
+	// 多端同步：写入发送者收件箱并推送给发送者的其他在线设备，会话序号与接收方一致
+	s.notify(ctx, msg.SenderId, msg, req.ConnId)
+
+	return result, nil
+}
+
+// accept 受理消息：分配服务端消息ID、会话序号并保存，更新发送方的会话列表；单聊同时写入接收方收件箱
+// （msg.UserSeq 为接收方收件箱序号）并更新接收方的未读数和会话列表，群聊在扩散时处理群成员。
+// 只有消息保存之前的步骤会返回失败，消息保存后即视为受理成功
+func (s *MessageService) accept(ctx context.Context, msg *messagepb.Message) (*messagepb.UpstreamResult, *xerr.Error) {
+	conversationID, xe := s.conversationID(ctx, msg)
+	if xe != nil {
+		return nil, xe
+	}
+	msg.ConversationId = conversationID
+	msg.MsgId = s.idGen.NextID()
+	msg.ServerTs = time.Now().UnixMilli()
+
+	seq, err := s.sequencer.NextSeq(ctx, msg.ConversationId)
+	if err != nil {
+		log.Error(ctx, "allocate seq failed",
+			log.Int64("conversation_id", msg.ConversationId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+	msg.Seq = seq
+
+	if err := s.msgStore.SaveMessage(ctx, msg); err != nil {
+		log.Error(ctx, "save message failed",
+			log.Int64("conversation_id", msg.ConversationId),
+			log.Int64("seq", msg.Seq),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+
+	if msg.MsgType == messagepb.MessageType_MESSAGE_TYPE_CHAT {
+		// 消息已经保存，收件箱写入失败不能返回失败，否则客户端重试会生成重复的消息；
+		// 接收方仍然收到不带收件箱序号的推送，并可以从会话历史中获取该消息
+		userSeq, err := s.inbox.Append(ctx, msg.ReceiverId, msg)
+		if err != nil {
+			log.Error(ctx, "append inbox failed",
+				log.String("user_id", msg.ReceiverId),
+				log.Int64("msg_id", msg.MsgId),
+				log.String("error", err.Error()),
+			)
+		}
+		msg.UserSeq = userSeq
+		s.incrUnread(ctx, msg.ReceiverId, msg.ConversationId)
+		updateConversation(ctx, s.convStore, msg.ReceiverId, msg)
+	}
+	updateConversation(ctx, s.convStore, msg.SenderId, msg)
+
+	return &messagepb.UpstreamResult{
+		MsgId:          msg.MsgId,
+		ConversationId: msg.ConversationId,
+		Seq:            msg.Seq,
+	}, nil
+}
+
+// deliver 通过 Push 服务将消息推送给用户的在线设备，excludeConnID 不为 0 时跳过该连接
+func (s *MessageService) deliver(ctx context.Context, userID string, msg *messagepb.Message, excludeConnID uint64) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		log.Error(ctx, "marshal message failed", log.String("error", err.Error()))
+		return
+	}
+
+	resp, err := s.pushClient.PushMsg(ctx, &pushpb.PushReq{
+		UserId:        userID,
+		Msg:           data,
+		ExcludeConnId: excludeConnID,
+	})
+	if err != nil {
+		log.Error(ctx, "push message failed",
+			log.String("user_id", userID),
+			log.Int64("msg_id", msg.MsgId),
+			log.String("error", err.Error()),
+		)
+		return
+	}
+
+	switch resp.Code {
+	case xerr.OK.Code():
+	case xerr.ErrSessionNotFound.Code():
+		// 接收方不在线，上线后从收件箱同步
+		log.Info(ctx, "receiver is offline",
+			log.String("user_id", userID),
+			log.Int64("msg_id", msg.MsgId),
+		)
+	default:
+		log.Warn(ctx, "push message failed",
+			log.String("user_id", userID),
+			log.Int64("msg_id", msg.MsgId),
+			log.Int("code", int(resp.Code)),
+			log.String("message", resp.Message),
+		)
+	}
+}
+
+// incrUnread 接收方会话未读数加一，失败只记录日志
+func (s *MessageService) incrUnread(ctx context.Context, userID string, conversationID int64) {
+	if err := s.readStore.IncrUnread(ctx, userID, conversationID); err != nil {
+		log.Warn(ctx, "incr unread failed",
+			log.String("user_id", userID),
+			log.Int64("conversation_id", conversationID),
+			log.String("error", err.Error()),
+		)
+	}
+}
+
+// checkGroupSender 检查发送者是群成员
+func (s *MessageService) checkGroupSender(ctx context.Context, msg *messagepb.Message) *xerr.Error {
+	_, err := s.groupMember(ctx, msg.GroupId, msg.SenderId)
+	return err
+}
+
+// groupMember 获取群成员，不是成员时返回 ErrGroupNotMember
+func (s *MessageService) groupMember(ctx context.Context, groupID int64, userID string) (*grouppb.GroupMember, *xerr.Error) {
+	member, err := s.groupStore.GetMember(ctx, groupID, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrMemberNotFound) {
+			return nil, xerr.ErrGroupNotMember
+		}
+		log.Error(ctx, "get group member failed",
+			log.Int64("group_id", groupID),
+			log.String("user_id", userID),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+	return member, nil
+}
+
+// deliverGroup 将群消息扩散给发送者以外的群成员，并向被@的成员推送@提醒
+func (s *MessageService) deliverGroup(ctx context.Context, msg *messagepb.Message) {
+	members, err := s.groupStore.GetMembers(ctx, msg.GroupId)
+	if err != nil {
+		log.Error(ctx, "get group members failed",
+			log.Int64("group_id", msg.GroupId),
+			log.Int64("msg_id", msg.MsgId),
+			log.String("error", err.Error()),
+		)
+		return
+	}
+
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		if member.UserId != msg.SenderId {
+			userIDs = append(userIDs, member.UserId)
+		}
+	}
+	s.fanout.Dispatch(ctx, msg, userIDs)
+
+	if msg.MsgType == messagepb.MessageType_MESSAGE_TYPE_GROUP_CHAT {
+		s.notifyMentions(ctx, msg)
+	}
+}
+
+// SyncMessages 同步用户收件箱中序号大于 since_seq 的消息
+func (s *MessageService) SyncMessages(ctx context.Context, req *messagepb.SyncMessagesReq) (*messagepb.SyncMessagesData, *xerr.Error) {
+	limit := int(req.Limit)
+	if limit <= 0 || limit > s.maxSyncLimit {
+		limit = s.maxSyncLimit
+	}
+
+	msgs, maxSeq, err := s.inbox.Fetch(ctx, req.UserId, req.SinceSeq, limit)
+	if err != nil {
+		log.Error(ctx, "fetch inbox failed",
+			log.String("user_id", req.UserId),
+			log.String("device_id", req.DeviceId),
+			log.Int64("since_seq", req.SinceSeq),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+
+	if err := s.applyTombstones(ctx, req.UserId, msgs); err != nil {
+		log.Error(ctx, "filter tombstones failed",
+			log.String("user_id", req.UserId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+
+	data := &messagepb.SyncMessagesData{
+		Messages: msgs,
+		MaxSeq:   maxSeq,
+	}
+	if len(msgs) > 0 {
+		data.HasMore = msgs[len(msgs)-1].UserSeq < maxSeq
+	}
+	return data, nil
+}
@@ -0,0 +1,461 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	pushpb "github.com/wsx864321/kim/idl/push"
+	"github.com/wsx864321/kim/internal/message/infra/store"
+	"github.com/wsx864321/kim/internal/message/pkg/id"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakePushClient struct {
+	reqs []*pushpb.PushReq
+
+	mu        sync.Mutex
+	batchReqs []*pushpb.BatchPushReq
+}
+
+func (f *fakePushClient) PushMsg(ctx context.Context, in *pushpb.PushReq) (*pushpb.PushResp, error) {
+	f.reqs = append(f.reqs, in)
+	return &pushpb.PushResp{Code: xerr.OK.Code()}, nil
+}
+
+func (f *fakePushClient) BatchPushMsg(ctx context.Context, in *pushpb.BatchPushReq) (*pushpb.BatchPushResp, error) {
+	// 与 gRPC 客户端一致先序列化请求，消息内容无法编码时调用失败
+	if _, err := proto.Marshal(in); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.batchReqs = append(f.batchReqs, in)
+
+	results := make([]*pushpb.PushResult, 0, len(in.Targets))
+	for _, target := range in.Targets {
+		results = append(results, &pushpb.PushResult{UserId: target.UserId, Code: xerr.OK.Code()})
+	}
+	return &pushpb.BatchPushResp{Code: xerr.OK.Code(), Results: results}, nil
+}
+
+func newTestService() (*MessageService, *fakePushClient, store.MessageStore) {
+	inbox := store.NewMemoryInbox(100)
+	pushCli := &fakePushClient{}
+	msgStore := store.NewMemoryMessageStore(100)
+	deduper := store.NewMemoryDeduper(time.Minute)
+	return NewMessageService(pushCli, store.NewMemorySequencer(), msgStore, deduper, inbox, store.NewMemoryReadStore(), store.NewMemoryTombstones(), store.NewMemoryConversationStore(100), id.NewGenerator(1)), pushCli, msgStore
+}
+
+func chatRequest(sender, receiver, text string) *messagepb.UpstreamRequest {
+	return chatRequestWithID(sender, receiver, text, 0)
+}
+
+func chatRequestWithID(sender, receiver, text string, cliMsgID int64) *messagepb.UpstreamRequest {
+	payload, _ := proto.Marshal(&messagepb.Message{
+		CliMsgId:   cliMsgID,
+		ReceiverId: receiver,
+		MsgBody: []*messagepb.MessageContent{{
+			ElementType: messagepb.MessageElementType_CONTENT_ELEMENT_TEXT,
+			Content:     &messagepb.MessageContent_Text{Text: &messagepb.TextElement{Text: text}},
+		}},
+	})
+	return &messagepb.UpstreamRequest{
+		MsgType:  messagepb.MessageType_MESSAGE_TYPE_CHAT,
+		Payload:  payload,
+		SenderId: sender,
+		ConnId:   1,
+	}
+}
+
+func TestSendMessageSeq(t *testing.T) {
+	s, pushCli, msgStore := newTestService()
+	ctx := context.Background()
+
+	var results []*messagepb.UpstreamResult
+	for _, req := range []*messagepb.UpstreamRequest{
+		chatRequest("alice", "bob", "1"),
+		chatRequest("bob", "alice", "2"),
+		chatRequest("alice", "bob", "3"),
+	} {
+		result, err := s.SendMessage(ctx, req)
+		if err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+		results = append(results, result)
+	}
+
+	for i, result := range results {
+		if result.ConversationId != results[0].ConversationId {
+			t.Fatalf("conversation id mismatch: got %d, want %d", result.ConversationId, results[0].ConversationId)
+		}
+		if result.Seq != int64(i+1) {
+			t.Fatalf("seq mismatch: got %d, want %d", result.Seq, i+1)
+		}
+	}
+
+	other, err := s.SendMessage(ctx, chatRequest("alice", "carol", "x"))
+	if err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	if other.ConversationId == results[0].ConversationId || other.Seq != 1 {
+		t.Fatalf("unexpected result for new conversation: %v", other)
+	}
+
+	msgs, _ := msgStore.GetMessages(ctx, results[0].ConversationId, 1, 10)
+	if len(msgs) != 2 || msgs[0].Seq != 2 || msgs[1].Seq != 3 {
+		t.Fatalf("unexpected stored messages: %v", msgs)
+	}
+	// 每条消息推送给接收方，并同步到发送者的其他设备
+	if len(pushCli.reqs) != 8 {
+		t.Fatalf("unexpected push requests: %v", pushCli.reqs)
+	}
+	toReceiver, toSender := pushCli.reqs[0], pushCli.reqs[1]
+	if toReceiver.UserId != "bob" || toReceiver.ExcludeConnId != 0 || toSender.UserId != "alice" || toSender.ExcludeConnId != 1 {
+		t.Fatalf("unexpected push requests: %v, %v", toReceiver, toSender)
+	}
+	var received, echoed messagepb.Message
+	_ = proto.Unmarshal(toReceiver.Msg, &received)
+	_ = proto.Unmarshal(toSender.Msg, &echoed)
+	if received.MsgId != echoed.MsgId || received.Seq != echoed.Seq {
+		t.Fatalf("echo mismatch: %v, %v", &received, &echoed)
+	}
+}
+
+func TestSendMessageInvalid(t *testing.T) {
+	s, _, _ := newTestService()
+
+	_, err := s.SendMessage(context.Background(), chatRequest("alice", "", "hi"))
+	if err == nil || err.Code() != xerr.ErrMessageInvalid.Code() {
+		t.Fatalf("expected invalid message error, got %v", err)
+	}
+}
+
+func TestSendMessageDedup(t *testing.T) {
+	s, pushCli, _ := newTestService()
+	ctx := context.Background()
+
+	first, err := s.SendMessage(ctx, chatRequestWithID("alice", "bob", "hi", 1001))
+	if err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	retry, err := s.SendMessage(ctx, chatRequestWithID("alice", "bob", "hi", 1001))
+	if err != nil {
+		t.Fatalf("SendMessage retry failed: %v", err)
+	}
+	if !proto.Equal(first, retry) {
+		t.Fatalf("retry result mismatch: got %v, want %v", retry, first)
+	}
+	if len(pushCli.reqs) != 2 {
+		t.Fatalf("retry should not be delivered again, got %d pushes", len(pushCli.reqs))
+	}
+
+	// 不同发送者的相同 cli_msg_id 不去重
+	other, err := s.SendMessage(ctx, chatRequestWithID("bob", "alice", "hi", 1001))
+	if err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	if other.MsgId == first.MsgId {
+		t.Fatalf("messages from different senders should not be deduplicated")
+	}
+}
+
+// failingInbox 写入指定用户的收件箱时失败
+type failingInbox struct {
+	store.Inbox
+	userID string
+}
+
+func (i *failingInbox) Append(ctx context.Context, userID string, msg *messagepb.Message) (int64, error) {
+	if userID == i.userID {
+		return 0, errors.New("inbox unavailable")
+	}
+	return i.Inbox.Append(ctx, userID, msg)
+}
+
+func TestSendMessageInboxFailure(t *testing.T) {
+	pushCli := &fakePushClient{}
+	msgStore := store.NewMemoryMessageStore(100)
+	inbox := &failingInbox{Inbox: store.NewMemoryInbox(100), userID: "bob"}
+	s := NewMessageService(pushCli, store.NewMemorySequencer(), msgStore, store.NewMemoryDeduper(time.Minute), inbox,
+		store.NewMemoryReadStore(), store.NewMemoryTombstones(), store.NewMemoryConversationStore(100), id.NewGenerator(1))
+	ctx := context.Background()
+
+	// 消息已保存后收件箱写入失败，仍然返回已分配的消息ID，重试不会生成重复消息
+	first, err := s.SendMessage(ctx, chatRequestWithID("alice", "bob", "hi", 1001))
+	if err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	retry, err := s.SendMessage(ctx, chatRequestWithID("alice", "bob", "hi", 1001))
+	if err != nil {
+		t.Fatalf("SendMessage retry failed: %v", err)
+	}
+	if !proto.Equal(first, retry) {
+		t.Fatalf("retry result mismatch: got %v, want %v", retry, first)
+	}
+	stored, _ := msgStore.GetMessages(ctx, first.ConversationId, 0, 10)
+	if len(stored) != 1 || stored[0].MsgId != first.MsgId {
+		t.Fatalf("expected one stored message, got %v", stored)
+	}
+	if len(pushCli.reqs) == 0 || pushCli.reqs[0].UserId != "bob" {
+		t.Fatalf("receiver should still be pushed: %v", pushCli.reqs)
+	}
+}
+
+func TestSyncMessages(t *testing.T) {
+	s, _, _ := newTestService()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		if _, err := s.SendMessage(ctx, chatRequest("alice", "bob", "hi")); err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+	}
+
+	data, err := s.SyncMessages(ctx, &messagepb.SyncMessagesReq{UserId: "bob", SinceSeq: 0, Limit: 3})
+	if err != nil {
+		t.Fatalf("SyncMessages failed: %v", err)
+	}
+	if len(data.Messages) != 3 || !data.HasMore || data.MaxSeq != 5 {
+		t.Fatalf("unexpected first page: %v", data)
+	}
+
+	last := data.Messages[len(data.Messages)-1].UserSeq
+	data, err = s.SyncMessages(ctx, &messagepb.SyncMessagesReq{UserId: "bob", SinceSeq: last, Limit: 3})
+	if err != nil {
+		t.Fatalf("SyncMessages failed: %v", err)
+	}
+	if len(data.Messages) != 2 || data.HasMore || data.Messages[1].UserSeq != 5 {
+		t.Fatalf("unexpected second page: %v", data)
+	}
+}
+
+func readReceipt(reader, peer string, readSeq int64) *messagepb.UpstreamRequest {
+	payload, _ := proto.Marshal(&messagepb.Message{
+		ReceiverId: peer,
+		ReadSeq:    readSeq,
+	})
+	return &messagepb.UpstreamRequest{
+		MsgType:  messagepb.MessageType_MESSAGE_TYPE_READ_RECEIPT,
+		Payload:  payload,
+		SenderId: reader,
+	}
+}
+
+func TestReadReceipt(t *testing.T) {
+	s, pushCli, _ := newTestService()
+	ctx := context.Background()
+
+	for _, req := range []*messagepb.UpstreamRequest{
+		chatRequest("alice", "bob", "1"),
+		chatRequest("alice", "bob", "2"),
+		chatRequest("bob", "alice", "3"),
+		chatRequest("alice", "bob", "4"),
+	} {
+		if _, err := s.SendMessage(ctx, req); err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+	}
+
+	unread, err := s.GetUnread(ctx, "bob")
+	if err != nil {
+		t.Fatalf("GetUnread failed: %v", err)
+	}
+	if unread.Total != 3 || len(unread.Conversations) != 1 {
+		t.Fatalf("unexpected unread: %v", unread)
+	}
+
+	// 读到 seq 3，之后只有 alice 的第 4 条未读
+	pushCli.reqs = nil
+	result, err := s.SendMessage(ctx, readReceipt("bob", "alice", 3))
+	if err != nil {
+		t.Fatalf("read receipt failed: %v", err)
+	}
+	if result.Seq != 3 || result.MsgId == 0 {
+		t.Fatalf("unexpected result: %v", result)
+	}
+	unread, _ = s.GetUnread(ctx, "bob")
+	if unread.Total != 1 || unread.Conversations[0].ReadSeq != 3 {
+		t.Fatalf("unexpected unread after read: %v", unread)
+	}
+
+	// 回执推送给对方和读者自己的设备
+	if len(pushCli.reqs) != 2 || pushCli.reqs[0].UserId != "alice" || pushCli.reqs[1].UserId != "bob" {
+		t.Fatalf("unexpected pushes: %v", pushCli.reqs)
+	}
+	msgs, _, _ := s.inbox.Fetch(ctx, "alice", 0, 10)
+	last := msgs[len(msgs)-1]
+	if last.MsgType != messagepb.MessageType_MESSAGE_TYPE_READ_RECEIPT || last.ReadSeq != 3 || last.SenderId != "bob" {
+		t.Fatalf("unexpected receipt in inbox: %v", last)
+	}
+
+	// 已读位置不后退
+	pushCli.reqs = nil
+	result, err = s.SendMessage(ctx, readReceipt("bob", "alice", 1))
+	if err != nil {
+		t.Fatalf("read receipt failed: %v", err)
+	}
+	if result.Seq != 3 || len(pushCli.reqs) != 0 {
+		t.Fatalf("stale receipt should be ignored: %v", result)
+	}
+
+	// 已读位置不超过会话最大序号
+	result, err = s.SendMessage(ctx, readReceipt("bob", "alice", 100))
+	if err != nil {
+		t.Fatalf("read receipt failed: %v", err)
+	}
+	if result.Seq != 4 {
+		t.Fatalf("read seq should be capped at 4, got %v", result)
+	}
+	unread, _ = s.GetUnread(ctx, "bob")
+	if unread.Total != 0 {
+		t.Fatalf("unexpected unread after read all: %v", unread)
+	}
+
+	// 没有会话的用户不能发送回执
+	pushCli.reqs = nil
+	if _, err := s.SendMessage(ctx, readReceipt("mallory", "alice", 1)); err == nil || err.Code() != xerr.ErrConversationNotFound.Code() {
+		t.Fatalf("expected ErrConversationNotFound, got %v", err)
+	}
+	if len(pushCli.reqs) != 0 {
+		t.Fatalf("receipt without conversation should not be pushed: %v", pushCli.reqs)
+	}
+	// 回执不分配单聊会话ID
+	if _, err := s.convStore.GetSingleID(ctx, "mallory", "alice"); !errors.Is(err, store.ErrConversationNotFound) {
+		t.Fatalf("receipt should not allocate conversation id, got %v", err)
+	}
+}
+
+func targetRequest(msgType messagepb.MessageType, sender, peer string, targetSeq int64) *messagepb.UpstreamRequest {
+	payload, _ := proto.Marshal(&messagepb.Message{
+		ReceiverId: peer,
+		TargetSeq:  targetSeq,
+	})
+	return &messagepb.UpstreamRequest{
+		MsgType:  msgType,
+		Payload:  payload,
+		SenderId: sender,
+	}
+}
+
+func TestRecallAndDelete(t *testing.T) {
+	s, _, msgStore := newTestService()
+	ctx := context.Background()
+
+	sent, err := s.SendMessage(ctx, chatRequest("alice", "bob", "hello"))
+	if err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+
+	// 删除只对删除者生效
+	if _, err := s.SendMessage(ctx, targetRequest(messagepb.MessageType_MESSAGE_TYPE_DELETE, "bob", "alice", sent.Seq)); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	data, _ := s.SyncMessages(ctx, &messagepb.SyncMessagesReq{UserId: "bob"})
+	if first := data.Messages[0]; !first.Deleted || len(first.MsgBody) != 0 {
+		t.Fatalf("message should be hidden for bob: %v", first)
+	}
+	if last := data.Messages[len(data.Messages)-1]; last.MsgType != messagepb.MessageType_MESSAGE_TYPE_DELETE || last.TargetSeq != sent.Seq {
+		t.Fatalf("delete notification not synced: %v", last)
+	}
+	stored, _ := msgStore.GetMessages(ctx, sent.ConversationId, 0, 10)
+	if len(stored[0].MsgBody) == 0 {
+		t.Fatal("delete should not touch the stored message")
+	}
+
+	// 只有发送者能撤回
+	if _, err := s.SendMessage(ctx, targetRequest(messagepb.MessageType_MESSAGE_TYPE_RECALL, "bob", "alice", sent.Seq)); err == nil || err.Code() != xerr.ErrMessageRecallDenied.Code() {
+		t.Fatalf("expected ErrMessageRecallDenied, got %v", err)
+	}
+	if _, err := s.SendMessage(ctx, targetRequest(messagepb.MessageType_MESSAGE_TYPE_RECALL, "alice", "bob", 100)); err == nil || err.Code() != xerr.ErrMessageNotFound.Code() {
+		t.Fatalf("expected ErrMessageNotFound, got %v", err)
+	}
+
+	// 没有会话的用户之间不能撤回、删除，也不分配单聊会话ID
+	for _, msgType := range []messagepb.MessageType{messagepb.MessageType_MESSAGE_TYPE_RECALL, messagepb.MessageType_MESSAGE_TYPE_DELETE} {
+		if _, err := s.SendMessage(ctx, targetRequest(msgType, "mallory", "alice", 1)); err == nil || err.Code() != xerr.ErrConversationNotFound.Code() {
+			t.Fatalf("%v: expected ErrConversationNotFound, got %v", msgType, err)
+		}
+	}
+	if _, err := s.convStore.GetSingleID(ctx, "mallory", "alice"); !errors.Is(err, store.ErrConversationNotFound) {
+		t.Fatalf("recall should not allocate conversation id, got %v", err)
+	}
+
+	if _, err := s.SendMessage(ctx, targetRequest(messagepb.MessageType_MESSAGE_TYPE_RECALL, "alice", "bob", sent.Seq)); err != nil {
+		t.Fatalf("recall failed: %v", err)
+	}
+	stored, _ = msgStore.GetMessages(ctx, sent.ConversationId, 0, 10)
+	if len(stored) != 1 || !stored[0].Recalled || len(stored[0].MsgBody) != 0 {
+		t.Fatalf("stored message should be a tombstone: %v", stored)
+	}
+	data, _ = s.SyncMessages(ctx, &messagepb.SyncMessagesReq{UserId: "bob"})
+	if first := data.Messages[0]; !first.Recalled {
+		t.Fatalf("inbox copy should be recalled: %v", first)
+	}
+	if last := data.Messages[len(data.Messages)-1]; last.MsgType != messagepb.MessageType_MESSAGE_TYPE_RECALL || last.SenderId != "alice" {
+		t.Fatalf("recall notification not synced: %v", last)
+	}
+
+	// 超过撤回窗口
+	sent, _ = s.SendMessage(ctx, chatRequest("alice", "bob", "late"))
+	s.recallWindow = -time.Second
+	if _, err := s.SendMessage(ctx, targetRequest(messagepb.MessageType_MESSAGE_TYPE_RECALL, "alice", "bob", sent.Seq)); err == nil || err.Code() != xerr.ErrMessageRecallExpired.Code() {
+		t.Fatalf("expected ErrMessageRecallExpired, got %v", err)
+	}
+}
+
+func TestListConversations(t *testing.T) {
+	s, _, _ := newTestService()
+	ctx := context.Background()
+
+	var results []*messagepb.UpstreamResult
+	for _, req := range []*messagepb.UpstreamRequest{
+		chatRequest("alice", "bob", "1"),
+		chatRequest("alice", "carol", "2"),
+		chatRequest("bob", "alice", "3"),
+	} {
+		result, err := s.SendMessage(ctx, req)
+		if err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+		results = append(results, result)
+		// 保证 updated_at 不同
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// 单聊会话ID与发送方向无关，不同的用户对分配不同的会话ID
+	bob, carol := results[0].ConversationId, results[1].ConversationId
+	if bob == 0 || results[2].ConversationId != bob || carol == bob {
+		t.Fatalf("unexpected conversation ids: %v", results)
+	}
+
+	data, err := s.ListConversations(ctx, &messagepb.ListConversationsReq{UserId: "alice", Limit: 1})
+	if err != nil {
+		t.Fatalf("ListConversations failed: %v", err)
+	}
+	if len(data.Conversations) != 1 || !data.HasMore {
+		t.Fatalf("unexpected page: %v", data)
+	}
+	first := data.Conversations[0]
+	if first.PeerId != "bob" || first.LastSeq != 2 || first.Unread != 1 || first.LastMessage.GetMsgBody()[0].GetText().GetText() != "3" {
+		t.Fatalf("unexpected conversation: %v", first)
+	}
+
+	// 置顶的会话排在最前
+	if err := s.SetConversationPinned(ctx, &messagepb.SetConversationPinnedReq{UserId: "alice", ConversationId: carol, Pinned: true}); err != nil {
+		t.Fatalf("SetConversationPinned failed: %v", err)
+	}
+	data, _ = s.ListConversations(ctx, &messagepb.ListConversationsReq{UserId: "alice"})
+	if len(data.Conversations) != 2 || data.HasMore || data.Conversations[0].ConversationId != carol || !data.Conversations[0].Pinned {
+		t.Fatalf("pinned conversation should be first: %v", data)
+	}
+
+	if err := s.SetConversationMuted(ctx, &messagepb.SetConversationMutedReq{UserId: "alice", ConversationId: 1, Muted: true}); err == nil || err.Code() != xerr.ErrConversationNotFound.Code() {
+		t.Fatalf("expected ErrConversationNotFound, got %v", err)
+	}
+}
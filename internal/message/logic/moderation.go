@@ -0,0 +1,58 @@
+package logic
+
+import (
+	"context"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/internal/message/infra/moderation"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+)
+
+// moderate 依次使用审核拦截器审核消息的每个内容元素：任一拦截器拒绝时消息不投递，
+// 改写的元素替换 msg.MsgBody 中的原元素并交给后续拦截器继续审核
+func (s *MessageService) moderate(ctx context.Context, msg *messagepb.Message) *xerr.Error {
+	for i := range msg.MsgBody {
+		for _, interceptor := range s.interceptors {
+			decision, err := interceptor.Intercept(ctx, msg, msg.MsgBody[i])
+			if err != nil {
+				log.Error(ctx, "moderate message failed",
+					log.String("interceptor", interceptor.Name()),
+					log.String("sender_id", msg.SenderId),
+					log.Int64("cli_msg_id", msg.CliMsgId),
+					log.String("error", err.Error()),
+				)
+				return xerr.ErrServiceUnavailable
+			}
+
+			switch decision.Action {
+			case moderation.ActionReject:
+				log.Info(ctx, "message rejected by moderation",
+					log.String("interceptor", interceptor.Name()),
+					log.String("sender_id", msg.SenderId),
+					log.Int64("cli_msg_id", msg.CliMsgId),
+					log.Int("element", i),
+				)
+				if decision.Err == nil {
+					return xerr.ErrMessageRejected
+				}
+				return decision.Err
+			case moderation.ActionRewrite:
+				if decision.Content == nil {
+					log.Error(ctx, "rewritten element is empty", log.String("interceptor", interceptor.Name()))
+					return xerr.ErrInternalServer
+				}
+				// 改写后的元素同样需要满足消息格式要求
+				if err := s.validateElement(msg, decision.Content); err != nil {
+					log.Error(ctx, "rewritten element is invalid",
+						log.String("interceptor", interceptor.Name()),
+						log.String("error", err.Error()),
+					)
+					return xerr.ErrInternalServer
+				}
+				msg.MsgBody[i] = decision.Content
+			}
+		}
+	}
+	return nil
+}
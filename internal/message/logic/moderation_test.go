@@ -0,0 +1,67 @@
+package logic
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/internal/message/infra/moderation"
+	"github.com/wsx864321/kim/pkg/xerr"
+)
+
+func TestModeration(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	blockFile, maskFile := filepath.Join(dir, "block.txt"), filepath.Join(dir, "mask.txt")
+	if err := os.WriteFile(blockFile, []byte("# 屏蔽词\nspam\nre:b[a4]d\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(maskFile, []byte("damn\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	filter, err := moderation.NewKeywordFilter(blockFile, maskFile)
+	if err != nil {
+		t.Fatalf("NewKeywordFilter failed: %v", err)
+	}
+
+	s, pushCli, _ := newTestService()
+	WithModeration(filter)(s)
+
+	// 命中屏蔽词（关键词忽略大小写、正则）的消息被拒绝，不投递
+	for _, text := range []string{"buy SPAM now", "so b4d"} {
+		if _, xe := s.SendMessage(ctx, chatRequest("alice", "bob", text)); xe == nil || xe.Code() != xerr.ErrMessageRejected.Code() {
+			t.Fatalf("%q: expected ErrMessageRejected, got %v", text, xe)
+		}
+	}
+	if len(pushCli.reqs) != 0 {
+		t.Fatalf("rejected message should not be pushed: %v", pushCli.reqs)
+	}
+
+	// 命中替换词的文本被改写后投递
+	if _, xe := s.SendMessage(ctx, chatRequest("alice", "bob", "Damn it")); xe != nil {
+		t.Fatalf("SendMessage failed: %v", xe)
+	}
+	data, xe := s.SyncMessages(ctx, &messagepb.SyncMessagesReq{UserId: "bob", Limit: 10})
+	if xe != nil {
+		t.Fatalf("SyncMessages failed: %v", xe)
+	}
+	if len(data.Messages) != 1 || data.Messages[0].MsgBody[0].GetText().Text != "**** it" {
+		t.Fatalf("unexpected messages: %v", data.Messages)
+	}
+
+	// 重新加载词表后生效
+	if err := os.WriteFile(blockFile, []byte("hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := filter.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if _, xe := s.SendMessage(ctx, chatRequest("alice", "bob", "spam")); xe != nil {
+		t.Fatalf("SendMessage failed: %v", xe)
+	}
+	if _, xe := s.SendMessage(ctx, chatRequest("alice", "bob", "hello")); xe == nil || xe.Code() != xerr.ErrMessageRejected.Code() {
+		t.Fatalf("expected ErrMessageRejected, got %v", xe)
+	}
+}
@@ -0,0 +1,170 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+)
+
+// recall 撤回消息：只有发送者能在撤回窗口内撤回。会话中保存的消息替换为撤回标记，
+// 收件箱中的副本在同步时隐藏，撤回通知经收件箱和推送发给会话成员和发送者的其他设备
+func (s *MessageService) recall(ctx context.Context, msg *messagepb.Message, connID uint64) (*messagepb.UpstreamResult, *xerr.Error) {
+	target, xe := s.targetMessage(ctx, msg)
+	if xe != nil {
+		return nil, xe
+	}
+	if target.SenderId != msg.SenderId {
+		return nil, xerr.ErrMessageRecallDenied
+	}
+
+	result := &messagepb.UpstreamResult{
+		ConversationId: msg.ConversationId,
+		Seq:            target.Seq,
+	}
+	if target.Recalled {
+		return result, nil
+	}
+	if time.Since(time.UnixMilli(target.ServerTs)) > s.recallWindow {
+		return nil, xerr.ErrMessageRecallExpired
+	}
+
+	// 先标记收件箱副本，再替换会话中的消息，替换失败时客户端可以重试
+	if err := s.tombstones.Recall(ctx, target.MsgId); err != nil {
+		log.Error(ctx, "mark recalled failed",
+			log.Int64("msg_id", target.MsgId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+	clearContent(target)
+	target.Recalled = true
+	if err := s.msgStore.SaveMessage(ctx, target); err != nil {
+		log.Error(ctx, "save recalled message failed",
+			log.Int64("conversation_id", target.ConversationId),
+			log.Int64("seq", target.Seq),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+
+	msg.MsgId = s.idGen.NextID()
+	msg.ServerTs = time.Now().UnixMilli()
+	result.MsgId = msg.MsgId
+
+	if msg.GroupId != 0 {
+		s.deliverGroup(ctx, msg)
+	} else {
+		s.notify(ctx, msg.ReceiverId, msg, 0)
+	}
+	s.notify(ctx, msg.SenderId, msg, connID)
+
+	log.Info(ctx, "message recalled",
+		log.String("sender_id", msg.SenderId),
+		log.Int64("conversation_id", msg.ConversationId),
+		log.Int64("seq", target.Seq),
+		log.Int64("msg_id", target.MsgId),
+	)
+	return result, nil
+}
+
+// delete 删除消息：只对删除者生效，删除者的设备同步时隐藏该消息，删除通知同步到删除者的其他设备
+func (s *MessageService) delete(ctx context.Context, msg *messagepb.Message, connID uint64) (*messagepb.UpstreamResult, *xerr.Error) {
+	target, xe := s.targetMessage(ctx, msg)
+	if xe != nil {
+		return nil, xe
+	}
+
+	if err := s.tombstones.Delete(ctx, msg.SenderId, target.MsgId); err != nil {
+		log.Error(ctx, "mark deleted failed",
+			log.String("user_id", msg.SenderId),
+			log.Int64("msg_id", target.MsgId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+
+	msg.MsgId = s.idGen.NextID()
+	msg.ServerTs = time.Now().UnixMilli()
+	s.notify(ctx, msg.SenderId, msg, connID)
+
+	return &messagepb.UpstreamResult{
+		MsgId:          msg.MsgId,
+		ConversationId: msg.ConversationId,
+		Seq:            target.Seq,
+	}, nil
+}
+
+// targetMessage 获取撤回、删除的目标消息，群聊要求操作者是群成员
+func (s *MessageService) targetMessage(ctx context.Context, msg *messagepb.Message) (*messagepb.Message, *xerr.Error) {
+	if msg.GroupId != 0 {
+		if err := s.checkGroupSender(ctx, msg); err != nil {
+			return nil, err
+		}
+	}
+
+	conversationID, xe := s.existingConversationID(ctx, msg)
+	if xe != nil {
+		return nil, xe
+	}
+	msg.ConversationId = conversationID
+	msgs, err := s.msgStore.GetMessages(ctx, msg.ConversationId, msg.TargetSeq-1, 1)
+	if err != nil {
+		log.Error(ctx, "get target message failed",
+			log.Int64("conversation_id", msg.ConversationId),
+			log.Int64("target_seq", msg.TargetSeq),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+	if len(msgs) == 0 || msgs[0].Seq != msg.TargetSeq {
+		return nil, xerr.ErrMessageNotFound
+	}
+	return msgs[0], nil
+}
+
+// applyTombstones 隐藏收件箱消息中已撤回和被用户删除的消息内容，消息本身保留以便客户端推进同步位点
+func (s *MessageService) applyTombstones(ctx context.Context, userID string, msgs []*messagepb.Message) error {
+	msgIDs := make([]int64, 0, len(msgs))
+	for _, msg := range msgs {
+		if isChatMessage(msg) {
+			msgIDs = append(msgIDs, msg.MsgId)
+		}
+	}
+	if len(msgIDs) == 0 {
+		return nil
+	}
+
+	recalled, deleted, err := s.tombstones.Filter(ctx, userID, msgIDs)
+	if err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		if !isChatMessage(msg) {
+			continue
+		}
+		if recalled[msg.MsgId] {
+			clearContent(msg)
+			msg.Recalled = true
+		}
+		if deleted[msg.MsgId] {
+			clearContent(msg)
+			msg.Deleted = true
+		}
+	}
+	return nil
+}
+
+// isChatMessage 是否单聊、群聊的内容消息
+func isChatMessage(msg *messagepb.Message) bool {
+	return msg.MsgType == messagepb.MessageType_MESSAGE_TYPE_CHAT || msg.MsgType == messagepb.MessageType_MESSAGE_TYPE_GROUP_CHAT
+}
+
+// clearContent 清空消息内容
+func clearContent(msg *messagepb.Message) {
+	msg.MsgBody = nil
+	msg.AtUsers = nil
+	msg.AtAll = false
+}
@@ -0,0 +1,191 @@
+package logic
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/protobuf/proto"
+)
+
+// unreadScanLimit 重新计算未读数时最多扫描的消息条数
+const unreadScanLimit = 1000
+
+// markRead 处理已读回执：前进读者在会话中的已读位置并重新计算未读数，
+// 再通知对方（群聊为被读消息的发送者）和读者的其他设备
+func (s *MessageService) markRead(ctx context.Context, msg *messagepb.Message, connID uint64) (*messagepb.UpstreamResult, *xerr.Error) {
+	if msg.GroupId != 0 {
+		if err := s.checkGroupSender(ctx, msg); err != nil {
+			return nil, err
+		}
+	}
+
+	reader := msg.SenderId
+	conversationID, xe := s.existingConversationID(ctx, msg)
+	if xe != nil {
+		return nil, xe
+	}
+	msg.ConversationId = conversationID
+	result := &messagepb.UpstreamResult{ConversationId: msg.ConversationId}
+
+	// 已读位置不超过会话当前最大序号；会话没有消息说明读者与接收方（单聊对方）之间没有会话，不通知接收方
+	maxSeq, err := s.sequencer.CurrentSeq(ctx, msg.ConversationId)
+	if err != nil {
+		log.Error(ctx, "get conversation seq failed",
+			log.Int64("conversation_id", msg.ConversationId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+	if maxSeq == 0 {
+		return nil, xerr.ErrConversationNotFound
+	}
+	msg.ReadSeq = min(msg.ReadSeq, maxSeq)
+
+	prevSeq, err := s.readStore.GetReadSeq(ctx, reader, msg.ConversationId)
+	if err != nil {
+		log.Error(ctx, "get read seq failed",
+			log.String("user_id", reader),
+			log.Int64("conversation_id", msg.ConversationId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+	if msg.ReadSeq <= prevSeq {
+		result.Seq = prevSeq
+		return result, nil
+	}
+
+	// 已读位置之后别人发的消息仍是未读
+	after, err := s.msgStore.GetMessages(ctx, msg.ConversationId, msg.ReadSeq, unreadScanLimit)
+	if err != nil {
+		log.Error(ctx, "get messages failed",
+			log.Int64("conversation_id", msg.ConversationId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+	var unread int64
+	for _, m := range after {
+		if m.SenderId != reader {
+			unread++
+		}
+	}
+
+	updated, err := s.readStore.MarkRead(ctx, reader, msg.ConversationId, msg.ReadSeq, unread)
+	if err != nil {
+		log.Error(ctx, "mark read failed",
+			log.String("user_id", reader),
+			log.Int64("conversation_id", msg.ConversationId),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+	result.Seq = msg.ReadSeq
+	if !updated {
+		// 并发的回执已经前进了已读位置，由那次回执通知
+		return result, nil
+	}
+
+	msg.MsgId = s.idGen.NextID()
+	msg.ServerTs = time.Now().UnixMilli()
+	result.MsgId = msg.MsgId
+
+	receivers := []string{msg.ReceiverId}
+	if msg.GroupId != 0 {
+		receivers = s.readSenders(ctx, msg, prevSeq)
+	}
+	for _, userID := range receivers {
+		s.notify(ctx, userID, msg, 0)
+	}
+	s.notify(ctx, reader, msg, connID)
+
+	return result, nil
+}
+
+// readSenders 返回群聊中本次新读到的消息的发送者（不含读者和已经退出群的成员）
+func (s *MessageService) readSenders(ctx context.Context, msg *messagepb.Message, prevSeq int64) []string {
+	limit := msg.ReadSeq - prevSeq
+	if limit > unreadScanLimit {
+		limit = unreadScanLimit
+	}
+	msgs, err := s.msgStore.GetMessages(ctx, msg.ConversationId, msg.ReadSeq-limit, int(limit))
+	if err != nil {
+		log.Warn(ctx, "get read messages failed",
+			log.Int64("conversation_id", msg.ConversationId),
+			log.String("error", err.Error()),
+		)
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	senders := make([]string, 0)
+	for _, m := range msgs {
+		if m.SenderId == msg.SenderId || seen[m.SenderId] {
+			continue
+		}
+		seen[m.SenderId] = true
+		if _, xe := s.groupMember(ctx, msg.GroupId, m.SenderId); xe != nil {
+			continue
+		}
+		senders = append(senders, m.SenderId)
+	}
+	return senders
+}
+
+// notify 将消息写入用户收件箱并推送给用户的在线设备（跳过 excludeConnID），离线设备上线后同步
+func (s *MessageService) notify(ctx context.Context, userID string, msg *messagepb.Message, excludeConnID uint64) {
+	msg = proto.Clone(msg).(*messagepb.Message)
+	userSeq, err := s.inbox.Append(ctx, userID, msg)
+	if err != nil {
+		log.Error(ctx, "append inbox failed",
+			log.String("user_id", userID),
+			log.Int64("msg_id", msg.MsgId),
+			log.String("error", err.Error()),
+		)
+	}
+	msg.UserSeq = userSeq
+	s.deliver(ctx, userID, msg, excludeConnID)
+}
+
+// GetUnread 获取用户有未读消息的会话、未读数和已读位置
+func (s *MessageService) GetUnread(ctx context.Context, userID string) (*messagepb.GetUnreadData, *xerr.Error) {
+	unread, err := s.readStore.GetUnread(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "get unread failed",
+			log.String("user_id", userID),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+	readSeqs, err := s.readStore.GetReadSeqs(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "get read seqs failed",
+			log.String("user_id", userID),
+			log.String("error", err.Error()),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+
+	data := &messagepb.GetUnreadData{
+		Conversations: make([]*messagepb.ConversationUnread, 0, len(unread)),
+	}
+	for convID, n := range unread {
+		if n <= 0 {
+			continue
+		}
+		data.Total += n
+		data.Conversations = append(data.Conversations, &messagepb.ConversationUnread{
+			ConversationId: convID,
+			Unread:         n,
+			ReadSeq:        readSeqs[convID],
+		})
+	}
+	sort.Slice(data.Conversations, func(i, j int) bool {
+		return data.Conversations[i].ConversationId < data.Conversations[j].ConversationId
+	})
+	return data, nil
+}
@@ -0,0 +1,144 @@
+package logic
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/pkg/xerr"
+)
+
+// validateMessage 校验消息的接收方和内容元素
+func (s *MessageService) validateMessage(msg *messagepb.Message) *xerr.Error {
+	switch msg.MsgType {
+	case messagepb.MessageType_MESSAGE_TYPE_CHAT:
+		if msg.ReceiverId == "" {
+			return xerr.ErrMessageInvalid.WithMessage("receiver_id is required")
+		}
+		if msg.GroupId != 0 {
+			return xerr.ErrMessageInvalid.WithMessage("group_id must be empty in chat message")
+		}
+		if msg.AtAll {
+			return xerr.ErrMessageInvalid.WithMessage("at_all is only allowed in group chat")
+		}
+	case messagepb.MessageType_MESSAGE_TYPE_GROUP_CHAT:
+		if s.groupStore == nil {
+			return xerr.ErrMessageTypeUnsupported.WithMessage("group chat is not enabled")
+		}
+		if msg.GroupId == 0 {
+			return xerr.ErrMessageInvalid.WithMessage("group_id is required")
+		}
+		if msg.ReceiverId != "" {
+			return xerr.ErrMessageInvalid.WithMessage("receiver_id must be empty in group message")
+		}
+	case messagepb.MessageType_MESSAGE_TYPE_READ_RECEIPT:
+		return s.validateReadReceipt(msg)
+	case messagepb.MessageType_MESSAGE_TYPE_RECALL, messagepb.MessageType_MESSAGE_TYPE_DELETE:
+		return s.validateTargetMessage(msg)
+	default:
+		return xerr.ErrMessageTypeUnsupported.WithMessage(fmt.Sprintf("unsupported message type: %s", msg.MsgType))
+	}
+
+	if len(msg.MsgBody) == 0 {
+		return xerr.ErrMessageInvalid.WithMessage("msg_body is empty")
+	}
+	if len(msg.MsgBody) > s.maxElements {
+		return xerr.ErrMessageTooLarge.WithMessage(fmt.Sprintf("too many elements: %d > %d", len(msg.MsgBody), s.maxElements))
+	}
+	for i, elem := range msg.MsgBody {
+		if err := s.validateElement(msg, elem); err != nil {
+			return err.WithMessage(fmt.Sprintf("msg_body[%d]: %s", i, err.Error()))
+		}
+	}
+	return nil
+}
+
+// validateReadReceipt 校验已读回执：单聊填 receiver_id，群聊填 group_id，不带消息内容
+func (s *MessageService) validateReadReceipt(msg *messagepb.Message) *xerr.Error {
+	if err := s.validateConversationRef(msg); err != nil {
+		return err
+	}
+	if msg.ReadSeq <= 0 {
+		return xerr.ErrMessageInvalid.WithMessage("read_seq is required")
+	}
+	return nil
+}
+
+// validateTargetMessage 校验撤回、删除：单聊填 receiver_id，群聊填 group_id，target_seq 指定目标消息，不带消息内容
+func (s *MessageService) validateTargetMessage(msg *messagepb.Message) *xerr.Error {
+	if err := s.validateConversationRef(msg); err != nil {
+		return err
+	}
+	if msg.TargetSeq <= 0 {
+		return xerr.ErrMessageInvalid.WithMessage("target_seq is required")
+	}
+	return nil
+}
+
+// validateConversationRef 校验指向会话的控制消息：receiver_id 和 group_id 只能填一个，不带消息内容
+func (s *MessageService) validateConversationRef(msg *messagepb.Message) *xerr.Error {
+	if (msg.ReceiverId == "") == (msg.GroupId == 0) {
+		return xerr.ErrMessageInvalid.WithMessage("exactly one of receiver_id and group_id is required")
+	}
+	if msg.GroupId != 0 && s.groupStore == nil {
+		return xerr.ErrMessageTypeUnsupported.WithMessage("group chat is not enabled")
+	}
+	if len(msg.MsgBody) > 0 {
+		return xerr.ErrMessageInvalid.WithMessage(fmt.Sprintf("msg_body must be empty in %s", msg.MsgType))
+	}
+	return nil
+}
+
+// validateElement 校验单个内容元素，element_type 必须和实际内容一致
+func (s *MessageService) validateElement(msg *messagepb.Message, elem *messagepb.MessageContent) *xerr.Error {
+	if elem == nil {
+		return xerr.ErrMessageInvalid.WithMessage("element is empty")
+	}
+
+	switch elem.ElementType {
+	case messagepb.MessageElementType_CONTENT_ELEMENT_TEXT:
+		text := elem.GetText()
+		if text == nil || text.Text == "" {
+			return xerr.ErrMessageInvalid.WithMessage("text is empty")
+		}
+		if utf8.RuneCountInString(text.Text) > s.maxTextLength {
+			return xerr.ErrMessageTooLarge.WithMessage(fmt.Sprintf("text too long: > %d", s.maxTextLength))
+		}
+	case messagepb.MessageElementType_CONTENT_ELEMENT_IMAGE:
+		if elem.GetImage().GetUrl() == "" {
+			return xerr.ErrMessageInvalid.WithMessage("image url is empty")
+		}
+	case messagepb.MessageElementType_CONTENT_ELEMENT_VIDEO:
+		if elem.GetVideo().GetUrl() == "" {
+			return xerr.ErrMessageInvalid.WithMessage("video url is empty")
+		}
+	case messagepb.MessageElementType_CONTENT_ELEMENT_FILE:
+		if elem.GetFileC().GetUrl() == "" {
+			return xerr.ErrMessageInvalid.WithMessage("file url is empty")
+		}
+	case messagepb.MessageElementType_CONTENT_ELEMENT_AUDIO:
+		if elem.GetAudio().GetUrl() == "" {
+			return xerr.ErrMessageInvalid.WithMessage("audio url is empty")
+		}
+	case messagepb.MessageElementType_CONTENT_ELEMENT_STICKER:
+		sticker := elem.GetSticker()
+		if sticker.GetStickerId() == "" && sticker.GetUrl() == "" {
+			return xerr.ErrMessageInvalid.WithMessage("sticker id and url are empty")
+		}
+	case messagepb.MessageElementType_CONTENT_ELEMENT_MENTION:
+		mention := elem.GetMention()
+		if mention == nil || (len(mention.AtUsers) == 0 && !mention.AtAll) {
+			return xerr.ErrMessageInvalid.WithMessage("mention has no target")
+		}
+		if mention.AtAll && msg.MsgType != messagepb.MessageType_MESSAGE_TYPE_GROUP_CHAT {
+			return xerr.ErrMessageInvalid.WithMessage("at_all is only allowed in group chat")
+		}
+	case messagepb.MessageElementType_CONTENT_ELEMENT_CUSTOM:
+		if len(elem.GetCustom().GetData()) == 0 {
+			return xerr.ErrMessageInvalid.WithMessage("custom data is empty")
+		}
+	default:
+		return xerr.ErrMessageInvalid.WithMessage(fmt.Sprintf("unknown element type: %s", elem.ElementType))
+	}
+	return nil
+}
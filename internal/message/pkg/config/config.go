@@ -0,0 +1,290 @@
+package config
+
+import "github.com/spf13/viper"
+
+// Init 初始化配置
+func Init(path string) {
+	viper.SetConfigFile(path)
+	viper.SetConfigType("yaml")
+	if err := viper.ReadInConfig(); err != nil {
+		panic(err)
+	}
+}
+
+// GetMessageServiceName 获取 Message 服务名称
+func GetMessageServiceName() string {
+	name := viper.GetString("message.service_name")
+	if name == "" {
+		return "kim-message"
+	}
+	return name
+}
+
+// GetMessageServicePort 获取 Message 服务端口
+func GetMessageServicePort() int {
+	port := viper.GetInt("message.port")
+	if port <= 0 {
+		return 9004
+	}
+	return port
+}
+
+// GetNodeID 获取消息ID生成器的节点ID（0-1023），多个实例必须配置不同的值
+func GetNodeID() int64 {
+	return viper.GetInt64("message.node_id")
+}
+
+// GetMaxElements 获取单条消息最多包含的内容元素数量
+func GetMaxElements() int {
+	n := viper.GetInt("message.max_elements")
+	if n <= 0 {
+		return 20
+	}
+	return n
+}
+
+// GetMaxTextLength 获取文本元素的最大长度（字符数）
+func GetMaxTextLength() int {
+	n := viper.GetInt("message.max_text_length")
+	if n <= 0 {
+		return 5000
+	}
+	return n
+}
+
+// GetStorage 获取存储类型（redis、memory），memory 只用于测试和单机部署
+func GetStorage() string {
+	storage := viper.GetString("message.storage")
+	if storage == "" {
+		return "redis"
+	}
+	return storage
+}
+
+// GetRedisEndpoint 获取 Redis 连接地址
+func GetRedisEndpoint() string {
+	return viper.GetString("message.redis.endpoint")
+}
+
+// GetRedisPassword 获取 Redis 密码
+func GetRedisPassword() string {
+	return viper.GetString("message.redis.password")
+}
+
+// GetRedisDB 获取 Redis 数据库编号
+func GetRedisDB() int {
+	return viper.GetInt("message.redis.db")
+}
+
+// GetRedisPoolSize 获取 Redis 连接池大小
+func GetRedisPoolSize() int {
+	poolSize := viper.GetInt("message.redis.pool_size")
+	if poolSize <= 0 {
+		return 10
+	}
+	return poolSize
+}
+
+// GetRedisMinIdleConns 获取 Redis 最小空闲连接数
+func GetRedisMinIdleConns() int {
+	minIdleConns := viper.GetInt("message.redis.min_idle_conns")
+	if minIdleConns <= 0 {
+		return 5
+	}
+	return minIdleConns
+}
+
+// GetHistorySize 获取每个会话保留的最近消息条数
+func GetHistorySize() int {
+	n := viper.GetInt("message.history_size")
+	if n <= 0 {
+		return 1000
+	}
+	return n
+}
+
+// GetRetentionDays 获取会话消息和收件箱的保留天数（无新消息超过该时间后删除）
+func GetRetentionDays() int {
+	n := viper.GetInt("message.retention_days")
+	if n <= 0 {
+		return 7
+	}
+	return n
+}
+
+// GetInboxSize 获取每个用户收件箱保留的消息条数，超过后最早的消息被裁剪
+func GetInboxSize() int {
+	n := viper.GetInt("message.inbox_size")
+	if n <= 0 {
+		return 2000
+	}
+	return n
+}
+
+// GetMaxSyncLimit 获取单次同步最多返回的消息条数
+func GetMaxSyncLimit() int {
+	n := viper.GetInt("message.max_sync_limit")
+	if n <= 0 {
+		return 200
+	}
+	return n
+}
+
+// GetDedupTTL 获取上行消息去重窗口（秒），窗口内相同 cli_msg_id 的重试返回首次的结果
+func GetDedupTTL() int {
+	ttl := viper.GetInt("message.dedup_ttl")
+	if ttl <= 0 {
+		return 3600
+	}
+	return ttl
+}
+
+// GetMaxConversations 获取每个用户会话列表保留的会话数量
+func GetMaxConversations() int {
+	n := viper.GetInt("message.max_conversations")
+	if n <= 0 {
+		return 1000
+	}
+	return n
+}
+
+// GetRecallWindow 获取消息发出后允许撤回的时间窗口（秒）
+func GetRecallWindow() int {
+	window := viper.GetInt("message.recall_window")
+	if window <= 0 {
+		return 120
+	}
+	return window
+}
+
+// GetGroupMaxMembers 获取单个群组的最大成员数量
+func GetGroupMaxMembers() int {
+	n := viper.GetInt("message.group.max_members")
+	if n <= 0 {
+		return 2000
+	}
+	return n
+}
+
+// GetFanoutAsyncThreshold 获取群消息异步推送的接收人数阈值
+func GetFanoutAsyncThreshold() int {
+	n := viper.GetInt("message.group.fanout.async_threshold")
+	if n <= 0 {
+		return 200
+	}
+	return n
+}
+
+// GetFanoutWorkers 获取群消息异步推送的工作协程数量
+func GetFanoutWorkers() int {
+	n := viper.GetInt("message.group.fanout.workers")
+	if n <= 0 {
+		return 8
+	}
+	return n
+}
+
+// GetFanoutQueueSize 获取群消息异步推送任务队列长度
+func GetFanoutQueueSize() int {
+	n := viper.GetInt("message.group.fanout.queue_size")
+	if n <= 0 {
+		return 1024
+	}
+	return n
+}
+
+// GetFanoutConcurrency 获取单次扩散的并发数量
+func GetFanoutConcurrency() int {
+	n := viper.GetInt("message.group.fanout.concurrency")
+	if n <= 0 {
+		return 16
+	}
+	return n
+}
+
+// GetFanoutTimeout 获取群消息异步推送任务超时时间（秒）
+func GetFanoutTimeout() int {
+	timeout := viper.GetInt("message.group.fanout.timeout")
+	if timeout <= 0 {
+		return 30
+	}
+	return timeout
+}
+
+// GetModerationKeywordBlockFile 获取屏蔽词表文件路径，消息命中屏蔽词时拒绝投递，为空表示不使用
+func GetModerationKeywordBlockFile() string {
+	return viper.GetString("message.moderation.keyword.block_file")
+}
+
+// GetModerationKeywordMaskFile 获取替换词表文件路径，命中的文本替换为 *，为空表示不使用
+func GetModerationKeywordMaskFile() string {
+	return viper.GetString("message.moderation.keyword.mask_file")
+}
+
+// GetModerationKeywordReloadInterval 获取检查词表文件是否修改的间隔（秒）
+func GetModerationKeywordReloadInterval() int {
+	n := viper.GetInt("message.moderation.keyword.reload_interval")
+	if n <= 0 {
+		return 10
+	}
+	return n
+}
+
+// GetModerationCalloutEnable 获取是否调用外部审核服务
+func GetModerationCalloutEnable() bool {
+	return viper.GetBool("message.moderation.callout.enable")
+}
+
+// GetModerationCalloutServiceName 获取外部审核服务在注册中心的名称
+func GetModerationCalloutServiceName() string {
+	name := viper.GetString("message.moderation.callout.service_name")
+	if name == "" {
+		return "kim-moderation"
+	}
+	return name
+}
+
+// GetModerationCalloutTimeout 获取调用外部审核服务的超时时间（毫秒）
+func GetModerationCalloutTimeout() int {
+	n := viper.GetInt("message.moderation.callout.timeout")
+	if n <= 0 {
+		return 500
+	}
+	return n
+}
+
+// GetModerationCalloutFailOpen 获取外部审核服务调用失败时是否放行消息，默认放行
+func GetModerationCalloutFailOpen() bool {
+	if !viper.IsSet("message.moderation.callout.fail_open") {
+		return true
+	}
+	return viper.GetBool("message.moderation.callout.fail_open")
+}
+
+// GetLogDebug 获取日志 Debug 模式配置
+func GetLogDebug() bool {
+	return viper.GetBool("log.debug")
+}
+
+// GetLogDir 获取日志目录
+func GetLogDir() string {
+	dir := viper.GetString("log.dir")
+	if dir == "" {
+		return "/home/www/logs/applogs"
+	}
+	return dir
+}
+
+// GetLogFilename 获取日志文件名
+func GetLogFilename() string {
+	filename := viper.GetString("log.filename")
+	if filename == "" {
+		return "message.log"
+	}
+	return filename
+}
+
+// GetRegistryEndpoints 获取注册中心端点列表
+func GetRegistryEndpoints() []string {
+	return viper.GetStringSlice("registry.endpoints")
+}
@@ -0,0 +1,65 @@
+package id
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	nodeBits = 10
+	seqBits  = 12
+
+	// MaxNodeID 节点ID最大值
+	MaxNodeID = 1<<nodeBits - 1
+	maxSeq    = 1<<seqBits - 1
+)
+
+// baseTime 基准时间 2025-10-01 00:00:00
+var baseTime = time.Unix(1759248000, 0)
+
+// Generator 消息ID生成器
+// 格式（snowflake）：相对时间戳(毫秒，41位) | 节点ID(10位) | 毫秒内序列号(12位)
+//
+//   - 不同节点使用不同的节点ID，保证全局唯一
+//   - 同一节点内按时间递增，消息ID可以用于排序
+//   - 每个节点每毫秒最多生成 4096 个ID，超过后等待下一毫秒
+//   - 时钟回拨时沿用上次的时间戳继续分配，不会生成重复ID
+type Generator struct {
+	mu     sync.Mutex
+	nodeID int64
+	lastMs int64
+	seq    int64
+}
+
+// NewGenerator 创建消息ID生成器，nodeID 取值 0-1023
+func NewGenerator(nodeID int64) *Generator {
+	return &Generator{nodeID: nodeID & MaxNodeID}
+}
+
+// NextID 生成消息ID
+func (g *Generator) NextID() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := time.Since(baseTime).Milliseconds()
+	if ms < g.lastMs {
+		// 时钟回拨，沿用上次的时间戳
+		ms = g.lastMs
+	}
+
+	if ms == g.lastMs {
+		g.seq = (g.seq + 1) & maxSeq
+		if g.seq == 0 {
+			// 当前毫秒序列号用完，等待下一毫秒
+			for ms <= g.lastMs {
+				time.Sleep(100 * time.Microsecond)
+				ms = time.Since(baseTime).Milliseconds()
+			}
+		}
+	} else {
+		g.seq = 0
+	}
+	g.lastMs = ms
+
+	return ms<<(nodeBits+seqBits) | g.nodeID<<seqBits | g.seq
+}
@@ -0,0 +1,210 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	grouppb "github.com/wsx864321/kim/idl/group"
+	messagepb "github.com/wsx864321/kim/idl/message"
+	"github.com/wsx864321/kim/internal/message/handler"
+	moderationclient "github.com/wsx864321/kim/internal/message/infra/grpc/moderation"
+	"github.com/wsx864321/kim/internal/message/infra/grpc/push"
+	"github.com/wsx864321/kim/internal/message/infra/moderation"
+	"github.com/wsx864321/kim/internal/message/infra/store"
+	"github.com/wsx864321/kim/internal/message/logic"
+	"github.com/wsx864321/kim/internal/message/pkg/config"
+	"github.com/wsx864321/kim/internal/message/pkg/id"
+	"github.com/wsx864321/kim/pkg/krpc"
+	"github.com/wsx864321/kim/pkg/krpc/registry"
+	"github.com/wsx864321/kim/pkg/krpc/registry/etcd"
+	"github.com/wsx864321/kim/pkg/log"
+	"google.golang.org/grpc"
+)
+
+// Run 启动 Message 服务端
+func Run(configPath string) {
+	// 初始化配置
+	config.Init(configPath)
+
+	// 初始化日志
+	log.InitLogger(
+		log.WithDebug(config.GetLogDebug()),
+		log.WithLogDir(config.GetLogDir()),
+		log.WithHistoryLogFileName(config.GetLogFilename()),
+	)
+
+	ctx := context.Background()
+
+	// 创建注册中心
+	r := createEtcdRegistry()
+
+	// 创建 Message、Group Handler
+	messageHandler, groupHandler := createHandlers(r)
+
+	// 创建 gRPC 服务器
+	grpcServer := krpc.NewPServer(
+		krpc.WithServiceName(config.GetMessageServiceName()),
+		krpc.WithPort(config.GetMessageServicePort()),
+		krpc.WithRegistry(r),
+	)
+
+	// 注册 Message、Group gRPC 服务
+	grpcServer.RegisterService(func(server *grpc.Server) {
+		messagepb.RegisterMessageServiceServer(server, messageHandler)
+		grouppb.RegisterGroupServiceServer(server, groupHandler)
+	})
+
+	log.Info(ctx, "message server starting",
+		log.String("service_name", config.GetMessageServiceName()),
+		log.Int("port", config.GetMessageServicePort()),
+		log.Int64("node_id", config.GetNodeID()),
+	)
+
+	// 启动 gRPC 服务（会阻塞）
+	grpcServer.Start(ctx)
+}
+
+// createHandlers 创建 MessageHandler 和 GroupHandler 实例
+func createHandlers(r registry.Registrar) (*handler.MessageHandler, *handler.GroupHandler) {
+	nodeID := config.GetNodeID()
+	if nodeID < 0 || nodeID > id.MaxNodeID {
+		panic("message.node_id must be in [0, 1023]")
+	}
+
+	st := createStore()
+	idGen := id.NewGenerator(nodeID)
+
+	// 群消息写入每个成员的收件箱，再通过 Push 服务批量推送给在线设备
+	pushClient := push.NewClient(r)
+	fanout := logic.NewFanout(pushClient, st.inbox, st.readStore, st.convStore, logic.FanoutConfig{
+		AsyncThreshold: config.GetFanoutAsyncThreshold(),
+		Workers:        config.GetFanoutWorkers(),
+		QueueSize:      config.GetFanoutQueueSize(),
+		Concurrency:    config.GetFanoutConcurrency(),
+		Timeout:        time.Duration(config.GetFanoutTimeout()) * time.Second,
+	})
+
+	messageService := logic.NewMessageService(
+		pushClient,
+		st.sequencer,
+		st.msgStore,
+		st.deduper,
+		st.inbox,
+		st.readStore,
+		st.tombstones,
+		st.convStore,
+		idGen,
+		logic.WithMaxElements(config.GetMaxElements()),
+		logic.WithMaxTextLength(config.GetMaxTextLength()),
+		logic.WithMaxSyncLimit(config.GetMaxSyncLimit()),
+		logic.WithRecallWindow(time.Duration(config.GetRecallWindow())*time.Second),
+		logic.WithGroup(st.groupStore, fanout),
+		logic.WithModeration(createInterceptors(r)...),
+	)
+	groupService := logic.NewGroupService(st.groupStore, idGen, config.GetGroupMaxMembers())
+
+	return handler.NewMessageHandler(messageService), handler.NewGroupHandler(groupService)
+}
+
+// createInterceptors 按配置创建内容审核拦截器：先经过关键词过滤，再调用外部审核服务
+func createInterceptors(r registry.Registrar) []moderation.Interceptor {
+	var interceptors []moderation.Interceptor
+
+	blockFile, maskFile := config.GetModerationKeywordBlockFile(), config.GetModerationKeywordMaskFile()
+	if blockFile != "" || maskFile != "" {
+		filter, err := moderation.NewKeywordFilter(blockFile, maskFile)
+		if err != nil {
+			panic(err)
+		}
+		go filter.Watch(context.Background(), time.Duration(config.GetModerationKeywordReloadInterval())*time.Second)
+		interceptors = append(interceptors, filter)
+	}
+
+	if config.GetModerationCalloutEnable() {
+		cli := moderationclient.NewClient(r, config.GetModerationCalloutServiceName())
+		interceptors = append(interceptors, moderation.NewCallout(cli,
+			time.Duration(config.GetModerationCalloutTimeout())*time.Millisecond,
+			config.GetModerationCalloutFailOpen(),
+		))
+	}
+
+	return interceptors
+}
+
+// stores Message 服务使用的存储
+type stores struct {
+	sequencer  store.Sequencer
+	msgStore   store.MessageStore
+	deduper    store.Deduper
+	inbox      store.Inbox
+	readStore  store.ReadStore
+	tombstones store.Tombstones
+	convStore  store.ConversationStore
+	groupStore store.GroupStore
+}
+
+// createStore 按配置创建会话序号分配器、消息存储、去重器、收件箱、已读位置、撤回删除标记、会话列表和群组存储
+func createStore() *stores {
+	historySize := config.GetHistorySize()
+	inboxSize := config.GetInboxSize()
+	dedupTTL := time.Duration(config.GetDedupTTL()) * time.Second
+	maxConversations := config.GetMaxConversations()
+
+	switch config.GetStorage() {
+	case "memory":
+		return &stores{
+			sequencer:  store.NewMemorySequencer(),
+			msgStore:   store.NewMemoryMessageStore(historySize),
+			deduper:    store.NewMemoryDeduper(dedupTTL),
+			inbox:      store.NewMemoryInbox(inboxSize),
+			readStore:  store.NewMemoryReadStore(),
+			tombstones: store.NewMemoryTombstones(),
+			convStore:  store.NewMemoryConversationStore(maxConversations),
+			groupStore: store.NewMemoryGroupStore(),
+		}
+	case "redis":
+		cli := createRedisClient()
+		retention := time.Duration(config.GetRetentionDays()) * 24 * time.Hour
+		return &stores{
+			sequencer: store.NewRedisSequencer(cli),
+			msgStore:  store.NewRedisMessageStore(cli, historySize, retention),
+			// Redis 不可用时退回到本地内存去重
+			deduper:    store.NewFallbackDeduper(store.NewRedisDeduper(cli, dedupTTL), store.NewMemoryDeduper(dedupTTL)),
+			inbox:      store.NewRedisInbox(cli, inboxSize, retention),
+			readStore:  store.NewRedisReadStore(cli, retention),
+			tombstones: store.NewRedisTombstones(cli, retention),
+			convStore:  store.NewRedisConversationStore(cli, maxConversations, retention),
+			groupStore: store.NewRedisGroupStore(cli),
+		}
+	default:
+		panic("unsupported message.storage: " + config.GetStorage())
+	}
+}
+
+// createRedisClient 创建 Redis 客户端
+func createRedisClient() redis.UniversalClient {
+	endpoint := config.GetRedisEndpoint()
+	if endpoint == "" {
+		panic("message.redis.endpoint is required")
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:         endpoint,
+		Password:     config.GetRedisPassword(),
+		DB:           config.GetRedisDB(),
+		PoolSize:     config.GetRedisPoolSize(),
+		MinIdleConns: config.GetRedisMinIdleConns(),
+	})
+}
+
+// createEtcdRegistry 创建 Etcd 注册中心
+func createEtcdRegistry() registry.Registrar {
+	r, err := etcd.NewETCDRegister(etcd.WithEndpoints(config.GetRegistryEndpoints()))
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
@@ -50,11 +50,16 @@ func (h *PushHandler) BatchPushMsg(ctx context.Context, req *pushpb.BatchPushReq
 		}, nil
 	}
 
-	if req.Msg == "" {
-		return &pushpb.BatchPushResp{
-			Code:    xerr.ErrInvalidParams.Code(),
-			Message: "msg is required",
-		}, nil
+	// 所有目标都设置了单独的消息内容时可以不设置 msg
+	if len(req.Msg) == 0 {
+		for _, target := range req.Targets {
+			if len(target.Msg) == 0 {
+				return &pushpb.BatchPushResp{
+					Code:    xerr.ErrInvalidParams.Code(),
+					Message: "msg is required",
+				}, nil
+			}
+		}
 	}
 
 	resp, err := h.service.BatchPushMsg(ctx, req)
@@ -67,6 +72,77 @@ func (h *PushHandler) BatchPushMsg(ctx context.Context, req *pushpb.BatchPushReq
 	return resp, nil
 }
 
+// ListDeadLetters 查看重试次数用尽的异步推送任务
+func (h *PushHandler) ListDeadLetters(ctx context.Context, req *pushpb.ListDeadLettersReq) (*pushpb.ListDeadLettersResp, error) {
+	data, err := h.service.ListDeadLetters(ctx, req)
+	if err != nil {
+		return &pushpb.ListDeadLettersResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &pushpb.ListDeadLettersResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    data,
+	}, nil
+}
+
+// ReplayDeadLetters 将死信任务重新放入异步推送队列
+func (h *PushHandler) ReplayDeadLetters(ctx context.Context, req *pushpb.ReplayDeadLettersReq) (*pushpb.ReplayDeadLettersResp, error) {
+	if len(req.PushIds) == 0 {
+		return &pushpb.ReplayDeadLettersResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: "push_ids is empty",
+		}, nil
+	}
+
+	data, err := h.service.ReplayDeadLetters(ctx, req)
+	if err != nil {
+		return &pushpb.ReplayDeadLettersResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &pushpb.ReplayDeadLettersResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    data,
+	}, nil
+}
+
+// Broadcast 广播消息到所有 Gateway 上满足过滤条件的连接
+func (h *PushHandler) Broadcast(ctx context.Context, req *pushpb.BroadcastReq) (*pushpb.BroadcastResp, error) {
+	data, err := h.service.Broadcast(ctx, req)
+	if err != nil {
+		return &pushpb.BroadcastResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &pushpb.BroadcastResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    data,
+	}, nil
+}
+
+// Publish 发布消息到主题
+func (h *PushHandler) Publish(ctx context.Context, req *pushpb.PublishReq) (*pushpb.PublishResp, error) {
+	data, err := h.service.Publish(ctx, req)
+	if err != nil {
+		return &pushpb.PublishResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+	return &pushpb.PublishResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    data,
+	}, nil
+}
+
 // CloseConn 关闭指定连接
 func (h *PushHandler) CloseConn(ctx context.Context, req *pushpb.CloseConnReq) (*pushpb.CloseConnResp, error) {
 	if req.UserId == "" {
@@ -1,44 +1,158 @@
 package gateway
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"sync"
 
 	gatewaypb "github.com/wsx864321/kim/idl/gateway"
 	"github.com/wsx864321/kim/pkg/krpc"
+	"github.com/wsx864321/kim/pkg/krpc/registry"
 	"github.com/wsx864321/kim/pkg/log"
+	"google.golang.org/grpc"
 )
 
-// ClientManager Gateway 客户端管理器
+const (
+	// serviceName Gateway 服务名称
+	serviceName = "kim-gateway"
+	// MetadataGatewayID Gateway 实例注册时携带的节点ID元数据
+	MetadataGatewayID = "gateway_id"
+)
+
+// ErrGatewayNotFound 注册中心中没有该 gateway_id 的 Gateway 实例（实例已下线或尚未注册）
+var ErrGatewayNotFound = errors.New("gateway not found")
+
+// gatewayClient 直连某个 Gateway 实例的客户端
+type gatewayClient struct {
+	addr   string
+	conn   *grpc.ClientConn
+	client gatewaypb.GatewayServiceClient
+}
+
+// ClientManager Gateway 客户端管理器：按 gateway_id 在注册中心找到对应的 Gateway 实例并直连，
+// 实例下线或地址变化时关闭并移除客户端
 type ClientManager struct {
-	clients sync.Map // map[string]gatewaypb.GatewayServiceClient
+	registry registry.Registrar
+	mu       sync.Mutex
+	clients  map[string]*gatewayClient
+	changed  chan struct{}
 }
 
 // NewClientManager 创建 Gateway 客户端管理器
-func NewClientManager() *ClientManager {
-	return &ClientManager{
-		clients: sync.Map{},
+func NewClientManager(r registry.Registrar) *ClientManager {
+	m := &ClientManager{
+		registry: r,
+		clients:  make(map[string]*gatewayClient),
+		changed:  make(chan struct{}, 1),
 	}
+
+	// 注册中心在持有内部锁时通知监听者，这里只发信号，由单独的协程检查并移除下线的实例
+	r.AddListener(context.Background(), func() {
+		select {
+		case m.changed <- struct{}{}:
+		default:
+		}
+	})
+	go m.watch()
+
+	return m
 }
 
-// GetClient 获取或创建 Gateway 客户端
+// GetClient 获取 gateway_id 对应的 Gateway 实例的客户端，实例不存在时立即返回 ErrGatewayNotFound
 func (m *ClientManager) GetClient(gatewayID string) (gatewaypb.GatewayServiceClient, error) {
-	// 如果已经存在，直接返回
-	if client, ok := m.clients.Load(gatewayID); ok {
-		return client.(gatewaypb.GatewayServiceClient), nil
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.clients[gatewayID]; ok {
+		return c.client, nil
+	}
+
+	addr, ok := m.endpoints()[gatewayID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrGatewayNotFound, gatewayID)
 	}
 
-	// 创建新的 Gateway 客户端
-	cli, err := krpc.NewKClient(krpc.WithClientServiceName("kim-gateway"))
+	cli, err := krpc.NewKClient(
+		krpc.WithDirect(true),
+		krpc.WithURL("passthrough:///"+addr),
+	)
 	if err != nil {
 		log.Error(nil, "create gateway client failed",
 			log.String("gateway_id", gatewayID),
+			log.String("addr", addr),
 			log.String("error", err.Error()),
 		)
 		return nil, err
 	}
 
-	gatewayClient := gatewaypb.NewGatewayServiceClient(cli.Conn())
-	m.clients.Store(gatewayID, gatewayClient)
+	c := &gatewayClient{
+		addr:   addr,
+		conn:   cli.Conn(),
+		client: gatewaypb.NewGatewayServiceClient(cli.Conn()),
+	}
+	m.clients[gatewayID] = c
+
+	return c.client, nil
+}
+
+// GatewayIDs 返回注册中心中所有在线 Gateway 实例的 gateway_id（按字典序）
+func (m *ClientManager) GatewayIDs() []string {
+	addrs := m.endpoints()
+	ids := make([]string, 0, len(addrs))
+	for gatewayID := range addrs {
+		ids = append(ids, gatewayID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// endpoints 返回注册中心中各 Gateway 实例的 gateway_id 与地址
+func (m *ClientManager) endpoints() map[string]string {
+	service := m.registry.GetService(context.Background(), serviceName)
+	addrs := make(map[string]string, len(service.Endpoints))
+	for _, endpoint := range service.Endpoints {
+		gatewayID := endpoint.Metadata[MetadataGatewayID]
+		if gatewayID == "" || !endpoint.Enable {
+			continue
+		}
+		addrs[gatewayID] = fmt.Sprintf("%s:%d", endpoint.IP, endpoint.Port)
+	}
+	return addrs
+}
+
+// watch 注册中心变化时移除已下线或地址变化的 Gateway 实例的客户端
+func (m *ClientManager) watch() {
+	for range m.changed {
+		m.evict()
+	}
+}
+
+// evict 关闭并移除已下线或地址变化的 Gateway 实例的客户端
+func (m *ClientManager) evict() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.clients) == 0 {
+		return
+	}
 
-	return gatewayClient, nil
+	addrs := m.endpoints()
+	for gatewayID, c := range m.clients {
+		if addrs[gatewayID] == c.addr {
+			continue
+		}
+		delete(m.clients, gatewayID)
+		if err := c.conn.Close(); err != nil {
+			log.Warn(nil, "close gateway client failed",
+				log.String("gateway_id", gatewayID),
+				log.String("error", err.Error()),
+			)
+		}
+		log.Info(nil, "gateway client evicted",
+			log.String("gateway_id", gatewayID),
+			log.String("addr", c.addr),
+		)
+	}
 }
@@ -0,0 +1,149 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wsx864321/kim/pkg/krpc/registry"
+)
+
+// fakeRegistry 内存注册中心，修改节点后通知监听者
+type fakeRegistry struct {
+	registry.Registrar
+
+	mu        sync.Mutex
+	endpoints []*registry.Endpoint
+	listeners []func()
+}
+
+func (r *fakeRegistry) GetService(_ context.Context, name string) *registry.Service {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &registry.Service{Name: name, Endpoints: slices.Clone(r.endpoints)}
+}
+
+func (r *fakeRegistry) AddListener(_ context.Context, f func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, f)
+}
+
+// set 替换节点列表并通知监听者
+func (r *fakeRegistry) set(endpoints ...*registry.Endpoint) {
+	r.mu.Lock()
+	r.endpoints = endpoints
+	listeners := slices.Clone(r.listeners)
+	r.mu.Unlock()
+	for _, f := range listeners {
+		f()
+	}
+}
+
+func gatewayEndpoint(gatewayID, ip string, enable bool) *registry.Endpoint {
+	e := &registry.Endpoint{ServerName: serviceName, IP: ip, Port: 9000, Enable: enable}
+	if gatewayID != "" {
+		e.Metadata = map[string]string{MetadataGatewayID: gatewayID}
+	}
+	return e
+}
+
+// cachedAddr 返回已创建客户端的地址，没有客户端时返回空
+func (m *ClientManager) cachedAddr(gatewayID string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok := m.clients[gatewayID]; ok {
+		return c.addr
+	}
+	return ""
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestClientManagerResolve(t *testing.T) {
+	r := &fakeRegistry{}
+	r.set(
+		gatewayEndpoint("gw-2", "10.0.0.2", true),
+		gatewayEndpoint("gw-1", "10.0.0.1", true),
+		// 没有 gateway_id 元数据或已禁用的节点不可寻址
+		gatewayEndpoint("", "10.0.0.3", true),
+		gatewayEndpoint("gw-4", "10.0.0.4", false),
+	)
+	m := NewClientManager(r)
+
+	if ids := m.GatewayIDs(); !slices.Equal(ids, []string{"gw-1", "gw-2"}) {
+		t.Fatalf("unexpected gateway ids: %v", ids)
+	}
+
+	tests := []struct {
+		name      string
+		gatewayID string
+		addr      string
+		wantErr   error
+	}{
+		{name: "online", gatewayID: "gw-1", addr: "10.0.0.1:9000"},
+		{name: "disabled", gatewayID: "gw-4", wantErr: ErrGatewayNotFound},
+		{name: "unknown", gatewayID: "gw-5", wantErr: ErrGatewayNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli, err := m.GetClient(tt.gatewayID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				if cli != nil || m.cachedAddr(tt.gatewayID) != "" {
+					t.Fatalf("unexpected client for %s", tt.gatewayID)
+				}
+				return
+			}
+			if cli == nil || m.cachedAddr(tt.gatewayID) != tt.addr {
+				t.Fatalf("expected client for %s, got %v", tt.addr, m.cachedAddr(tt.gatewayID))
+			}
+
+			// 再次获取复用同一个客户端
+			again, err := m.GetClient(tt.gatewayID)
+			if err != nil || again != cli {
+				t.Fatalf("expected cached client, got %v %v", again, err)
+			}
+		})
+	}
+}
+
+func TestClientManagerEvict(t *testing.T) {
+	r := &fakeRegistry{}
+	r.set(gatewayEndpoint("gw-1", "10.0.0.1", true), gatewayEndpoint("gw-2", "10.0.0.2", true))
+	m := NewClientManager(r)
+
+	for _, gatewayID := range []string{"gw-1", "gw-2"} {
+		if _, err := m.GetClient(gatewayID); err != nil {
+			t.Fatalf("get client %s failed: %v", gatewayID, err)
+		}
+	}
+
+	// gw-1 下线，gw-2 地址变化：两个客户端都被移除
+	r.set(gatewayEndpoint("gw-2", "10.0.0.22", true))
+	waitFor(t, func() bool {
+		return m.cachedAddr("gw-1") == "" && m.cachedAddr("gw-2") == ""
+	})
+
+	// 下线的实例立即返回失败，地址变化的实例按新地址重新创建客户端
+	if _, err := m.GetClient("gw-1"); !errors.Is(err, ErrGatewayNotFound) {
+		t.Fatalf("expected ErrGatewayNotFound, got %v", err)
+	}
+	if _, err := m.GetClient("gw-2"); err != nil || m.cachedAddr("gw-2") != "10.0.0.22:9000" {
+		t.Fatalf("expected client for new addr, got %s %v", m.cachedAddr("gw-2"), err)
+	}
+}
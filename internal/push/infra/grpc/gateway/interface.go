@@ -0,0 +1,13 @@
+package gateway
+
+import (
+	gatewaypb "github.com/wsx864321/kim/idl/gateway"
+)
+
+// ClientManagerInterface ...
+type ClientManagerInterface interface {
+	// GetClient 获取 Gateway 客户端
+	GetClient(gatewayID string) (gatewaypb.GatewayServiceClient, error)
+	// GatewayIDs 返回所有在线 Gateway 实例的 gateway_id
+	GatewayIDs() []string
+}
@@ -44,6 +44,11 @@ func (c *Client) GetSessions(ctx context.Context, in *sessionpb.GetSessionsReq)
 	return c.cli.GetSessions(ctx, in)
 }
 
+// BatchGetSessions 批量获取多个用户的会话列表
+func (c *Client) BatchGetSessions(ctx context.Context, in *sessionpb.BatchGetSessionsReq) (*sessionpb.BatchGetSessionsResp, error) {
+	return c.cli.BatchGetSessions(ctx, in)
+}
+
 // RefreshSessionTTL 刷新会话 TTL
 func (c *Client) RefreshSessionTTL(ctx context.Context, in *sessionpb.RefreshSessionTTLReq) (*sessionpb.RefreshSessionTTLResp, error) {
 	return c.cli.RefreshSessionTTL(ctx, in)
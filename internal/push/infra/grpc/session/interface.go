@@ -13,6 +13,8 @@ type ClientInterface interface {
 	DelSession(ctx context.Context, in *sessionpb.DelSessionReq) (*sessionpb.DelSessionResp, error)
 	// GetSessions 获取用户会话列表
 	GetSessions(ctx context.Context, in *sessionpb.GetSessionsReq) (*sessionpb.GetSessionsResp, error)
+	// BatchGetSessions 批量获取多个用户的会话列表
+	BatchGetSessions(ctx context.Context, in *sessionpb.BatchGetSessionsReq) (*sessionpb.BatchGetSessionsResp, error)
 	// RefreshSessionTTL 刷新会话 TTL
 	RefreshSessionTTL(ctx context.Context, in *sessionpb.RefreshSessionTTLReq) (*sessionpb.RefreshSessionTTLResp, error)
 }
@@ -0,0 +1,58 @@
+package queue
+
+import (
+	"encoding/json"
+
+	pushpb "github.com/wsx864321/kim/idl/push"
+	"google.golang.org/protobuf/proto"
+)
+
+// jobData 任务序列化格式
+type jobData struct {
+	ID        string `json:"id"`
+	Req       []byte `json:"req"`
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+	FailedAt  int64  `json:"failed_at,omitempty"`
+}
+
+// encodeJob 序列化任务，推送请求使用 protobuf 编码
+func encodeJob(job *Job) (string, error) {
+	req, err := proto.Marshal(job.Req)
+	if err != nil {
+		return "", err
+	}
+	raw, err := json.Marshal(jobData{
+		ID:        job.ID,
+		Req:       req,
+		Attempts:  job.Attempts,
+		LastError: job.LastError,
+		CreatedAt: job.CreatedAt,
+		FailedAt:  job.FailedAt,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// decodeJob 反序列化任务
+func decodeJob(raw string) (*Job, error) {
+	var data jobData
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, err
+	}
+	req := &pushpb.PushReq{}
+	if err := proto.Unmarshal(data.Req, req); err != nil {
+		return nil, err
+	}
+	return &Job{
+		ID:        data.ID,
+		Req:       req,
+		Attempts:  data.Attempts,
+		LastError: data.LastError,
+		CreatedAt: data.CreatedAt,
+		FailedAt:  data.FailedAt,
+	}, nil
+}
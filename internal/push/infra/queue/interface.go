@@ -0,0 +1,53 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pushpb "github.com/wsx864321/kim/idl/push"
+)
+
+// ErrQueueFull 队列中的任务（包括未确认和等待重试的任务）达到上限，拒绝入队
+var ErrQueueFull = errors.New("push queue is full")
+
+// Job 异步推送任务
+type Job struct {
+	// ID 推送ID，重试和重放时保持不变
+	ID string
+	// Req 推送请求
+	Req *pushpb.PushReq
+	// Attempts 已尝试次数
+	Attempts int
+	// LastError 最后一次失败的原因
+	LastError string
+	// CreatedAt 创建时间戳（毫秒）
+	CreatedAt int64
+	// FailedAt 进入死信的时间戳（毫秒）
+	FailedAt int64
+
+	// ref 队列内部的投递标识（如 Redis Stream 的消息ID），用于确认
+	ref string
+}
+
+// Queue 异步推送队列，任务被取出后需要调用 Ack 或 Retry 确认，未确认的任务在实现支持时会重新投递
+type Queue interface {
+	// Enqueue 任务入队，队列已满时返回 ErrQueueFull
+	Enqueue(ctx context.Context, job *Job) error
+	// Dequeue 取出最多 count 个任务（包括到期的重试任务），没有任务时最多等待 block
+	Dequeue(ctx context.Context, count int, block time.Duration) ([]*Job, error)
+	// Ack 确认任务处理完成
+	Ack(ctx context.Context, job *Job) error
+	// Retry 确认本次投递，并在 at 时间重新投递任务（任务的 Attempts、LastError 一并保存）
+	Retry(ctx context.Context, job *Job, at time.Time) error
+}
+
+// DeadLetters 死信存储，保存重试次数用尽或不可重试的任务，可查看和重放
+type DeadLetters interface {
+	// Add 添加死信任务
+	Add(ctx context.Context, job *Job) error
+	// List 按进入死信的时间倒序分页查看死信任务，返回任务和总数
+	List(ctx context.Context, offset, limit int) ([]*Job, int64, error)
+	// Take 取出并删除指定的死信任务，不存在的任务忽略
+	Take(ctx context.Context, ids []string) ([]*Job, error)
+}
@@ -0,0 +1,173 @@
+package queue
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// delayedJob 等待重试的任务
+type delayedJob struct {
+	job *Job
+	at  time.Time
+}
+
+// MemoryQueue 内存异步推送队列，只用于测试和单机部署，进程退出后未处理的任务丢失
+type MemoryQueue struct {
+	mu      sync.Mutex
+	ready   []*Job
+	delayed []delayedJob
+	notify  chan struct{}
+}
+
+// NewMemoryQueue 创建内存异步推送队列
+func NewMemoryQueue() *MemoryQueue {
+	return &MemoryQueue{notify: make(chan struct{}, 1)}
+}
+
+// Enqueue 任务入队
+func (q *MemoryQueue) Enqueue(ctx context.Context, job *Job) error {
+	q.mu.Lock()
+	q.ready = append(q.ready, job)
+	q.mu.Unlock()
+	q.wakeup()
+	return nil
+}
+
+// Dequeue 取出最多 count 个任务，没有任务时等待新任务或最早的重试任务到期，最多等待 block
+func (q *MemoryQueue) Dequeue(ctx context.Context, count int, block time.Duration) ([]*Job, error) {
+	deadline := time.Now().Add(block)
+	for {
+		jobs, next := q.take(count)
+		if len(jobs) > 0 {
+			return jobs, nil
+		}
+
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return nil, nil
+		}
+		if !next.IsZero() && time.Until(next) < wait {
+			wait = time.Until(next)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-q.notify:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// take 将到期的重试任务移入就绪队列并取出最多 count 个任务，同时返回最早的重试任务的到期时间
+func (q *MemoryQueue) take(count int) ([]*Job, time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	due := 0
+	for due < len(q.delayed) && !q.delayed[due].at.After(now) {
+		q.ready = append(q.ready, q.delayed[due].job)
+		due++
+	}
+	q.delayed = q.delayed[due:]
+
+	n := min(count, len(q.ready))
+	jobs := q.ready[:n:n]
+	q.ready = q.ready[n:]
+
+	var next time.Time
+	if len(q.delayed) > 0 {
+		next = q.delayed[0].at
+	}
+	return jobs, next
+}
+
+// Ack 确认任务处理完成
+func (q *MemoryQueue) Ack(ctx context.Context, job *Job) error {
+	return nil
+}
+
+// Retry 在 at 时间重新投递任务
+func (q *MemoryQueue) Retry(ctx context.Context, job *Job, at time.Time) error {
+	q.mu.Lock()
+	i := sort.Search(len(q.delayed), func(i int) bool { return q.delayed[i].at.After(at) })
+	q.delayed = append(q.delayed, delayedJob{})
+	copy(q.delayed[i+1:], q.delayed[i:])
+	q.delayed[i] = delayedJob{job: job, at: at}
+	q.mu.Unlock()
+	q.wakeup()
+	return nil
+}
+
+// wakeup 唤醒等待中的 Dequeue
+func (q *MemoryQueue) wakeup() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// MemoryDeadLetters 内存死信存储，只用于测试和单机部署；存取时复制任务，避免调用方修改已保存的任务
+type MemoryDeadLetters struct {
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// NewMemoryDeadLetters 创建内存死信存储
+func NewMemoryDeadLetters() *MemoryDeadLetters {
+	return &MemoryDeadLetters{jobs: make(map[string]*Job)}
+}
+
+// Add 添加死信任务
+func (d *MemoryDeadLetters) Add(ctx context.Context, job *Job) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	cp := *job
+	d.jobs[job.ID] = &cp
+	return nil
+}
+
+// List 按进入死信的时间倒序分页查看死信任务
+func (d *MemoryDeadLetters) List(ctx context.Context, offset, limit int) ([]*Job, int64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	jobs := make([]*Job, 0, len(d.jobs))
+	for _, job := range d.jobs {
+		cp := *job
+		jobs = append(jobs, &cp)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].FailedAt != jobs[j].FailedAt {
+			return jobs[i].FailedAt > jobs[j].FailedAt
+		}
+		return jobs[i].ID > jobs[j].ID
+	})
+
+	total := int64(len(jobs))
+	if offset >= len(jobs) {
+		return []*Job{}, total, nil
+	}
+	return jobs[offset:min(offset+limit, len(jobs))], total, nil
+}
+
+// Take 取出并删除指定的死信任务
+func (d *MemoryDeadLetters) Take(ctx context.Context, ids []string) ([]*Job, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	jobs := make([]*Job, 0, len(ids))
+	for _, id := range ids {
+		if job, ok := d.jobs[id]; ok {
+			jobs = append(jobs, job)
+			delete(d.jobs, id)
+		}
+	}
+	return jobs, nil
+}
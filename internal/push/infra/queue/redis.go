@@ -0,0 +1,316 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/wsx864321/kim/pkg/log"
+)
+
+const (
+	// streamKey 异步推送任务流，所有 key 使用同一个哈希标签，保证 Redis Cluster 中 Lua 脚本和事务访问的 key 在同一个槽
+	streamKey = "kim:push:{queue}:stream"
+	// retryKey 等待重试的任务 Sorted Set，score 为重新投递的时间戳（毫秒）
+	retryKey = "kim:push:{queue}:retry"
+	// deadKey 死信任务 Hash，field 为推送ID
+	deadKey = "kim:push:{queue}:dead"
+	// deadIndexKey 死信任务索引 Sorted Set，score 为进入死信的时间戳（毫秒）
+	deadIndexKey = "kim:push:{queue}:dead:index"
+
+	// consumerGroup Stream 消费组名称
+	consumerGroup = "kim-push"
+	// jobField Stream 消息中保存任务的字段
+	jobField = "job"
+)
+
+// enqueueLuaScript 任务流和重试集合中的任务总数未达到上限时写入任务流
+// 任务流中的任务在确认后才删除，XLEN 包括未读取和已读取未确认的任务，不能用 MAXLEN 裁剪，否则会丢失任务
+//
+// 参数：
+//
+//	KEYS[1]: stream key
+//	KEYS[2]: retry sorted set key
+//	ARGV[1]: 序列化后的任务
+//	ARGV[2]: 队列中的最大任务数量
+//
+// 返回值：
+//
+//	1: 入队成功
+//	0: 队列已满
+const enqueueLuaScript = `
+if redis.call('XLEN', KEYS[1]) + redis.call('ZCARD', KEYS[2]) >= tonumber(ARGV[2]) then
+    return 0
+end
+redis.call('XADD', KEYS[1], '*', 'job', ARGV[1])
+return 1
+`
+
+// promoteLuaScript 将到期的重试任务移回任务流，重试任务已计入队列上限，移动时不再检查
+//
+// 参数：
+//
+//	KEYS[1]: retry sorted set key
+//	KEYS[2]: stream key
+//	ARGV[1]: 当前时间戳（毫秒）
+//	ARGV[2]: 单次最多移动的任务数量
+const promoteLuaScript = `
+local jobs = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, tonumber(ARGV[2]))
+for i = 1, #jobs do
+    redis.call('ZREM', KEYS[1], jobs[i])
+    redis.call('XADD', KEYS[2], '*', 'job', jobs[i])
+end
+return #jobs
+`
+
+// takeDeadLuaScript 取出并删除死信任务
+//
+// 参数：
+//
+//	KEYS[1]: dead hash key
+//	KEYS[2]: dead index sorted set key
+//	ARGV: 推送ID列表
+const takeDeadLuaScript = `
+local jobs = {}
+for i = 1, #ARGV do
+    local job = redis.call('HGET', KEYS[1], ARGV[i])
+    if job then
+        redis.call('HDEL', KEYS[1], ARGV[i])
+        redis.call('ZREM', KEYS[2], ARGV[i])
+        table.insert(jobs, job)
+    end
+end
+return jobs
+`
+
+// RedisQueue 基于 Redis Streams 的异步推送队列：任务通过消费组分发给各个 Push 实例，
+// 处理中实例退出导致未确认的任务在空闲超过 claimIdle 后由其他实例认领；重试任务先放入 Sorted Set，到期后移回任务流
+type RedisQueue struct {
+	cli       redis.UniversalClient
+	consumer  string
+	maxLen    int64
+	claimIdle time.Duration
+	enqueue   *redis.Script
+	promote   *redis.Script
+}
+
+// NewRedisQueue 创建 Redis 异步推送队列，consumer 为当前实例的消费者名称（每个实例唯一），
+// maxLen 为队列中的最大任务数量（任务流 + 等待重试），达到后拒绝入队；
+// claimIdle 需要大于一个实例处理一批任务的最长时间，否则处理中的任务会被其他实例认领并重复推送
+func NewRedisQueue(cli redis.UniversalClient, consumer string, maxLen int64, claimIdle time.Duration) (*RedisQueue, error) {
+	err := cli.XGroupCreateMkStream(context.Background(), streamKey, consumerGroup, "0").Err()
+	if err != nil && !redis.HasErrorPrefix(err, "BUSYGROUP") {
+		return nil, fmt.Errorf("create consumer group failed: %w", err)
+	}
+
+	return &RedisQueue{
+		cli:       cli,
+		consumer:  consumer,
+		maxLen:    maxLen,
+		claimIdle: claimIdle,
+		enqueue:   redis.NewScript(enqueueLuaScript),
+		promote:   redis.NewScript(promoteLuaScript),
+	}, nil
+}
+
+// Enqueue 任务入队，队列已满时返回 ErrQueueFull
+func (q *RedisQueue) Enqueue(ctx context.Context, job *Job) error {
+	raw, err := encodeJob(job)
+	if err != nil {
+		return err
+	}
+	ok, err := q.enqueue.Run(ctx, q.cli, []string{streamKey, retryKey}, raw, q.maxLen).Int()
+	if err != nil {
+		return fmt.Errorf("enqueue job failed: %w", err)
+	}
+	if ok == 0 {
+		return ErrQueueFull
+	}
+	return nil
+}
+
+// Dequeue 先移回到期的重试任务并认领空闲超时的未确认任务，不足 count 个时再读取新任务
+func (q *RedisQueue) Dequeue(ctx context.Context, count int, block time.Duration) ([]*Job, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	if err := q.promote.Run(ctx, q.cli, []string{retryKey, streamKey}, now, count).Err(); err != nil {
+		return nil, fmt.Errorf("promote retry jobs failed: %w", err)
+	}
+
+	claimed, _, err := q.cli.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   streamKey,
+		Group:    consumerGroup,
+		Consumer: q.consumer,
+		MinIdle:  q.claimIdle,
+		Start:    "0-0",
+		Count:    int64(count),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("claim pending jobs failed: %w", err)
+	}
+	if len(claimed) > 0 {
+		return q.decode(ctx, claimed), nil
+	}
+
+	streams, err := q.cli.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    consumerGroup,
+		Consumer: q.consumer,
+		Streams:  []string{streamKey, ">"},
+		Count:    int64(count),
+		Block:    block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read jobs failed: %w", err)
+	}
+
+	var jobs []*Job
+	for _, stream := range streams {
+		jobs = append(jobs, q.decode(ctx, stream.Messages)...)
+	}
+	return jobs, nil
+}
+
+// decode 解析 Stream 消息，无法解析的消息直接确认丢弃
+func (q *RedisQueue) decode(ctx context.Context, messages []redis.XMessage) []*Job {
+	jobs := make([]*Job, 0, len(messages))
+	for _, message := range messages {
+		raw, _ := message.Values[jobField].(string)
+		job, err := decodeJob(raw)
+		if err != nil {
+			log.Error(ctx, "decode push job failed",
+				log.String("message_id", message.ID),
+				log.String("error", err.Error()),
+			)
+			q.ack(ctx, q.cli, message.ID)
+			continue
+		}
+		job.ref = message.ID
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// Ack 确认任务并从任务流中删除
+func (q *RedisQueue) Ack(ctx context.Context, job *Job) error {
+	pipe := q.cli.TxPipeline()
+	q.ack(ctx, pipe, job.ref)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Retry 将任务放入重试集合并确认本次投递
+func (q *RedisQueue) Retry(ctx context.Context, job *Job, at time.Time) error {
+	raw, err := encodeJob(job)
+	if err != nil {
+		return err
+	}
+
+	pipe := q.cli.TxPipeline()
+	pipe.ZAdd(ctx, retryKey, redis.Z{Score: float64(at.UnixMilli()), Member: raw})
+	q.ack(ctx, pipe, job.ref)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// ack 确认并删除 Stream 消息
+func (q *RedisQueue) ack(ctx context.Context, cmd redis.Cmdable, id string) {
+	cmd.XAck(ctx, streamKey, consumerGroup, id)
+	cmd.XDel(ctx, streamKey, id)
+}
+
+// RedisDeadLetters 基于 Redis 的死信存储
+type RedisDeadLetters struct {
+	cli  redis.UniversalClient
+	take *redis.Script
+}
+
+// NewRedisDeadLetters 创建 Redis 死信存储
+func NewRedisDeadLetters(cli redis.UniversalClient) *RedisDeadLetters {
+	return &RedisDeadLetters{
+		cli:  cli,
+		take: redis.NewScript(takeDeadLuaScript),
+	}
+}
+
+// Add 添加死信任务
+func (d *RedisDeadLetters) Add(ctx context.Context, job *Job) error {
+	raw, err := encodeJob(job)
+	if err != nil {
+		return err
+	}
+
+	pipe := d.cli.TxPipeline()
+	pipe.HSet(ctx, deadKey, job.ID, raw)
+	pipe.ZAdd(ctx, deadIndexKey, redis.Z{Score: float64(job.FailedAt), Member: job.ID})
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// List 按进入死信的时间倒序分页查看死信任务
+func (d *RedisDeadLetters) List(ctx context.Context, offset, limit int) ([]*Job, int64, error) {
+	pipe := d.cli.Pipeline()
+	idsCmd := pipe.ZRevRange(ctx, deadIndexKey, int64(offset), int64(offset+limit-1))
+	totalCmd := pipe.ZCard(ctx, deadIndexKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	ids := idsCmd.Val()
+	if len(ids) == 0 {
+		return []*Job{}, totalCmd.Val(), nil
+	}
+	values, err := d.cli.HMGet(ctx, deadKey, ids...).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	jobs := make([]*Job, 0, len(values))
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		job, err := decodeJob(raw)
+		if err != nil {
+			log.Warn(ctx, "decode dead letter failed",
+				log.String("push_id", ids[i]),
+				log.String("error", err.Error()),
+			)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, totalCmd.Val(), nil
+}
+
+// Take 取出并删除指定的死信任务
+func (d *RedisDeadLetters) Take(ctx context.Context, ids []string) ([]*Job, error) {
+	if len(ids) == 0 {
+		return []*Job{}, nil
+	}
+
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	values, err := d.take.Run(ctx, d.cli, []string{deadKey, deadIndexKey}, args...).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*Job, 0, len(values))
+	for _, raw := range values {
+		job, err := decodeJob(raw)
+		if err != nil {
+			log.Warn(ctx, "decode dead letter failed", log.String("error", err.Error()))
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,184 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	pushpb "github.com/wsx864321/kim/idl/push"
+)
+
+// newTestRedis 连接 KIM_TEST_REDIS 指定的 Redis（如 127.0.0.1:6379），未设置或无法连接时跳过测试；
+// 测试会清空异步推送队列使用的 key，不要指向线上实例
+func newTestRedis(t *testing.T) redis.UniversalClient {
+	t.Helper()
+	addr := os.Getenv("KIM_TEST_REDIS")
+	if addr == "" {
+		t.Skip("KIM_TEST_REDIS is not set")
+	}
+
+	cli := redis.NewClient(&redis.Options{Addr: addr})
+	ctx := context.Background()
+	if err := cli.Ping(ctx).Err(); err != nil {
+		cli.Close()
+		t.Skipf("redis %s is unavailable: %v", addr, err)
+	}
+
+	clean := func() {
+		cli.Del(ctx, streamKey, retryKey, deadKey, deadIndexKey)
+	}
+	clean()
+	t.Cleanup(func() {
+		clean()
+		cli.Close()
+	})
+	return cli
+}
+
+func newTestJob(id string) *Job {
+	return &Job{
+		ID:        id,
+		Req:       &pushpb.PushReq{UserId: "user-" + id},
+		CreatedAt: time.Now().UnixMilli(),
+	}
+}
+
+func TestRedisQueue(t *testing.T) {
+	cli := newTestRedis(t)
+	ctx := context.Background()
+
+	q, err := NewRedisQueue(cli, "consumer-a", 3, time.Hour)
+	if err != nil {
+		t.Fatalf("create queue failed: %v", err)
+	}
+
+	for _, id := range []string{"1", "2", "3"} {
+		if err := q.Enqueue(ctx, newTestJob(id)); err != nil {
+			t.Fatalf("enqueue %s failed: %v", id, err)
+		}
+	}
+	if err := q.Enqueue(ctx, newTestJob("4")); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected queue full, got %v", err)
+	}
+
+	jobs, err := q.Dequeue(ctx, 2, 10*time.Millisecond)
+	if err != nil || len(jobs) != 2 || jobs[0].ID != "1" || jobs[1].ID != "2" || jobs[0].Req.UserId != "user-1" {
+		t.Fatalf("unexpected dequeue result: %v %v", jobs, err)
+	}
+
+	// 已取出未确认的任务仍占用队列容量，不会被裁剪
+	if err := q.Enqueue(ctx, newTestJob("4")); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("pending jobs should count towards capacity, got %v", err)
+	}
+	if n := cli.XLen(ctx, streamKey).Val(); n != 3 {
+		t.Fatalf("expected 3 jobs in stream, got %d", n)
+	}
+
+	// 确认后释放容量
+	if err := q.Ack(ctx, jobs[0]); err != nil {
+		t.Fatalf("ack failed: %v", err)
+	}
+	if err := q.Enqueue(ctx, newTestJob("4")); err != nil {
+		t.Fatalf("enqueue after ack failed: %v", err)
+	}
+
+	// 等待重试的任务同样占用容量，到期后移回任务流并保留尝试次数
+	retried := jobs[1]
+	retried.Attempts, retried.LastError = 1, "gateway unavailable"
+	if err := q.Retry(ctx, retried, time.Now().Add(-time.Millisecond)); err != nil {
+		t.Fatalf("retry failed: %v", err)
+	}
+	if err := q.Enqueue(ctx, newTestJob("5")); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("retry jobs should count towards capacity, got %v", err)
+	}
+
+	jobs, err = q.Dequeue(ctx, 3, 10*time.Millisecond)
+	if err != nil || len(jobs) != 3 {
+		t.Fatalf("unexpected dequeue result: %v %v", jobs, err)
+	}
+	got := map[string]*Job{}
+	for _, job := range jobs {
+		got[job.ID] = job
+	}
+	if job := got["2"]; job == nil || job.Attempts != 1 || job.LastError != "gateway unavailable" {
+		t.Fatalf("retried job not redelivered: %+v", got)
+	}
+	if got["3"] == nil || got["4"] == nil {
+		t.Fatalf("missing jobs: %+v", got)
+	}
+	for _, job := range jobs {
+		if err := q.Ack(ctx, job); err != nil {
+			t.Fatalf("ack failed: %v", err)
+		}
+	}
+	if n := cli.XLen(ctx, streamKey).Val(); n != 0 {
+		t.Fatalf("expected empty stream after ack, got %d", n)
+	}
+}
+
+func TestRedisQueueClaim(t *testing.T) {
+	cli := newTestRedis(t)
+	ctx := context.Background()
+
+	const claimIdle = 100 * time.Millisecond
+	a, err := NewRedisQueue(cli, "consumer-a", 10, claimIdle)
+	if err != nil {
+		t.Fatalf("create queue failed: %v", err)
+	}
+	b, err := NewRedisQueue(cli, "consumer-b", 10, claimIdle)
+	if err != nil {
+		t.Fatalf("create queue failed: %v", err)
+	}
+
+	if err := a.Enqueue(ctx, newTestJob("1")); err != nil {
+		t.Fatalf("enqueue failed: %v", err)
+	}
+	if jobs, err := a.Dequeue(ctx, 1, 10*time.Millisecond); err != nil || len(jobs) != 1 {
+		t.Fatalf("unexpected dequeue result: %v %v", jobs, err)
+	}
+
+	// 空闲未超过 claimIdle 时其他实例不会认领
+	if jobs, err := b.Dequeue(ctx, 1, 10*time.Millisecond); err != nil || len(jobs) != 0 {
+		t.Fatalf("job claimed before idle timeout: %v %v", jobs, err)
+	}
+
+	// 实例 a 未确认，超过 claimIdle 后由实例 b 认领
+	time.Sleep(claimIdle + 50*time.Millisecond)
+	jobs, err := b.Dequeue(ctx, 1, 10*time.Millisecond)
+	if err != nil || len(jobs) != 1 || jobs[0].ID != "1" {
+		t.Fatalf("expected job claimed by b: %v %v", jobs, err)
+	}
+	if err := b.Ack(ctx, jobs[0]); err != nil {
+		t.Fatalf("ack failed: %v", err)
+	}
+}
+
+func TestRedisDeadLetters(t *testing.T) {
+	cli := newTestRedis(t)
+	ctx := context.Background()
+	d := NewRedisDeadLetters(cli)
+
+	for i, id := range []string{"1", "2", "3"} {
+		job := newTestJob(id)
+		job.Attempts, job.LastError, job.FailedAt = 5, "timeout", int64(1000+i)
+		if err := d.Add(ctx, job); err != nil {
+			t.Fatalf("add dead letter failed: %v", err)
+		}
+	}
+
+	jobs, total, err := d.List(ctx, 0, 2)
+	if err != nil || total != 3 || len(jobs) != 2 || jobs[0].ID != "3" || jobs[1].ID != "2" || jobs[0].Attempts != 5 {
+		t.Fatalf("unexpected list result: %v %d %v", jobs, total, err)
+	}
+
+	jobs, err = d.Take(ctx, []string{"2", "missing"})
+	if err != nil || len(jobs) != 1 || jobs[0].ID != "2" || jobs[0].Req.UserId != "user-2" {
+		t.Fatalf("unexpected take result: %v %v", jobs, err)
+	}
+	if _, total, _ := d.List(ctx, 0, 10); total != 2 {
+		t.Fatalf("expected 2 dead letters after take, got %d", total)
+	}
+}
@@ -0,0 +1,282 @@
+package logic
+
+import (
+	"context"
+	"sync"
+
+	gatewaypb "github.com/wsx864321/kim/idl/gateway"
+	pushpb "github.com/wsx864321/kim/idl/push"
+	sessionpb "github.com/wsx864321/kim/idl/session"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+)
+
+// targetSessions 推送目标的会话查询结果
+type targetSessions struct {
+	sessions []*sessionpb.Session
+	err      *xerr.Error
+}
+
+// connResult 单个连接的推送结果
+type connResult struct {
+	code    int32
+	message string
+}
+
+// gatewayCall 一次网关 BatchPushMsg 调用，同一次调用中每个连接只出现一次
+type gatewayCall struct {
+	gatewayID string
+	connIDs   []uint64
+	conns     map[uint64]bool
+	connMsgs  map[uint64][]byte
+	results   map[uint64]connResult
+}
+
+// delivery 推送到一个连接的一份消息内容，msg 为目标单独的消息内容，为空表示 BatchPushReq.msg
+type delivery struct {
+	gatewayID string
+	connID    uint64
+	msg       string
+}
+
+// BatchPushMsg 批量推送消息：批量查询所有目标的会话，按网关分组连接，并发调用各网关的 BatchPushMsg，
+// 再把连接的推送结果汇总为每个目标的结果（至少一个连接推送成功即为成功）
+func (s *PushService) BatchPushMsg(ctx context.Context, req *pushpb.BatchPushReq) (*pushpb.BatchPushResp, *xerr.Error) {
+	sessions := s.lookupSessions(ctx, req.Targets)
+
+	// 按网关分组在线连接，同一连接的相同内容只推送一次；设置了单独消息内容的目标，其连接推送该目标的消息。
+	// 多个目标对应同一连接且内容不同时（如同一用户的多条单独消息），放入该网关的下一次调用
+	var calls []*gatewayCall
+	gatewayCalls := make(map[string][]*gatewayCall)
+	placed := make(map[delivery]*gatewayCall)
+	for i, ts := range sessions {
+		msg := req.Targets[i].Msg
+		for _, session := range ts.sessions {
+			d := delivery{gatewayID: session.GatewayId, connID: session.ConnId, msg: string(msg)}
+			if placed[d] != nil {
+				continue
+			}
+
+			var call *gatewayCall
+			for _, c := range gatewayCalls[d.gatewayID] {
+				if !c.conns[d.connID] {
+					call = c
+					break
+				}
+			}
+			if call == nil {
+				call = &gatewayCall{gatewayID: d.gatewayID, conns: make(map[uint64]bool)}
+				gatewayCalls[d.gatewayID] = append(gatewayCalls[d.gatewayID], call)
+				calls = append(calls, call)
+			}
+
+			call.conns[d.connID] = true
+			call.connIDs = append(call.connIDs, d.connID)
+			if len(msg) > 0 {
+				if call.connMsgs == nil {
+					call.connMsgs = make(map[uint64][]byte)
+				}
+				call.connMsgs[d.connID] = msg
+			}
+			placed[d] = call
+		}
+	}
+
+	s.parallel(len(calls), func(i int) {
+		call := calls[i]
+		call.results = s.batchPush(ctx, call.gatewayID, call.connIDs, req.Msg, call.connMsgs)
+	})
+
+	results := make([]*pushpb.PushResult, 0, len(req.Targets))
+	for i, target := range req.Targets {
+		result := &pushpb.PushResult{
+			UserId:   target.UserId,
+			DeviceId: target.DeviceId,
+		}
+		results = append(results, result)
+
+		if err := sessions[i].err; err != nil {
+			result.Code, result.Message = err.Code(), err.Error()
+			continue
+		}
+		if len(sessions[i].sessions) == 0 {
+			result.Code, result.Message = xerr.ErrSessionNotFound.Code(), "no online sessions found"
+			continue
+		}
+
+		result.Code, result.Message = xerr.ErrInternalServer.Code(), xerr.ErrInternalServer.Error()
+		for _, session := range sessions[i].sessions {
+			d := delivery{gatewayID: session.GatewayId, connID: session.ConnId, msg: string(target.Msg)}
+			r := placed[d].results[session.ConnId]
+			if r.code == xerr.OK.Code() {
+				result.Code, result.Message = xerr.OK.Code(), xerr.OK.Error()
+				break
+			}
+			result.Code, result.Message = r.code, r.message
+		}
+	}
+
+	return &pushpb.BatchPushResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Results: results,
+	}, nil
+}
+
+// sessionBatchSize 每次 BatchGetSessions 查询的用户数量
+const sessionBatchSize = 500
+
+// lookupSessions 查询推送目标的在线会话，返回结果与 targets 一一对应：所有用户按批调用 BatchGetSessions 查询，
+// 指定了 device_id 的目标只保留该设备的会话
+func (s *PushService) lookupSessions(ctx context.Context, targets []*pushpb.PushTarget) []targetSessions {
+	seen := make(map[string]bool, len(targets))
+	userIDs := make([]string, 0, len(targets))
+	for _, target := range targets {
+		if target.UserId == "" || seen[target.UserId] {
+			continue
+		}
+		seen[target.UserId] = true
+		userIDs = append(userIDs, target.UserId)
+	}
+
+	batches := (len(userIDs) + sessionBatchSize - 1) / sessionBatchSize
+	found := make(map[string]targetSessions, len(userIDs))
+	var mu sync.Mutex
+	s.parallel(batches, func(i int) {
+		batch := userIDs[i*sessionBatchSize : min((i+1)*sessionBatchSize, len(userIDs))]
+		sessions := s.batchOnlineSessions(ctx, batch)
+		mu.Lock()
+		defer mu.Unlock()
+		for userID, ts := range sessions {
+			found[userID] = ts
+		}
+	})
+
+	results := make([]targetSessions, len(targets))
+	for i, target := range targets {
+		if target.UserId == "" {
+			results[i] = targetSessions{err: xerr.ErrInvalidParams.WithMessage("user_id is required")}
+			continue
+		}
+		ts := found[target.UserId]
+		if ts.err != nil || target.DeviceId == "" {
+			results[i] = ts
+			continue
+		}
+		for _, session := range ts.sessions {
+			if session.DeviceId == target.DeviceId {
+				results[i].sessions = append(results[i].sessions, session)
+			}
+		}
+	}
+	return results
+}
+
+// batchOnlineSessions 批量查询用户的在线会话，查询失败时所有用户都记为失败
+func (s *PushService) batchOnlineSessions(ctx context.Context, userIDs []string) map[string]targetSessions {
+	results := make(map[string]targetSessions, len(userIDs))
+	fail := func(err *xerr.Error) map[string]targetSessions {
+		for _, userID := range userIDs {
+			results[userID] = targetSessions{err: err}
+		}
+		return results
+	}
+
+	resp, err := s.sessionClient.BatchGetSessions(ctx, &sessionpb.BatchGetSessionsReq{UserIds: userIDs})
+	if err != nil {
+		log.Error(ctx, "batch get sessions failed",
+			log.Int("users", len(userIDs)),
+			log.String("error", err.Error()),
+		)
+		return fail(xerr.ErrInternalServer.WithMessage(err.Error()))
+	}
+	if resp.Code != xerr.OK.Code() {
+		return fail(xerr.NewError(resp.Code, resp.Message))
+	}
+
+	for userID, userSessions := range resp.GetData().GetSessions() {
+		sessions := make([]*sessionpb.Session, 0, len(userSessions.Sessions))
+		for _, session := range userSessions.Sessions {
+			if session.Status == sessionpb.SessionStatus_SESSION_STATUS_ONLINE {
+				sessions = append(sessions, session)
+			}
+		}
+		results[userID] = targetSessions{sessions: sessions}
+	}
+	return results
+}
+
+// batchPush 调用网关 BatchPushMsg，connMsgs 为连接单独的消息内容，返回每个连接的推送结果；请求失败时所有连接都记为失败
+func (s *PushService) batchPush(ctx context.Context, gatewayID string, connIDs []uint64, msg []byte, connMsgs map[uint64][]byte) map[uint64]connResult {
+	results := make(map[uint64]connResult, len(connIDs))
+	fail := func(code int32, message string) map[uint64]connResult {
+		for _, connID := range connIDs {
+			results[connID] = connResult{code: code, message: message}
+		}
+		return results
+	}
+
+	gatewayClient, err := s.gatewayMgr.GetClient(gatewayID)
+	if err != nil {
+		log.Error(ctx, "get gateway client failed",
+			log.String("gateway_id", gatewayID),
+			log.String("error", err.Error()),
+		)
+		return fail(xerr.ErrServiceUnavailable.Code(), err.Error())
+	}
+
+	resp, err := gatewayClient.BatchPushMsg(ctx, &gatewaypb.BatchPushReq{
+		ConnIds:  connIDs,
+		Msg:      msg,
+		ConnMsgs: connMsgs,
+	})
+	if err != nil {
+		log.Warn(ctx, "batch push to gateway failed",
+			log.String("gateway_id", gatewayID),
+			log.Int("conns", len(connIDs)),
+			log.String("error", err.Error()),
+		)
+		return fail(xerr.ErrInternalServer.Code(), err.Error())
+	}
+	if resp.Code != xerr.OK.Code() {
+		log.Warn(ctx, "batch push to gateway failed",
+			log.String("gateway_id", gatewayID),
+			log.Int("code", int(resp.Code)),
+			log.String("message", resp.Message),
+		)
+		return fail(resp.Code, resp.Message)
+	}
+
+	for _, r := range resp.Results {
+		results[r.ConnId] = connResult{code: r.Code, message: r.Message}
+	}
+	// 网关没有返回结果的连接视为失败
+	for _, connID := range connIDs {
+		if _, ok := results[connID]; !ok {
+			results[connID] = connResult{code: xerr.ErrInternalServer.Code(), message: "no result from gateway"}
+		}
+	}
+	return results
+}
+
+// parallel 以有限并发执行 n 个任务并等待全部完成
+func (s *PushService) parallel(n int, fn func(i int)) {
+	if n == 0 {
+		return
+	}
+
+	sem := make(chan struct{}, s.batchConcurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,176 @@
+package logic
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gatewaypb "github.com/wsx864321/kim/idl/gateway"
+	pushpb "github.com/wsx864321/kim/idl/push"
+	sessionpb "github.com/wsx864321/kim/idl/session"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/grpc"
+)
+
+func (f *fakeSessionClient) BatchGetSessions(ctx context.Context, in *sessionpb.BatchGetSessionsReq) (*sessionpb.BatchGetSessionsResp, error) {
+	sessions := make(map[string]*sessionpb.UserSessions)
+	for _, userID := range in.UserIds {
+		if userSessions, ok := f.sessions[userID]; ok {
+			sessions[userID] = &sessionpb.UserSessions{Sessions: userSessions}
+		}
+	}
+	return &sessionpb.BatchGetSessionsResp{
+		Code: xerr.OK.Code(),
+		Data: &sessionpb.BatchGetSessionsData{Sessions: sessions},
+	}, nil
+}
+
+func (c *fakeGatewayClient) BatchPushMsg(ctx context.Context, in *gatewaypb.BatchPushReq, opts ...grpc.CallOption) (*gatewaypb.BatchPushResp, error) {
+	if c.inflight != nil {
+		n := c.inflight.Add(1)
+		defer c.inflight.Add(-1)
+		for {
+			m := c.maxInflight.Load()
+			if n <= m || c.maxInflight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		// 留出时间让其他网关的调用并发进入
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	c.mu.Lock()
+	c.batchReqs = append(c.batchReqs, in)
+	c.mu.Unlock()
+
+	results := make([]*gatewaypb.PushResult, 0, len(in.ConnIds))
+	for _, connID := range in.ConnIds {
+		code := xerr.OK.Code()
+		if c.fails[connID] {
+			code = xerr.ErrInternalServer.Code()
+		}
+		results = append(results, &gatewaypb.PushResult{ConnId: connID, Code: code})
+	}
+	return &gatewaypb.BatchPushResp{Code: xerr.OK.Code(), Results: results}, nil
+}
+
+func onlineSession(userID, deviceID, gatewayID string, connID uint64) *sessionpb.Session {
+	return &sessionpb.Session{
+		UserId:    userID,
+		DeviceId:  deviceID,
+		GatewayId: gatewayID,
+		ConnId:    connID,
+		Status:    sessionpb.SessionStatus_SESSION_STATUS_ONLINE,
+	}
+}
+
+func TestBatchPushMsg(t *testing.T) {
+	var inflight, maxInflight atomic.Int32
+	gw1 := &fakeGatewayClient{inflight: &inflight, maxInflight: &maxInflight}
+	gw2 := &fakeGatewayClient{inflight: &inflight, maxInflight: &maxInflight, fails: map[uint64]bool{4: true}}
+	sessionClient := &fakeSessionClient{sessions: map[string][]*sessionpb.Session{
+		"alice": {onlineSession("alice", "phone", "gw-1", 1), onlineSession("alice", "pc", "gw-2", 2)},
+		"bob":   {onlineSession("bob", "phone", "gw-1", 3)},
+		"dave":  {onlineSession("dave", "phone", "gw-2", 4)},
+		"eve":   {onlineSession("eve", "phone", "gw-x", 5)},
+		"frank": {{UserId: "frank", GatewayId: "gw-1", ConnId: 6, Status: sessionpb.SessionStatus_SESSION_STATUS_OFFLINE}},
+	}}
+	s := NewPushService(sessionClient, &fakeGatewayManager{clients: map[string]*fakeGatewayClient{"gw-1": gw1, "gw-2": gw2}})
+
+	resp, xe := s.BatchPushMsg(context.Background(), &pushpb.BatchPushReq{
+		Msg: []byte("common"),
+		Targets: []*pushpb.PushTarget{
+			{UserId: "alice"},
+			{UserId: "bob", Msg: []byte("to bob")},
+			// 同一用户的另一条单独消息，连接与第一个目标相同但内容不同
+			{UserId: "alice", Msg: []byte("to alice")},
+			// 与第一个目标的设备重复且内容相同，不再重复推送
+			{UserId: "alice", DeviceId: "phone"},
+			{UserId: "carol"},
+			{UserId: "dave"},
+			{UserId: "eve"},
+			{UserId: "frank"},
+			{},
+		},
+	})
+	if xe != nil || resp.Code != xerr.OK.Code() {
+		t.Fatalf("batch push failed: %v %v", resp, xe)
+	}
+
+	// 结果与目标一一对应
+	wantCodes := []int32{
+		xerr.OK.Code(),
+		xerr.OK.Code(),
+		xerr.OK.Code(),
+		xerr.OK.Code(),
+		xerr.ErrSessionNotFound.Code(),
+		xerr.ErrInternalServer.Code(),
+		xerr.ErrServiceUnavailable.Code(),
+		xerr.ErrSessionNotFound.Code(),
+		xerr.ErrInvalidParams.Code(),
+	}
+	if len(resp.Results) != len(wantCodes) {
+		t.Fatalf("expected %d results, got %v", len(wantCodes), resp.Results)
+	}
+	for i, result := range resp.Results {
+		if result.Code != wantCodes[i] {
+			t.Fatalf("result %d: expected code %d, got %v", i, wantCodes[i], result)
+		}
+	}
+
+	// 每个连接收到的消息内容（排序后），同一次网关调用中每个连接只出现一次
+	received := func(gw *fakeGatewayClient) map[uint64][]string {
+		got := make(map[uint64][]string)
+		for _, req := range gw.batchReqs {
+			seen := make(map[uint64]bool)
+			for _, connID := range req.ConnIds {
+				if seen[connID] {
+					t.Fatalf("conn %d appears twice in one call: %v", connID, req)
+				}
+				seen[connID] = true
+				msg, ok := req.ConnMsgs[connID]
+				if !ok {
+					msg = req.Msg
+				}
+				got[connID] = append(got[connID], string(msg))
+			}
+		}
+		// 同一网关的多次调用并发执行，不保证顺序
+		for _, msgs := range got {
+			slices.Sort(msgs)
+		}
+		return got
+	}
+	tests := []struct {
+		name  string
+		gw    *fakeGatewayClient
+		calls int
+		want  map[uint64][]string
+	}{
+		{name: "gw-1", gw: gw1, calls: 2, want: map[uint64][]string{1: {"common", "to alice"}, 3: {"to bob"}}},
+		{name: "gw-2", gw: gw2, calls: 2, want: map[uint64][]string{2: {"common", "to alice"}, 4: {"common"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.gw.batchReqs) != tt.calls {
+				t.Fatalf("expected %d calls, got %v", tt.calls, tt.gw.batchReqs)
+			}
+			got := received(tt.gw)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for connID, msgs := range tt.want {
+				if !slices.Equal(got[connID], msgs) {
+					t.Fatalf("conn %d: expected %v, got %v", connID, msgs, got[connID])
+				}
+			}
+		})
+	}
+
+	// 各网关的调用并发执行
+	if maxInflight.Load() < 2 {
+		t.Fatalf("expected parallel gateway calls, max inflight %d", maxInflight.Load())
+	}
+}
@@ -0,0 +1,104 @@
+package logic
+
+import (
+	"context"
+	"sync"
+
+	gatewaypb "github.com/wsx864321/kim/idl/gateway"
+	pushpb "github.com/wsx864321/kim/idl/push"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+)
+
+// Broadcast 广播消息到所有 Gateway 上满足过滤条件的连接，连接的匹配由各个 Gateway 在本地完成
+func (s *PushService) Broadcast(ctx context.Context, req *pushpb.BroadcastReq) (*pushpb.BroadcastData, *xerr.Error) {
+	if len(req.Msg) == 0 {
+		return nil, xerr.ErrInvalidParams.WithMessage("msg is required")
+	}
+	return s.broadcast(ctx, "", req.Filter, req.Msg), nil
+}
+
+// Publish 发布消息到主题，推送给所有 Gateway 上订阅了该主题且满足过滤条件的连接
+func (s *PushService) Publish(ctx context.Context, req *pushpb.PublishReq) (*pushpb.BroadcastData, *xerr.Error) {
+	if req.Topic == "" {
+		return nil, xerr.ErrInvalidParams.WithMessage("topic is required")
+	}
+	if len(req.Msg) == 0 {
+		return nil, xerr.ErrInvalidParams.WithMessage("msg is required")
+	}
+	return s.broadcast(ctx, req.Topic, req.Filter, req.Msg), nil
+}
+
+// broadcast 并发调用所有在线 Gateway 的 Broadcast，汇总推送成功的连接数量和失败的 Gateway
+func (s *PushService) broadcast(ctx context.Context, topic string, filter *pushpb.BroadcastFilter, msg []byte) *pushpb.BroadcastData {
+	gatewayIDs := s.gatewayMgr.GatewayIDs()
+	req := &gatewaypb.BroadcastReq{
+		Topic:  topic,
+		Filter: toGatewayFilter(filter),
+		Msg:    msg,
+	}
+
+	var mu sync.Mutex
+	data := &pushpb.BroadcastData{FailedGateways: make([]string, 0)}
+	s.parallel(len(gatewayIDs), func(i int) {
+		delivered, ok := s.broadcastGateway(ctx, gatewayIDs[i], req)
+
+		mu.Lock()
+		defer mu.Unlock()
+		data.Delivered += delivered
+		if !ok {
+			data.FailedGateways = append(data.FailedGateways, gatewayIDs[i])
+		}
+	})
+
+	log.Info(ctx, "broadcast completed",
+		log.String("topic", topic),
+		log.Int("gateways", len(gatewayIDs)),
+		log.Int64("delivered", data.Delivered),
+		log.Strings("failed_gateways", data.FailedGateways),
+	)
+	return data
+}
+
+// broadcastGateway 调用单个 Gateway 的 Broadcast，返回推送成功的连接数量以及请求是否成功
+func (s *PushService) broadcastGateway(ctx context.Context, gatewayID string, req *gatewaypb.BroadcastReq) (int64, bool) {
+	gatewayClient, err := s.gatewayMgr.GetClient(gatewayID)
+	if err != nil {
+		log.Error(ctx, "get gateway client failed",
+			log.String("gateway_id", gatewayID),
+			log.String("error", err.Error()),
+		)
+		return 0, false
+	}
+
+	resp, err := gatewayClient.Broadcast(ctx, req)
+	if err != nil {
+		log.Warn(ctx, "broadcast to gateway failed",
+			log.String("gateway_id", gatewayID),
+			log.String("error", err.Error()),
+		)
+		return 0, false
+	}
+	if resp.Code != xerr.OK.Code() {
+		log.Warn(ctx, "broadcast to gateway failed",
+			log.String("gateway_id", gatewayID),
+			log.Int("code", int(resp.Code)),
+			log.String("message", resp.Message),
+		)
+		return resp.Delivered, false
+	}
+	return resp.Delivered, true
+}
+
+// toGatewayFilter 转换广播过滤条件
+func toGatewayFilter(f *pushpb.BroadcastFilter) *gatewaypb.BroadcastFilter {
+	if f == nil {
+		return nil
+	}
+	return &gatewaypb.BroadcastFilter{
+		DeviceTypes:   f.DeviceTypes,
+		MinAppVersion: f.MinAppVersion,
+		MaxAppVersion: f.MaxAppVersion,
+		Meta:          f.Meta,
+	}
+}
@@ -0,0 +1,149 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	gatewaypb "github.com/wsx864321/kim/idl/gateway"
+	pushpb "github.com/wsx864321/kim/idl/push"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/grpc"
+)
+
+func (c *fakeGatewayClient) Broadcast(ctx context.Context, in *gatewaypb.BroadcastReq, opts ...grpc.CallOption) (*gatewaypb.BroadcastResp, error) {
+	c.mu.Lock()
+	c.broadcastReqs = append(c.broadcastReqs, in)
+	c.mu.Unlock()
+
+	if c.broadcastErr != nil {
+		return nil, c.broadcastErr
+	}
+	if code := c.code.Load(); code != xerr.OK.Code() {
+		return &gatewaypb.BroadcastResp{Code: code, Message: "broadcast failed", Delivered: c.broadcastDelivered}, nil
+	}
+	return &gatewaypb.BroadcastResp{Code: xerr.OK.Code(), Message: xerr.OK.Error(), Delivered: c.broadcastDelivered}, nil
+}
+
+// offlineGatewayManager 在线列表中包含获取不到客户端的网关
+type offlineGatewayManager struct {
+	*fakeGatewayManager
+	offline []string
+}
+
+func (m *offlineGatewayManager) GatewayIDs() []string {
+	return append(m.fakeGatewayManager.GatewayIDs(), m.offline...)
+}
+
+func TestBroadcastFailedGateways(t *testing.T) {
+	newGateway := func(delivered int64, code int32, err error) *fakeGatewayClient {
+		c := &fakeGatewayClient{broadcastDelivered: delivered, broadcastErr: err}
+		c.code.Store(code)
+		return c
+	}
+	ok := xerr.OK.Code()
+	internal := xerr.ErrInternalServer.Code()
+
+	tests := []struct {
+		name          string
+		gateways      map[string]*fakeGatewayClient
+		offline       []string
+		wantDelivered int64
+		wantFailed    []string
+	}{
+		{name: "no gateway", wantFailed: []string{}},
+		{
+			name:          "all succeed",
+			gateways:      map[string]*fakeGatewayClient{"gw-1": newGateway(3, ok, nil), "gw-2": newGateway(5, ok, nil)},
+			wantDelivered: 8,
+			wantFailed:    []string{},
+		},
+		{
+			name:          "rpc error",
+			gateways:      map[string]*fakeGatewayClient{"gw-1": newGateway(3, ok, nil), "gw-2": newGateway(5, ok, errors.New("unavailable"))},
+			wantDelivered: 3,
+			wantFailed:    []string{"gw-2"},
+		},
+		{
+			// 响应码失败时仍然计入已推送的连接数量
+			name:          "error code",
+			gateways:      map[string]*fakeGatewayClient{"gw-1": newGateway(3, internal, nil), "gw-2": newGateway(5, ok, nil)},
+			wantDelivered: 8,
+			wantFailed:    []string{"gw-1"},
+		},
+		{
+			name:          "client not found",
+			gateways:      map[string]*fakeGatewayClient{"gw-1": newGateway(3, ok, nil)},
+			offline:       []string{"gw-9"},
+			wantDelivered: 3,
+			wantFailed:    []string{"gw-9"},
+		},
+		{
+			name: "all failed",
+			gateways: map[string]*fakeGatewayClient{
+				"gw-1": newGateway(0, ok, errors.New("timeout")),
+				"gw-2": newGateway(0, internal, nil),
+			},
+			offline:    []string{"gw-3"},
+			wantFailed: []string{"gw-1", "gw-2", "gw-3"},
+		},
+	}
+
+	filter := &pushpb.BroadcastFilter{DeviceTypes: []int32{1}, MinAppVersion: "1.0", Meta: map[string]string{"region": "cn"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := &offlineGatewayManager{fakeGatewayManager: &fakeGatewayManager{clients: tt.gateways}, offline: tt.offline}
+			s := NewPushService(&fakeSessionClient{}, mgr)
+
+			data, xe := s.Publish(context.Background(), &pushpb.PublishReq{Topic: "news", Msg: []byte("hello"), Filter: filter})
+			if xe != nil {
+				t.Fatalf("publish failed: %v", xe)
+			}
+			slices.Sort(data.FailedGateways)
+			if data.Delivered != tt.wantDelivered || !slices.Equal(data.FailedGateways, tt.wantFailed) {
+				t.Fatalf("expected delivered %d failed %v, got %d %v", tt.wantDelivered, tt.wantFailed, data.Delivered, data.FailedGateways)
+			}
+
+			// 每个网关收到相同的主题、过滤条件和消息
+			for gatewayID, gw := range tt.gateways {
+				if len(gw.broadcastReqs) != 1 {
+					t.Fatalf("%s: expected 1 broadcast, got %d", gatewayID, len(gw.broadcastReqs))
+				}
+				req := gw.broadcastReqs[0]
+				if req.Topic != "news" || string(req.Msg) != "hello" || req.Filter.MinAppVersion != "1.0" ||
+					!slices.Equal(req.Filter.DeviceTypes, filter.DeviceTypes) || req.Filter.Meta["region"] != "cn" {
+					t.Fatalf("%s: unexpected broadcast request %v", gatewayID, req)
+				}
+			}
+		})
+	}
+}
+
+func TestBroadcastInvalidParams(t *testing.T) {
+	s := NewPushService(&fakeSessionClient{}, &fakeGatewayManager{})
+	tests := []struct {
+		name string
+		call func() *xerr.Error
+	}{
+		{name: "broadcast empty msg", call: func() *xerr.Error {
+			_, xe := s.Broadcast(context.Background(), &pushpb.BroadcastReq{})
+			return xe
+		}},
+		{name: "publish empty topic", call: func() *xerr.Error {
+			_, xe := s.Publish(context.Background(), &pushpb.PublishReq{Msg: []byte("hello")})
+			return xe
+		}},
+		{name: "publish empty msg", call: func() *xerr.Error {
+			_, xe := s.Publish(context.Background(), &pushpb.PublishReq{Topic: "news"})
+			return xe
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if xe := tt.call(); xe == nil || xe.Code() != xerr.ErrInvalidParams.Code() {
+				t.Fatalf("expected invalid params, got %v", xe)
+			}
+		})
+	}
+}
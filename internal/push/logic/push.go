@@ -2,27 +2,51 @@ package logic
 
 import (
 	"context"
+	"fmt"
+
 	gatewaypb "github.com/wsx864321/kim/idl/gateway"
 	pushpb "github.com/wsx864321/kim/idl/push"
 	sessionpb "github.com/wsx864321/kim/idl/session"
-	sessiongrpc "github.com/wsx864321/kim/internal/gateway/infra/grpc/session"
 	"github.com/wsx864321/kim/internal/push/infra/grpc/gateway"
+	sessiongrpc "github.com/wsx864321/kim/internal/push/infra/grpc/session"
+	"github.com/wsx864321/kim/internal/push/infra/queue"
 	"github.com/wsx864321/kim/pkg/log"
 	"github.com/wsx864321/kim/pkg/xerr"
 )
 
 // PushService Push 业务逻辑服务
 type PushService struct {
-	sessionClient sessiongrpc.ClientInterface
-	gatewayMgr    *gateway.ClientManager
+	sessionClient    sessiongrpc.ClientInterface
+	gatewayMgr       gateway.ClientManagerInterface
+	batchConcurrency int
+	queue            queue.Queue
+	deadLetters      queue.DeadLetters
+	queueCfg         QueueConfig
+}
+
+// Option PushService 配置选项
+type Option func(*PushService)
+
+// WithBatchConcurrency 设置批量推送时并发查询会话、并发请求网关的数量
+func WithBatchConcurrency(n int) Option {
+	return func(s *PushService) {
+		if n > 0 {
+			s.batchConcurrency = n
+		}
+	}
 }
 
 // NewPushService 创建 PushService 实例
-func NewPushService(sessionClient sessiongrpc.ClientInterface, gatewayMgr *gateway.ClientManager) *PushService {
-	return &PushService{
-		sessionClient: sessionClient,
-		gatewayMgr:    gatewayMgr,
+func NewPushService(sessionClient sessiongrpc.ClientInterface, gatewayMgr gateway.ClientManagerInterface, opts ...Option) *PushService {
+	s := &PushService{
+		sessionClient:    sessionClient,
+		gatewayMgr:       gatewayMgr,
+		batchConcurrency: 16,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // getSessions 获取用户会话（辅助方法）
@@ -37,8 +61,19 @@ func (s *PushService) getSessions(ctx context.Context, userID string, deviceID s
 	})
 }
 
-// PushMsg 推送消息到指定用户
+// maxDeadLetterLimit 单次查看死信任务的最大数量
+const maxDeadLetterLimit = 100
+
+// PushMsg 推送消息到指定用户，async 请求放入异步推送队列后返回推送ID
 func (s *PushService) PushMsg(ctx context.Context, req *pushpb.PushReq) (*pushpb.PushResp, *xerr.Error) {
+	if req.Async {
+		return s.enqueue(ctx, req)
+	}
+	return s.push(ctx, req)
+}
+
+// push 同步推送消息到用户的在线会话
+func (s *PushService) push(ctx context.Context, req *pushpb.PushReq) (*pushpb.PushResp, *xerr.Error) {
 
 	// 获取用户会话
 	sessionsResp, err := s.getSessions(ctx, req.UserId, req.DeviceId)
@@ -78,6 +113,11 @@ func (s *PushService) PushMsg(ctx context.Context, req *pushpb.PushReq) (*pushpb
 			continue
 		}
 
+		// 多端同步时跳过发送消息的连接
+		if req.ExcludeConnId != 0 && session.ConnId == req.ExcludeConnId {
+			continue
+		}
+
 		// 获取对应的 Gateway 客户端
 		gatewayClient, err := s.gatewayMgr.GetClient(session.GatewayId)
 		if err != nil {
@@ -90,7 +130,7 @@ func (s *PushService) PushMsg(ctx context.Context, req *pushpb.PushReq) (*pushpb
 		}
 
 		// 调用 Gateway 服务推送消息
-		_, err = gatewayClient.PushMsg(ctx, &gatewaypb.PushReq{
+		resp, err := gatewayClient.PushMsg(ctx, &gatewaypb.PushReq{
 			ConnId: session.ConnId,
 			Msg:    req.Msg,
 		})
@@ -103,6 +143,17 @@ func (s *PushService) PushMsg(ctx context.Context, req *pushpb.PushReq) (*pushpb
 			lastErr = err
 			continue
 		}
+		// 网关找不到连接、写队列已满等失败通过响应码返回
+		if resp.Code != xerr.OK.Code() {
+			log.Warn(ctx, "push message to gateway failed",
+				log.String("gateway_id", session.GatewayId),
+				log.Uint64("conn_id", session.ConnId),
+				log.Int("code", int(resp.Code)),
+				log.String("message", resp.Message),
+			)
+			lastErr = fmt.Errorf("gateway %s: %s", session.GatewayId, resp.Message)
+			continue
+		}
 
 		successCount++
 	}
@@ -126,131 +177,6 @@ func (s *PushService) PushMsg(ctx context.Context, req *pushpb.PushReq) (*pushpb
 	}, nil
 }
 
-// BatchPushMsg 批量推送消息
-func (s *PushService) BatchPushMsg(ctx context.Context, req *pushpb.BatchPushReq) (*pushpb.BatchPushResp, *xerr.Error) {
-	// 批量获取会话并推送
-	results := make([]*pushpb.PushResult, 0, len(req.Targets))
-	msgBytes := []byte(req.Msg)
-
-	for _, target := range req.Targets {
-		if target.UserId == "" {
-			results = append(results, &pushpb.PushResult{
-				UserId:   target.UserId,
-				DeviceId: target.DeviceId,
-				Code:     xerr.ErrInvalidParams.Code(),
-				Message:  "user_id is required",
-			})
-			continue
-		}
-
-		// 获取用户会话
-		sessionsResp, err := s.getSessions(ctx, target.UserId, target.DeviceId)
-		if err != nil {
-			log.Error(ctx, "get sessions failed",
-				log.String("user_id", target.UserId),
-				log.String("device_id", target.DeviceId),
-				log.String("error", err.Error()),
-			)
-			results = append(results, &pushpb.PushResult{
-				UserId:   target.UserId,
-				DeviceId: target.DeviceId,
-				Code:     xerr.ErrInternalServer.Code(),
-				Message:  err.Error(),
-			})
-			continue
-		}
-
-		if sessionsResp.Code != xerr.OK.Code() {
-			results = append(results, &pushpb.PushResult{
-				UserId:   target.UserId,
-				DeviceId: target.DeviceId,
-				Code:     sessionsResp.Code,
-				Message:  sessionsResp.Message,
-			})
-			continue
-		}
-
-		if len(sessionsResp.Data.Sessions) == 0 {
-			results = append(results, &pushpb.PushResult{
-				UserId:   target.UserId,
-				DeviceId: target.DeviceId,
-				Code:     xerr.ErrSessionNotFound.Code(),
-				Message:  "no sessions found",
-			})
-			continue
-		}
-
-		// 推送消息到所有会话
-		var lastErr error
-		successCount := 0
-		for _, session := range sessionsResp.Data.Sessions {
-			// 只推送在线状态的会话
-			if session.Status != sessionpb.SessionStatus_SESSION_STATUS_ONLINE {
-				continue
-			}
-
-			// 获取对应的 Gateway 客户端
-			gatewayClient, err := s.gatewayMgr.GetClient(session.GatewayId)
-			if err != nil {
-				log.Error(ctx, "get gateway client failed",
-					log.String("gateway_id", session.GatewayId),
-					log.String("error", err.Error()),
-				)
-				lastErr = err
-				continue
-			}
-
-			// 调用 Gateway 服务推送消息
-			_, err = gatewayClient.PushMsg(ctx, &gatewaypb.PushReq{
-				ConnId: session.ConnId,
-				Msg:    msgBytes,
-			})
-			if err != nil {
-				log.Warn(ctx, "push message to gateway failed",
-					log.String("gateway_id", session.GatewayId),
-					log.Uint64("conn_id", session.ConnId),
-					log.String("error", err.Error()),
-				)
-				lastErr = err
-				continue
-			}
-
-			successCount++
-		}
-
-		if successCount == 0 {
-			if lastErr != nil {
-				results = append(results, &pushpb.PushResult{
-					UserId:   target.UserId,
-					DeviceId: target.DeviceId,
-					Code:     xerr.ErrInternalServer.Code(),
-					Message:  lastErr.Error(),
-				})
-			} else {
-				results = append(results, &pushpb.PushResult{
-					UserId:   target.UserId,
-					DeviceId: target.DeviceId,
-					Code:     xerr.ErrSessionNotFound.Code(),
-					Message:  "no online sessions found",
-				})
-			}
-		} else {
-			results = append(results, &pushpb.PushResult{
-				UserId:   target.UserId,
-				DeviceId: target.DeviceId,
-				Code:     xerr.OK.Code(),
-				Message:  xerr.OK.Error(),
-			})
-		}
-	}
-
-	return &pushpb.BatchPushResp{
-		Code:    xerr.OK.Code(),
-		Message: xerr.OK.Error(),
-		Results: results,
-	}, nil
-}
-
 // CloseConn 关闭指定连接
 func (s *PushService) CloseConn(ctx context.Context, req *pushpb.CloseConnReq) (*pushpb.CloseConnResp, *xerr.Error) {
 	sessionsResp, err := s.sessionClient.GetSessions(ctx, &sessionpb.GetSessionsReq{
@@ -0,0 +1,277 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	pushpb "github.com/wsx864321/kim/idl/push"
+	"github.com/wsx864321/kim/internal/push/infra/queue"
+	"github.com/wsx864321/kim/pkg/log"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/protobuf/proto"
+)
+
+// QueueConfig 异步推送配置
+type QueueConfig struct {
+	// Workers 推送工作协程数量
+	Workers int
+	// BatchSize 每次从队列取出的任务数量
+	BatchSize int
+	// MaxAttempts 最多尝试次数，用尽后放入死信
+	MaxAttempts int
+	// BackoffBase 第一次重试的等待时间，之后每次翻倍
+	BackoffBase time.Duration
+	// BackoffMax 重试等待时间的上限
+	BackoffMax time.Duration
+	// Timeout 单个任务的推送超时时间
+	Timeout time.Duration
+}
+
+// withDefaults 填充未设置的配置
+func (c QueueConfig) withDefaults() QueueConfig {
+	if c.Workers <= 0 {
+		c.Workers = 8
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 16
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 5
+	}
+	if c.BackoffBase <= 0 {
+		c.BackoffBase = 500 * time.Millisecond
+	}
+	if c.BackoffMax <= 0 {
+		c.BackoffMax = time.Minute
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 5 * time.Second
+	}
+	return c
+}
+
+// backoff 第 attempts 次失败后的重试等待时间：指数退避，加上最多 20% 的随机抖动，避免大量任务同时重试
+func (c QueueConfig) backoff(attempts int) time.Duration {
+	d := c.BackoffBase
+	for i := 1; i < attempts && d < c.BackoffMax; i++ {
+		d *= 2
+	}
+	d = min(d, c.BackoffMax)
+	return d + time.Duration(rand.Int63n(int64(d)/5+1))
+}
+
+// WithQueue 开启异步推送，PushMsg 的 async 请求放入队列后由工作协程推送，失败时按退避策略重试
+func WithQueue(q queue.Queue, deadLetters queue.DeadLetters, cfg QueueConfig) Option {
+	return func(s *PushService) {
+		s.queue = q
+		s.deadLetters = deadLetters
+		s.queueCfg = cfg.withDefaults()
+	}
+}
+
+// StartWorkers 启动异步推送工作协程，ctx 取消后工作协程处理完当前任务后退出；未开启异步推送时不做任何事
+func (s *PushService) StartWorkers(ctx context.Context) {
+	if s.queue == nil {
+		return
+	}
+	for i := 0; i < s.queueCfg.Workers; i++ {
+		go s.worker(ctx)
+	}
+}
+
+// enqueue 将推送请求放入异步推送队列，返回推送ID
+func (s *PushService) enqueue(ctx context.Context, req *pushpb.PushReq) (*pushpb.PushResp, *xerr.Error) {
+	if s.queue == nil {
+		return nil, xerr.ErrInvalidParams.WithMessage("async push is not enabled")
+	}
+
+	job := &queue.Job{
+		ID:        uuid.NewString(),
+		Req:       proto.Clone(req).(*pushpb.PushReq),
+		CreatedAt: time.Now().UnixMilli(),
+	}
+	if err := s.queue.Enqueue(ctx, job); err != nil {
+		log.Error(ctx, "enqueue push job failed",
+			log.String("user_id", req.UserId),
+			log.String("error", err.Error()),
+		)
+		if errors.Is(err, queue.ErrQueueFull) {
+			return nil, xerr.ErrTooManyRequests.WithMessage(err.Error())
+		}
+		return nil, xerr.ErrInternalServer
+	}
+
+	return &pushpb.PushResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    &pushpb.PushData{PushId: job.ID},
+	}, nil
+}
+
+// worker 异步推送工作协程
+func (s *PushService) worker(ctx context.Context) {
+	for ctx.Err() == nil {
+		jobs, err := s.queue.Dequeue(ctx, s.queueCfg.BatchSize, time.Second)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Error(ctx, "dequeue push jobs failed", log.String("error", err.Error()))
+			time.Sleep(time.Second)
+			continue
+		}
+		for _, job := range jobs {
+			s.process(ctx, job)
+		}
+	}
+}
+
+// process 推送任务：成功或用户不在线时确认任务，可重试的失败按退避策略重试，重试次数用尽或不可重试时放入死信
+func (s *PushService) process(ctx context.Context, job *queue.Job) {
+	pushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.queueCfg.Timeout)
+	resp, xe := s.push(pushCtx, job.Req)
+	cancel()
+
+	// 任务确认不受 ctx 取消影响，避免退出时已推送的任务被重复投递
+	ctx = context.WithoutCancel(ctx)
+	job.Attempts++
+	retry, reason := retryable(resp, xe)
+	if reason == "" {
+		if err := s.queue.Ack(ctx, job); err != nil {
+			log.Warn(ctx, "ack push job failed",
+				log.String("push_id", job.ID),
+				log.String("error", err.Error()),
+			)
+		}
+		return
+	}
+
+	job.LastError = reason
+	if retry && job.Attempts < s.queueCfg.MaxAttempts {
+		delay := s.queueCfg.backoff(job.Attempts)
+		if err := s.queue.Retry(ctx, job, time.Now().Add(delay)); err != nil {
+			log.Error(ctx, "retry push job failed",
+				log.String("push_id", job.ID),
+				log.String("error", err.Error()),
+			)
+		}
+		log.Debug(ctx, "push job will be retried",
+			log.String("push_id", job.ID),
+			log.Int("attempts", job.Attempts),
+			log.String("delay", delay.String()),
+			log.String("reason", reason),
+		)
+		return
+	}
+
+	job.FailedAt = time.Now().UnixMilli()
+	if err := s.deadLetters.Add(ctx, job); err != nil {
+		// 死信写入失败时不确认任务，由队列重新投递
+		log.Error(ctx, "add dead letter failed",
+			log.String("push_id", job.ID),
+			log.String("error", err.Error()),
+		)
+		return
+	}
+	if err := s.queue.Ack(ctx, job); err != nil {
+		log.Warn(ctx, "ack push job failed",
+			log.String("push_id", job.ID),
+			log.String("error", err.Error()),
+		)
+	}
+	log.Warn(ctx, "push job moved to dead letters",
+		log.String("push_id", job.ID),
+		log.String("user_id", job.Req.UserId),
+		log.Int("attempts", job.Attempts),
+		log.String("reason", reason),
+	)
+}
+
+// retryable 判断推送结果：reason 为空表示处理完成（推送成功或用户不在线），否则 retry 表示失败是否可以重试
+func retryable(resp *pushpb.PushResp, xe *xerr.Error) (retry bool, reason string) {
+	code, message := xerr.OK.Code(), ""
+	if xe != nil {
+		code, message = xe.Code(), xe.Error()
+	} else if resp != nil {
+		code, message = resp.Code, resp.Message
+	}
+
+	switch code {
+	case xerr.OK.Code(), xerr.ErrSessionNotFound.Code():
+		// 用户不在线时由消息服务的收件箱保证送达，不需要重试
+		return false, ""
+	case xerr.ErrInternalServer.Code(), xerr.ErrServiceUnavailable.Code(), xerr.ErrDeadlineExceeded.Code():
+		return true, message
+	default:
+		return false, message
+	}
+}
+
+// ListDeadLetters 按进入死信的时间倒序查看死信任务
+func (s *PushService) ListDeadLetters(ctx context.Context, req *pushpb.ListDeadLettersReq) (*pushpb.ListDeadLettersData, *xerr.Error) {
+	if s.deadLetters == nil {
+		return nil, xerr.ErrInvalidParams.WithMessage("async push is not enabled")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 || limit > maxDeadLetterLimit {
+		limit = maxDeadLetterLimit
+	}
+	jobs, total, err := s.deadLetters.List(ctx, max(int(req.Offset), 0), limit)
+	if err != nil {
+		log.Error(ctx, "list dead letters failed", log.String("error", err.Error()))
+		return nil, xerr.ErrInternalServer
+	}
+
+	data := &pushpb.ListDeadLettersData{
+		DeadLetters: make([]*pushpb.DeadLetter, 0, len(jobs)),
+		Total:       total,
+	}
+	for _, job := range jobs {
+		data.DeadLetters = append(data.DeadLetters, &pushpb.DeadLetter{
+			PushId:    job.ID,
+			Req:       job.Req,
+			Attempts:  int32(job.Attempts),
+			LastError: job.LastError,
+			CreatedAt: job.CreatedAt,
+			FailedAt:  job.FailedAt,
+		})
+	}
+	return data, nil
+}
+
+// ReplayDeadLetters 将死信任务重置尝试次数后重新放入异步推送队列，推送ID保持不变
+func (s *PushService) ReplayDeadLetters(ctx context.Context, req *pushpb.ReplayDeadLettersReq) (*pushpb.ReplayDeadLettersData, *xerr.Error) {
+	if s.deadLetters == nil {
+		return nil, xerr.ErrInvalidParams.WithMessage("async push is not enabled")
+	}
+
+	jobs, err := s.deadLetters.Take(ctx, req.PushIds)
+	if err != nil {
+		log.Error(ctx, "take dead letters failed", log.String("error", err.Error()))
+		return nil, xerr.ErrInternalServer
+	}
+
+	data := &pushpb.ReplayDeadLettersData{Replayed: make([]string, 0, len(jobs))}
+	for _, job := range jobs {
+		job.Attempts, job.LastError, job.FailedAt = 0, "", 0
+		if err := s.queue.Enqueue(ctx, job); err != nil {
+			log.Error(ctx, "replay dead letter failed",
+				log.String("push_id", job.ID),
+				log.String("error", err.Error()),
+			)
+			// 重新入队失败时放回死信，避免任务丢失
+			job.FailedAt = time.Now().UnixMilli()
+			job.LastError = err.Error()
+			if err := s.deadLetters.Add(ctx, job); err != nil {
+				log.Error(ctx, "restore dead letter failed", log.String("push_id", job.ID), log.String("error", err.Error()))
+			}
+			continue
+		}
+		data.Replayed = append(data.Replayed, job.ID)
+	}
+	return data, nil
+}
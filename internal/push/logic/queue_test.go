@@ -0,0 +1,204 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gatewaypb "github.com/wsx864321/kim/idl/gateway"
+	pushpb "github.com/wsx864321/kim/idl/push"
+	sessionpb "github.com/wsx864321/kim/idl/session"
+	"github.com/wsx864321/kim/internal/push/infra/grpc/gateway"
+	sessiongrpc "github.com/wsx864321/kim/internal/push/infra/grpc/session"
+	"github.com/wsx864321/kim/internal/push/infra/queue"
+	"github.com/wsx864321/kim/pkg/xerr"
+	"google.golang.org/grpc"
+)
+
+type fakeSessionClient struct {
+	sessiongrpc.ClientInterface
+
+	fail  atomic.Bool
+	calls atomic.Int32
+	// sessions 每个用户的会话，不区分设备
+	sessions map[string][]*sessionpb.Session
+}
+
+func (f *fakeSessionClient) GetSessions(ctx context.Context, in *sessionpb.GetSessionsReq) (*sessionpb.GetSessionsResp, error) {
+	f.calls.Add(1)
+	if f.fail.Load() {
+		return nil, errors.New("session service unavailable")
+	}
+	return &sessionpb.GetSessionsResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    &sessionpb.GetSessionsData{Sessions: f.sessions[in.UserId]},
+	}, nil
+}
+
+// fakeGatewayManager 按 gateway_id 返回内存中的网关客户端
+type fakeGatewayManager struct {
+	clients map[string]*fakeGatewayClient
+}
+
+func (m *fakeGatewayManager) GetClient(gatewayID string) (gatewaypb.GatewayServiceClient, error) {
+	c, ok := m.clients[gatewayID]
+	if !ok {
+		return nil, gateway.ErrGatewayNotFound
+	}
+	return c, nil
+}
+
+func (m *fakeGatewayManager) GatewayIDs() []string {
+	ids := make([]string, 0, len(m.clients))
+	for gatewayID := range m.clients {
+		ids = append(ids, gatewayID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+type fakeGatewayClient struct {
+	gatewaypb.GatewayServiceClient
+
+	// code PushMsg 返回的响应码
+	code      atomic.Int32
+	pushes    atomic.Int32
+	delivered atomic.Int32
+
+	// fails BatchPushMsg 推送失败的连接
+	fails map[uint64]bool
+	// inflight、maxInflight 所有网关共享的并发调用计数
+	inflight, maxInflight *atomic.Int32
+	mu                    sync.Mutex
+	batchReqs             []*gatewaypb.BatchPushReq
+
+	// broadcastDelivered、broadcastErr Broadcast 返回的连接数量和错误，响应码同样使用 code
+	broadcastDelivered int64
+	broadcastErr       error
+	broadcastReqs      []*gatewaypb.BroadcastReq
+}
+
+func (c *fakeGatewayClient) PushMsg(ctx context.Context, in *gatewaypb.PushReq, opts ...grpc.CallOption) (*gatewaypb.PushResp, error) {
+	c.pushes.Add(1)
+	if code := c.code.Load(); code != xerr.OK.Code() {
+		return &gatewaypb.PushResp{Code: code, Message: "conn not found"}, nil
+	}
+	c.delivered.Add(1)
+	return &gatewaypb.PushResp{Code: xerr.OK.Code(), Message: xerr.OK.Error()}, nil
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestAsyncPushDeadLetterReplay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sessionClient := &fakeSessionClient{}
+	sessionClient.fail.Store(true)
+	deadLetters := queue.NewMemoryDeadLetters()
+	s := NewPushService(sessionClient, nil, WithQueue(queue.NewMemoryQueue(), deadLetters, QueueConfig{
+		Workers:     2,
+		MaxAttempts: 3,
+		BackoffBase: 10 * time.Millisecond,
+		BackoffMax:  20 * time.Millisecond,
+	}))
+	s.StartWorkers(ctx)
+
+	resp, xe := s.PushMsg(ctx, &pushpb.PushReq{UserId: "alice", Async: true})
+	if xe != nil || resp.Data.GetPushId() == "" {
+		t.Fatalf("async push failed: %v", xe)
+	}
+	pushID := resp.Data.PushId
+
+	// 会话服务一直失败，重试次数用尽后进入死信
+	var data *pushpb.ListDeadLettersData
+	waitFor(t, func() bool {
+		data, xe = s.ListDeadLetters(ctx, &pushpb.ListDeadLettersReq{})
+		return xe == nil && data.Total == 1
+	})
+	if dl := data.DeadLetters[0]; dl.PushId != pushID || dl.Attempts != 3 || dl.LastError == "" || dl.Req.UserId != "alice" {
+		t.Fatalf("unexpected dead letter: %v", dl)
+	}
+	if n := sessionClient.calls.Load(); n != 3 {
+		t.Fatalf("expected 3 attempts, got %d", n)
+	}
+
+	// 会话服务恢复后重放死信，推送ID不变，用户不在线时任务处理完成
+	sessionClient.fail.Store(false)
+	replayed, xe := s.ReplayDeadLetters(ctx, &pushpb.ReplayDeadLettersReq{PushIds: []string{pushID, "unknown"}})
+	if xe != nil || len(replayed.Replayed) != 1 || replayed.Replayed[0] != pushID {
+		t.Fatalf("unexpected replay result: %v, %v", replayed, xe)
+	}
+	waitFor(t, func() bool { return sessionClient.calls.Load() == 4 })
+
+	time.Sleep(50 * time.Millisecond)
+	data, xe = s.ListDeadLetters(ctx, &pushpb.ListDeadLettersReq{})
+	if xe != nil || data.Total != 0 || sessionClient.calls.Load() != 4 {
+		t.Fatalf("replayed job should be done, dead letters %v, calls %d", data, sessionClient.calls.Load())
+	}
+}
+
+func TestAsyncPushNotEnabled(t *testing.T) {
+	s := NewPushService(&fakeSessionClient{}, nil)
+	_, xe := s.PushMsg(context.Background(), &pushpb.PushReq{UserId: "alice", Async: true})
+	if xe == nil || xe.Code() != xerr.ErrInvalidParams.Code() {
+		t.Fatalf("expected invalid params, got %v", xe)
+	}
+}
+
+func TestAsyncPushGatewayFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sessionClient := &fakeSessionClient{sessions: map[string][]*sessionpb.Session{
+		"alice": {{UserId: "alice", GatewayId: "gw-1", ConnId: 1, Status: sessionpb.SessionStatus_SESSION_STATUS_ONLINE}},
+	}}
+	gw := &fakeGatewayClient{}
+	gw.code.Store(xerr.ErrInternalServer.Code())
+	s := NewPushService(sessionClient, &fakeGatewayManager{clients: map[string]*fakeGatewayClient{"gw-1": gw}},
+		WithQueue(queue.NewMemoryQueue(), queue.NewMemoryDeadLetters(), QueueConfig{
+			Workers:     1,
+			MaxAttempts: 5,
+			BackoffBase: 10 * time.Millisecond,
+			BackoffMax:  20 * time.Millisecond,
+		}))
+
+	// 网关返回失败响应码时同步推送失败
+	resp, xe := s.PushMsg(ctx, &pushpb.PushReq{UserId: "alice", Msg: []byte("hi")})
+	if xe != nil || resp.Code != xerr.ErrInternalServer.Code() {
+		t.Fatalf("expected internal server error, got %v %v", resp, xe)
+	}
+
+	// 异步推送在网关恢复前重试，恢复后处理完成
+	s.StartWorkers(ctx)
+	if _, xe := s.PushMsg(ctx, &pushpb.PushReq{UserId: "alice", Msg: []byte("hi"), Async: true}); xe != nil {
+		t.Fatalf("async push failed: %v", xe)
+	}
+	waitFor(t, func() bool { return gw.pushes.Load() >= 3 })
+	gw.code.Store(xerr.OK.Code())
+	waitFor(t, func() bool { return gw.delivered.Load() == 1 })
+
+	n := gw.pushes.Load()
+	time.Sleep(50 * time.Millisecond)
+	data, xe := s.ListDeadLetters(ctx, &pushpb.ListDeadLettersReq{})
+	if xe != nil || data.Total != 0 {
+		t.Fatalf("expected no dead letters, got %v %v", data, xe)
+	}
+	if pushes := gw.pushes.Load(); pushes != n || gw.delivered.Load() != 1 {
+		t.Fatalf("expected push to stop after success, got %d pushes after %d", pushes, n)
+	}
+}
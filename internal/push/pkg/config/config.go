@@ -29,6 +29,125 @@ func GetPushServicePort() int {
 	return port
 }
 
+// GetBatchConcurrency 获取批量推送时并发查询会话、并发请求网关的数量
+func GetBatchConcurrency() int {
+	n := viper.GetInt("push.batch_concurrency")
+	if n <= 0 {
+		return 16
+	}
+	return n
+}
+
+// GetQueueEnable 获取是否开启异步推送队列
+func GetQueueEnable() bool {
+	return viper.GetBool("push.queue.enable")
+}
+
+// GetQueueStorage 获取异步推送队列的存储类型（redis、memory），memory 只用于测试和单机部署
+func GetQueueStorage() string {
+	storage := viper.GetString("push.queue.storage")
+	if storage == "" {
+		return "redis"
+	}
+	return storage
+}
+
+// GetQueueWorkers 获取异步推送工作协程数量
+func GetQueueWorkers() int {
+	n := viper.GetInt("push.queue.workers")
+	if n <= 0 {
+		return 8
+	}
+	return n
+}
+
+// GetQueueBatchSize 获取每次从队列取出的任务数量
+func GetQueueBatchSize() int {
+	n := viper.GetInt("push.queue.batch_size")
+	if n <= 0 {
+		return 16
+	}
+	return n
+}
+
+// GetQueueMaxAttempts 获取异步推送最多尝试次数，用尽后放入死信
+func GetQueueMaxAttempts() int {
+	n := viper.GetInt("push.queue.max_attempts")
+	if n <= 0 {
+		return 5
+	}
+	return n
+}
+
+// GetQueueBackoffBase 获取第一次重试的等待时间（毫秒），之后每次翻倍
+func GetQueueBackoffBase() int {
+	n := viper.GetInt("push.queue.backoff_base")
+	if n <= 0 {
+		return 500
+	}
+	return n
+}
+
+// GetQueueBackoffMax 获取重试等待时间的上限（毫秒）
+func GetQueueBackoffMax() int {
+	n := viper.GetInt("push.queue.backoff_max")
+	if n <= 0 {
+		return 60000
+	}
+	return n
+}
+
+// GetQueueTimeout 获取单个异步推送任务的超时时间（毫秒）
+func GetQueueTimeout() int {
+	n := viper.GetInt("push.queue.timeout")
+	if n <= 0 {
+		return 5000
+	}
+	return n
+}
+
+// GetQueueClaimIdle 获取未确认任务被其他实例认领前的空闲时间（秒），需要大于 batch_size * timeout
+func GetQueueClaimIdle() int {
+	n := viper.GetInt("push.queue.claim_idle")
+	if n <= 0 {
+		return 120
+	}
+	return n
+}
+
+// GetQueueMaxLen 获取队列中的最大任务数量（包括未确认和等待重试的任务），达到后拒绝入队
+func GetQueueMaxLen() int64 {
+	n := viper.GetInt64("push.queue.max_len")
+	if n <= 0 {
+		return 1000000
+	}
+	return n
+}
+
+// GetRedisEndpoint 获取 Redis 连接地址
+func GetRedisEndpoint() string {
+	return viper.GetString("push.redis.endpoint")
+}
+
+// GetRedisPassword 获取 Redis 密码
+func GetRedisPassword() string {
+	return viper.GetString("push.redis.password")
+}
+
+// GetRedisDB 获取 Redis 数据库编号
+func GetRedisDB() int {
+	return viper.GetInt("push.redis.db")
+}
+
+// GetRedisPoolSize 获取 Redis 连接池大小
+func GetRedisPoolSize() int {
+	n := viper.GetInt("push.redis.pool_size")
+	if n <= 0 {
+		return 10
+	}
+	return n
+}
+
 // GetLogDebug 获取日志 Debug 模式配置
 func GetLogDebug() bool {
 	return viper.GetBool("log.debug")
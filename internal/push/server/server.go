@@ -2,10 +2,16 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
 	pushpb "github.com/wsx864321/kim/idl/push"
 	"github.com/wsx864321/kim/internal/push/handler"
 	"github.com/wsx864321/kim/internal/push/infra/grpc/gateway"
 	"github.com/wsx864321/kim/internal/push/infra/grpc/session"
+	"github.com/wsx864321/kim/internal/push/infra/queue"
 	"github.com/wsx864321/kim/internal/push/logic"
 	"github.com/wsx864321/kim/internal/push/pkg/config"
 	"github.com/wsx864321/kim/pkg/krpc"
@@ -32,14 +38,23 @@ func Run(configPath string) {
 	// 创建注册中心
 	r := createEtcdRegistry()
 
+	// 创建 Push Service 并启动异步推送工作协程
+	pushService := createPushService(r)
+	workerCtx, stopWorkers := context.WithCancel(ctx)
+	pushService.StartWorkers(workerCtx)
+
 	// 创建 Push Handler
-	pushHandler := createPushHandler(r)
+	pushHandler := handler.NewPushHandler(pushService)
 
 	// 创建 gRPC 服务器
 	grpcServer := krpc.NewPServer(
 		krpc.WithServiceName(config.GetPushServiceName()),
 		krpc.WithPort(config.GetPushServicePort()),
 		krpc.WithRegistry(r),
+		// 收到退出信号后停止从队列取任务，未确认的任务由其他实例认领
+		krpc.WithBeforeStop(func(ctx context.Context) {
+			stopWorkers()
+		}),
 	)
 
 	// 注册 Push gRPC 服务
@@ -56,20 +71,86 @@ func Run(configPath string) {
 	grpcServer.Start(ctx)
 }
 
-// createPushHandler 创建 PushHandler 实例
-func createPushHandler(r registry.Registrar) *handler.PushHandler {
-	// 创建 Push Service
-	pushService := logic.NewPushService(
+// createPushService 创建 PushService 实例
+func createPushService(r registry.Registrar) *logic.PushService {
+	opts := []logic.Option{
+		logic.WithBatchConcurrency(config.GetBatchConcurrency()),
+	}
+	if config.GetQueueEnable() {
+		q, deadLetters := createQueue()
+		opts = append(opts, logic.WithQueue(q, deadLetters, logic.QueueConfig{
+			Workers:     config.GetQueueWorkers(),
+			BatchSize:   config.GetQueueBatchSize(),
+			MaxAttempts: config.GetQueueMaxAttempts(),
+			BackoffBase: time.Duration(config.GetQueueBackoffBase()) * time.Millisecond,
+			BackoffMax:  time.Duration(config.GetQueueBackoffMax()) * time.Millisecond,
+			Timeout:     time.Duration(config.GetQueueTimeout()) * time.Millisecond,
+		}))
+	}
+
+	return logic.NewPushService(
 		session.NewClient(r),
-		createGatewayManager(),
+		createGatewayManager(r),
+		opts...,
 	)
+}
+
+// createQueue 根据配置创建异步推送队列和死信存储
+func createQueue() (queue.Queue, queue.DeadLetters) {
+	switch config.GetQueueStorage() {
+	case "memory":
+		return queue.NewMemoryQueue(), queue.NewMemoryDeadLetters()
+	case "redis":
+		// 一个工作协程串行处理一批任务，认领时间不超过一批任务的最长处理时间时，处理中的任务会被其他实例认领并重复推送
+		claimIdle := time.Duration(config.GetQueueClaimIdle()) * time.Second
+		batchTimeout := time.Duration(config.GetQueueBatchSize()) * time.Duration(config.GetQueueTimeout()) * time.Millisecond
+		if claimIdle <= batchTimeout {
+			panic(fmt.Sprintf("push.queue.claim_idle (%s) must be greater than batch_size * timeout (%s)", claimIdle, batchTimeout))
+		}
 
-	return handler.NewPushHandler(pushService)
+		cli := createRedisClient()
+		q, err := queue.NewRedisQueue(
+			cli,
+			queueConsumer(),
+			config.GetQueueMaxLen(),
+			claimIdle,
+		)
+		if err != nil {
+			panic(err)
+		}
+		return q, queue.NewRedisDeadLetters(cli)
+	default:
+		panic("unsupported push.queue.storage: " + config.GetQueueStorage())
+	}
+}
+
+// queueConsumer 当前实例在消费组中的消费者名称
+func queueConsumer() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// createRedisClient 创建 Redis 客户端
+func createRedisClient() redis.UniversalClient {
+	endpoint := config.GetRedisEndpoint()
+	if endpoint == "" {
+		panic("push.redis.endpoint is required")
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     endpoint,
+		Password: config.GetRedisPassword(),
+		DB:       config.GetRedisDB(),
+		PoolSize: config.GetRedisPoolSize(),
+	})
 }
 
-// createGatewayManager 创建 Gateway 客户端管理器
-func createGatewayManager() *gateway.ClientManager {
-	return gateway.NewClientManager()
+// createGatewayManager 创建 Gateway 客户端管理器，按会话的 gateway_id 直连对应的 Gateway 实例
+func createGatewayManager(r registry.Registrar) gateway.ClientManagerInterface {
+	return gateway.NewClientManager(r)
 }
 
 // createEtcdRegistry 创建 Etcd 注册中心
@@ -97,6 +97,31 @@ func (s *SessionHandler) GetSessions(ctx context.Context, req *sessionpb.GetSess
 	}, nil
 }
 
+// BatchGetSessions 批量获取多个用户的会话列表
+func (s *SessionHandler) BatchGetSessions(ctx context.Context, req *sessionpb.BatchGetSessionsReq) (*sessionpb.BatchGetSessionsResp, error) {
+	if len(req.UserIds) == 0 {
+		log.Warn(ctx, "user_ids is required")
+		return &sessionpb.BatchGetSessionsResp{
+			Code:    xerr.ErrInvalidParams.Code(),
+			Message: xerr.ErrInvalidParams.Error(),
+		}, nil
+	}
+
+	resp, err := s.service.BatchGetSessions(ctx, req)
+	if err != nil {
+		return &sessionpb.BatchGetSessionsResp{
+			Code:    err.Code(),
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &sessionpb.BatchGetSessionsResp{
+		Code:    xerr.OK.Code(),
+		Message: xerr.OK.Error(),
+		Data:    resp,
+	}, nil
+}
+
 // Kick 踢人
 func (s *SessionHandler) Kick(ctx context.Context, req *sessionpb.KickReq) (*sessionpb.KickResp, error) {
 	if req.UserId == "" {
@@ -12,6 +12,8 @@ type InstanceInterface interface {
 	GetSession(ctx context.Context, userID, deviceID string) (*sessionpb.Session, error)
 	// GetSessionsByUserID 获取用户所有会话
 	GetSessionsByUserID(ctx context.Context, userID string) ([]*sessionpb.Session, error)
+	// GetSessionsByUserIDs 批量获取多个用户的所有会话，返回按用户ID分组的会话，没有会话的用户不返回
+	GetSessionsByUserIDs(ctx context.Context, userIDs []string) (map[string][]*sessionpb.Session, error)
 	// DeleteSession 删除会话
 	DeleteSession(ctx context.Context, userID, deviceID string) error
 	// DeleteSessionsByUserID 删除用户所有会话
@@ -25,6 +25,9 @@ const (
 
 	// sessionExpire 会话过期时间，200 秒
 	sessionExpire = 200 * time.Second
+
+	// batchGetSessionsSize 批量获取会话时每个 pipeline 包含的用户数量
+	batchGetSessionsSize = 500
 )
 
 // StoreSession 存储Session（使用Lua脚本保证原子性）
@@ -81,14 +84,59 @@ func (i *Instance) GetSessionsByUserID(ctx context.Context, userID string) ([]*s
 		return nil, fmt.Errorf("get sessions by user id failed: %w", err)
 	}
 
-	// 解析结果
-	resultSlice, ok := result.([]interface{})
-	if !ok {
-		return []*sessionpb.Session{}, nil
+	return parseSessions(ctx, userID, result), nil
+}
+
+// GetSessionsByUserIDs 批量获取多个用户的所有会话：每个用户执行一次 getSessionsByUserIDLuaScript，
+// 通过 pipeline 一次发送。脚本只访问同一个 {user_id} 哈希标签下的 key，在 Redis Cluster 中也落在同一个槽
+func (i *Instance) GetSessionsByUserIDs(ctx context.Context, userIDs []string) (map[string][]*sessionpb.Session, error) {
+	sessions := make(map[string][]*sessionpb.Session, len(userIDs))
+	for start := 0; start < len(userIDs); start += batchGetSessionsSize {
+		end := min(start+batchGetSessionsSize, len(userIDs))
+		if err := i.getSessionsByUserIDs(ctx, userIDs[start:end], sessions); err != nil {
+			return nil, err
+		}
+	}
+	return sessions, nil
+}
+
+// getSessionsByUserIDs 通过一次 pipeline 获取一批用户的会话，脚本未加载时加载后重试一次
+func (i *Instance) getSessionsByUserIDs(ctx context.Context, userIDs []string, sessions map[string][]*sessionpb.Session) error {
+	run := func() ([]*redis.Cmd, error) {
+		pipe := i.redis.Pipeline()
+		cmds := make([]*redis.Cmd, 0, len(userIDs))
+		for _, userID := range userIDs {
+			cmds = append(cmds, i.getSessionsByUserIDLuaScript.EvalSha(ctx, pipe, []string{buildUserSessionsSetKey(userID)}, userID))
+		}
+		_, err := pipe.Exec(ctx)
+		return cmds, err
+	}
+
+	cmds, err := run()
+	if err != nil && redis.HasErrorPrefix(err, "NOSCRIPT") {
+		if err := i.getSessionsByUserIDLuaScript.Load(ctx, i.redis).Err(); err != nil {
+			return fmt.Errorf("load get sessions script failed: %w", err)
+		}
+		cmds, err = run()
+	}
+	if err != nil {
+		return fmt.Errorf("get sessions by user ids failed: %w", err)
 	}
 
-	if len(resultSlice) == 0 {
-		return []*sessionpb.Session{}, nil
+	for idx, cmd := range cmds {
+		userSessions := parseSessions(ctx, userIDs[idx], cmd.Val())
+		if len(userSessions) > 0 {
+			sessions[userIDs[idx]] = userSessions
+		}
+	}
+	return nil
+}
+
+// parseSessions 解析 getSessionsByUserIDLuaScript 返回的会话数据
+func parseSessions(ctx context.Context, userID string, result interface{}) []*sessionpb.Session {
+	resultSlice, ok := result.([]interface{})
+	if !ok || len(resultSlice) == 0 {
+		return []*sessionpb.Session{}
 	}
 
 	// 反序列化所有session
@@ -110,7 +158,7 @@ func (i *Instance) GetSessionsByUserID(ctx context.Context, userID string) ([]*s
 		sessions = append(sessions, &session)
 	}
 
-	return sessions, nil
+	return sessions
 }
 
 // DeleteSession 删除会话（使用Lua脚本保证原子性）
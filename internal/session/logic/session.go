@@ -47,6 +47,8 @@ func (s *SessionService) Login(ctx context.Context, auth *sessionpb.AuthInfo, re
 		LastActiveAt: now,
 		ExpireAt:     claim.ExpireTime,
 		Meta:         auth.GetMeta(),
+		AppVersion:   auth.GetAppVersion(),
+		Topics:       auth.GetTopics(),
 	}
 	err = s.redis.StoreSession(ctx, session)
 	if err != nil {
@@ -124,6 +126,75 @@ func (s *SessionService) GetSessions(ctx context.Context, req *sessionpb.GetSess
 	}, nil
 }
 
+// maxBatchGetUsers 单次批量获取会话的最大用户数量
+const maxBatchGetUsers = 2000
+
+// BatchGetSessions 批量获取多个用户的会话列表，按 device_filter 过滤
+func (s *SessionService) BatchGetSessions(ctx context.Context, req *sessionpb.BatchGetSessionsReq) (*sessionpb.BatchGetSessionsData, *xerr.Error) {
+	if len(req.UserIds) > maxBatchGetUsers {
+		return nil, xerr.ErrInvalidParams.WithMessage("too many user_ids")
+	}
+
+	// 去重并忽略空的 user_id
+	seen := make(map[string]bool, len(req.UserIds))
+	userIDs := make([]string, 0, len(req.UserIds))
+	for _, userID := range req.UserIds {
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		userIDs = append(userIDs, userID)
+	}
+
+	sessions, err := s.redis.GetSessionsByUserIDs(ctx, userIDs)
+	if err != nil {
+		log.Error(ctx, "get sessions by user ids failed",
+			log.String("err", err.Error()),
+			log.Int("users", len(userIDs)),
+		)
+		return nil, xerr.ErrInternalServer
+	}
+
+	data := &sessionpb.BatchGetSessionsData{
+		Sessions: make(map[string]*sessionpb.UserSessions, len(sessions)),
+	}
+	for userID, userSessions := range sessions {
+		filtered := filterSessions(userSessions, req.DeviceFilter)
+		if len(filtered) > 0 {
+			data.Sessions[userID] = &sessionpb.UserSessions{Sessions: filtered}
+		}
+	}
+	return data, nil
+}
+
+// filterSessions 按设备ID和设备类型过滤会话
+func filterSessions(sessions []*sessionpb.Session, filter *sessionpb.DeviceFilter) []*sessionpb.Session {
+	if len(filter.GetDeviceIds()) == 0 && len(filter.GetDeviceTypes()) == 0 {
+		return sessions
+	}
+
+	deviceIDs := make(map[string]bool, len(filter.GetDeviceIds()))
+	for _, deviceID := range filter.GetDeviceIds() {
+		deviceIDs[deviceID] = true
+	}
+	deviceTypes := make(map[sessionpb.DeviceType]bool, len(filter.GetDeviceTypes()))
+	for _, deviceType := range filter.GetDeviceTypes() {
+		deviceTypes[deviceType] = true
+	}
+
+	filtered := make([]*sessionpb.Session, 0, len(sessions))
+	for _, session := range sessions {
+		if len(deviceIDs) > 0 && !deviceIDs[session.DeviceId] {
+			continue
+		}
+		if len(deviceTypes) > 0 && !deviceTypes[session.DeviceType] {
+			continue
+		}
+		filtered = append(filtered, session)
+	}
+	return filtered
+}
+
 // Kick 踢掉用户会话
 func (s *SessionService) Kick(ctx context.Context, req *sessionpb.KickReq) *xerr.Error {
 	var err error
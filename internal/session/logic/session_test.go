@@ -0,0 +1,65 @@
+package logic
+
+import (
+	"context"
+	"testing"
+
+	sessionpb "github.com/wsx864321/kim/idl/session"
+	"github.com/wsx864321/kim/internal/session/infra/redis"
+)
+
+type fakeInstance struct {
+	redis.InstanceInterface
+
+	sessions map[string][]*sessionpb.Session
+	calls    [][]string
+}
+
+func (f *fakeInstance) GetSessionsByUserIDs(ctx context.Context, userIDs []string) (map[string][]*sessionpb.Session, error) {
+	f.calls = append(f.calls, userIDs)
+	result := make(map[string][]*sessionpb.Session)
+	for _, userID := range userIDs {
+		if sessions := f.sessions[userID]; len(sessions) > 0 {
+			result[userID] = sessions
+		}
+	}
+	return result, nil
+}
+
+func TestBatchGetSessions(t *testing.T) {
+	ctx := context.Background()
+	instance := &fakeInstance{sessions: map[string][]*sessionpb.Session{
+		"alice": {
+			{UserId: "alice", DeviceId: "phone", DeviceType: sessionpb.DeviceType_DEVICE_TYPE_MOBILE},
+			{UserId: "alice", DeviceId: "web", DeviceType: sessionpb.DeviceType_DEVICE_TYPE_WEB},
+		},
+		"bob": {
+			{UserId: "bob", DeviceId: "pc", DeviceType: sessionpb.DeviceType_DEVICE_TYPE_PC},
+		},
+	}}
+	s := NewSessionService(instance)
+
+	// 重复和空的 user_id 只查询一次，没有会话的用户不返回
+	data, xe := s.BatchGetSessions(ctx, &sessionpb.BatchGetSessionsReq{UserIds: []string{"alice", "bob", "alice", "", "carol"}})
+	if xe != nil {
+		t.Fatalf("BatchGetSessions failed: %v", xe)
+	}
+	if len(instance.calls) != 1 || len(instance.calls[0]) != 3 {
+		t.Fatalf("unexpected redis calls: %v", instance.calls)
+	}
+	if len(data.Sessions) != 2 || len(data.Sessions["alice"].Sessions) != 2 || len(data.Sessions["bob"].Sessions) != 1 {
+		t.Fatalf("unexpected sessions: %v", data.Sessions)
+	}
+
+	// 按设备类型过滤，过滤后没有会话的用户不返回
+	data, xe = s.BatchGetSessions(ctx, &sessionpb.BatchGetSessionsReq{
+		UserIds:      []string{"alice", "bob"},
+		DeviceFilter: &sessionpb.DeviceFilter{DeviceTypes: []sessionpb.DeviceType{sessionpb.DeviceType_DEVICE_TYPE_WEB}},
+	})
+	if xe != nil {
+		t.Fatalf("BatchGetSessions failed: %v", xe)
+	}
+	if len(data.Sessions) != 1 || data.Sessions["alice"].Sessions[0].DeviceId != "web" {
+		t.Fatalf("unexpected filtered sessions: %v", data.Sessions)
+	}
+}
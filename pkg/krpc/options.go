@@ -1,15 +1,22 @@
 package krpc
 
 import (
+	"context"
+	"time"
+
 	"github.com/wsx864321/kim/pkg/krpc/registry"
 	"google.golang.org/grpc"
 )
 
 type serverOptions struct {
-	serviceName string
-	port        int
-	weight      int
-	registry    registry.Registrar
+	serviceName         string
+	port                int
+	weight              int
+	metadata            map[string]string
+	registry            registry.Registrar
+	drain               []func(ctx context.Context)
+	beforeStop          []func(ctx context.Context)
+	gracefulStopTimeout time.Duration
 }
 
 type clientOptions struct {
@@ -45,6 +52,13 @@ func WithWeight(weight int) ServerOption {
 	}
 }
 
+// WithMetadata 注册到注册中心的节点元数据
+func WithMetadata(metadata map[string]string) ServerOption {
+	return func(opts *serverOptions) {
+		opts.metadata = metadata
+	}
+}
+
 // WithRegistry set registry
 func WithRegistry(registry registry.Registrar) ServerOption {
 	return func(opts *serverOptions) {
@@ -52,6 +66,27 @@ func WithRegistry(registry registry.Registrar) ServerOption {
 	}
 }
 
+// WithDrain 服务停止时的排空钩子，在取消注册之前按顺序执行，执行期间节点仍可被发现（如网关排空连接）
+func WithDrain(fns ...func(ctx context.Context)) ServerOption {
+	return func(opts *serverOptions) {
+		opts.drain = append(opts.drain, fns...)
+	}
+}
+
+// WithBeforeStop 服务停止前执行的钩子，在取消注册之后、gRPC 优雅关闭之前按顺序执行（如停止后台任务）
+func WithBeforeStop(fns ...func(ctx context.Context)) ServerOption {
+	return func(opts *serverOptions) {
+		opts.beforeStop = append(opts.beforeStop, fns...)
+	}
+}
+
+// WithGracefulStopTimeout gRPC 优雅关闭的超时时间，超时后强制关闭
+func WithGracefulStopTimeout(timeout time.Duration) ServerOption {
+	return func(opts *serverOptions) {
+		opts.gracefulStopTimeout = timeout
+	}
+}
+
 // WithClientRegistry set registry
 func WithClientRegistry(registry registry.Registrar) ClientOption {
 	return func(opts *clientOptions) {
@@ -19,3 +19,12 @@ func NewHistogramVec(opts prometheus.HistogramOpts, labelNames []string) *promet
 
 	return histogramVec
 }
+
+// NewGaugeVec ...
+func NewGaugeVec(opts prometheus.GaugeOpts, labelNames []string) *prometheus.GaugeVec {
+	gaugeVec := prometheus.NewGaugeVec(opts, labelNames)
+
+	prometheus.MustRegister(gaugeVec)
+
+	return gaugeVec
+}
@@ -279,10 +279,7 @@ func (r *Register) watch(ctx context.Context, key string, revision int64) {
 					Endpoints: []*registry.Endpoint{&endpoint},
 				})
 			case clientv3.EventTypeDelete:
-				var endpoint registry.Service
-				if err := json.Unmarshal(ev.Kv.Value, &endpoint); err != nil {
-					continue
-				}
+				// 删除事件不带 value，节点信息从 key 中解析
 				serviceName, ip, Port := r.getServiceNameByETCDKey(string(ev.Kv.Key))
 				r.delDownService(&registry.Service{
 					Name: serviceName,
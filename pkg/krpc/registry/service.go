@@ -11,4 +11,6 @@ type Endpoint struct {
 	Port       int    `json:"port"`
 	Weight     int    `json:"weight"`
 	Enable     bool   `json:"enable"`
+	// Metadata 节点元数据，调用方可据此选择指定的节点（如 Gateway 的 gateway_id）
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
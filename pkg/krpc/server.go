@@ -25,7 +25,8 @@ type KServer struct {
 
 func NewPServer(opts ...ServerOption) *KServer {
 	opt := serverOptions{
-		weight: 100, // default weight 100
+		weight:              100, // default weight 100
+		gracefulStopTimeout: 10 * time.Second,
 	}
 	for _, o := range opts {
 		o(&opt)
@@ -91,6 +92,7 @@ func (p *KServer) Start(ctx context.Context) {
 				Port:       p.port,
 				Weight:     p.weight,
 				Enable:     true,
+				Metadata:   p.metadata,
 			},
 		},
 	}
@@ -106,11 +108,7 @@ func (p *KServer) Start(ctx context.Context) {
 		sig := <-c
 		switch sig {
 		case syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGINT:
-			s.Stop()
-			if p.registry != nil {
-				p.registry.UnRegister(ctx, &service)
-			}
-			time.Sleep(time.Second)
+			p.shutdown(ctx, s, &service)
 			return
 		case syscall.SIGHUP:
 		default:
@@ -119,3 +117,39 @@ func (p *KServer) Start(ctx context.Context) {
 	}
 
 }
+
+// shutdown 停止服务：先在仍注册的状态下执行排空钩子，再取消注册，让调用方不再把新请求路由过来，
+// 最后执行停止前钩子并优雅关闭 gRPC
+func (p *KServer) shutdown(ctx context.Context, s *grpc.Server, service *registry.Service) {
+	// 排空期间节点保持注册，按节点元数据寻址的调用方（如 Push 按 gateway_id 下推）仍能找到本实例
+	for _, fn := range p.drain {
+		fn(ctx)
+	}
+
+	if p.registry != nil {
+		p.registry.UnRegister(ctx, service)
+	}
+	time.Sleep(time.Second)
+
+	for _, fn := range p.beforeStop {
+		fn(ctx)
+	}
+
+	p.gracefulStop(s)
+}
+
+// gracefulStop 等待进行中的请求处理完成后关闭，超时则强制关闭
+func (p *KServer) gracefulStop(s *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(p.gracefulStopTimeout):
+		log.Warn(context.Background(), "graceful stop timeout, force stop", log.Any("service", p.serviceName))
+		s.Stop()
+	}
+}
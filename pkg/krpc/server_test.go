@@ -0,0 +1,76 @@
+package krpc
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/wsx864321/kim/pkg/krpc/registry"
+	"google.golang.org/grpc"
+)
+
+// fakeRegistrar 记录注册状态和调用顺序
+type fakeRegistrar struct {
+	registry.Registrar
+
+	mu         sync.Mutex
+	registered bool
+	calls      []string
+}
+
+func (r *fakeRegistrar) Register(context.Context, *registry.Service) {
+	r.record("register")
+	r.mu.Lock()
+	r.registered = true
+	r.mu.Unlock()
+}
+
+func (r *fakeRegistrar) UnRegister(context.Context, *registry.Service) {
+	r.record("unregister")
+	r.mu.Lock()
+	r.registered = false
+	r.mu.Unlock()
+}
+
+func (r *fakeRegistrar) isRegistered() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.registered
+}
+
+func (r *fakeRegistrar) record(call string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func TestShutdownDrainBeforeUnRegister(t *testing.T) {
+	ctx := context.Background()
+	r := &fakeRegistrar{}
+	p := NewPServer(
+		WithServiceName("test"),
+		WithRegistry(r),
+		WithDrain(func(context.Context) {
+			// 排空期间节点仍然注册，调用方可以继续寻址
+			if !r.isRegistered() {
+				t.Error("endpoint unregistered during drain")
+			}
+			r.record("drain")
+		}),
+		WithBeforeStop(func(context.Context) {
+			if r.isRegistered() {
+				t.Error("endpoint still registered before stop")
+			}
+			r.record("before stop")
+		}),
+	)
+
+	service := &registry.Service{Name: "test"}
+	r.Register(ctx, service)
+	p.shutdown(ctx, grpc.NewServer(), service)
+
+	if want := []string{"register", "drain", "unregister", "before stop"}; !slices.Equal(r.calls, want) {
+		t.Fatalf("expected calls %v, got %v", want, r.calls)
+	}
+}
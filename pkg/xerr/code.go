@@ -24,6 +24,23 @@ const (
 	ErrSessionUserMismatchCode   int32 = 20007
 	ErrSessionAlreadyOfflineCode int32 = 20008
 	ErrSessionStateCorruptCode   int32 = 20009
+
+	// Message 模块错误码 30000 - 39999
+	ErrMessageInvalidCode         int32 = 30001
+	ErrMessageTypeUnsupportedCode int32 = 30002
+	ErrMessageTooLargeCode        int32 = 30003
+	ErrMessageInProgressCode      int32 = 30004
+	ErrMessageNotFoundCode        int32 = 30005
+	ErrMessageRecallDeniedCode    int32 = 30006
+	ErrMessageRecallExpiredCode   int32 = 30007
+	ErrConversationNotFoundCode   int32 = 30008
+	ErrMessageRejectedCode        int32 = 30009
+
+	// Group 模块错误码 40000 - 49999
+	ErrGroupNotFoundCode         int32 = 40001
+	ErrGroupNotMemberCode        int32 = 40002
+	ErrGroupPermissionDeniedCode int32 = 40003
+	ErrGroupFullCode             int32 = 40004
 )
 
 // 通用错误实例10000 - 19999
@@ -53,3 +70,24 @@ var (
 	ErrSessionAlreadyOffline = NewError(ErrSessionAlreadyOfflineCode, "already offline")
 	ErrSessionStateCorrupt   = NewError(ErrSessionStateCorruptCode, "session state corrupt")
 )
+
+// Message 模块错误实例30000 - 39999
+var (
+	ErrMessageInvalid         = NewError(ErrMessageInvalidCode, "invalid message")
+	ErrMessageTypeUnsupported = NewError(ErrMessageTypeUnsupportedCode, "unsupported message type")
+	ErrMessageTooLarge        = NewError(ErrMessageTooLargeCode, "message too large")
+	ErrMessageInProgress      = NewError(ErrMessageInProgressCode, "message is being processed")
+	ErrMessageNotFound        = NewError(ErrMessageNotFoundCode, "message not found")
+	ErrMessageRecallDenied    = NewError(ErrMessageRecallDeniedCode, "only the sender can recall the message")
+	ErrMessageRecallExpired   = NewError(ErrMessageRecallExpiredCode, "message recall window has expired")
+	ErrConversationNotFound   = NewError(ErrConversationNotFoundCode, "conversation not found")
+	ErrMessageRejected        = NewError(ErrMessageRejectedCode, "message rejected by moderation")
+)
+
+// Group 模块错误实例40000 - 49999
+var (
+	ErrGroupNotFound         = NewError(ErrGroupNotFoundCode, "group not found")
+	ErrGroupNotMember        = NewError(ErrGroupNotMemberCode, "not a group member")
+	ErrGroupPermissionDenied = NewError(ErrGroupPermissionDeniedCode, "group permission denied")
+	ErrGroupFull             = NewError(ErrGroupFullCode, "group is full")
+)